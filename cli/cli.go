@@ -1,9 +1,26 @@
 package cli
 
 import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/rit3sh-x/blaze/cli/deploy"
 	initpkg "github.com/rit3sh-x/blaze/cli/init"
+	"github.com/rit3sh-x/blaze/cli/migrate"
+	"github.com/rit3sh-x/blaze/core/ast"
 )
 
 func Init() {
 	initpkg.Init()
+}
+
+func Migrate(migrationName string, fromSchema *ast.SchemaAST, toSchema *ast.SchemaAST, concurrent bool) error {
+	if concurrent {
+		return migrate.GenerateConcurrentMigration(migrationName, fromSchema, toSchema)
+	}
+	return migrate.GenerateMigration(migrationName, fromSchema, toSchema)
+}
+
+func Deploy(pool *pgxpool.Pool, ctx context.Context) error {
+	return deploy.DeployMigrations(pool, ctx)
 }
\ No newline at end of file
@@ -0,0 +1,204 @@
+package deploy
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/rit3sh-x/blaze/core/constants"
+	"github.com/rit3sh-x/blaze/core/migration"
+)
+
+type appliedMigration struct {
+	Checksum     string
+	StepCount    int
+	AppliedSteps int
+}
+
+func DeployMigrations(pool *pgxpool.Pool, ctx context.Context) error {
+	if _, err := pool.Exec(ctx, constants.BLAZE_TABLE_QUERY); err != nil {
+		return fmt.Errorf(constants.RED+"failed to create %s table: %v"+constants.RESET, constants.MIGRATION_TABLE_NAME, err)
+	}
+
+	applied, err := fetchAppliedMigrations(pool, ctx)
+	if err != nil {
+		return err
+	}
+
+	names, err := readMigrationNames()
+	if err != nil {
+		return err
+	}
+
+	pending := 0
+	for _, name := range names {
+		content, err := os.ReadFile(filepath.Join(constants.MIGRATION_DIR, name, constants.QUERY_FILE_NAME))
+		if err != nil {
+			return fmt.Errorf(constants.RED+"failed to read migration %s: %v"+constants.RESET, name, err)
+		}
+
+		checksum := computeChecksum(content)
+		steps := migration.SplitMigrationSteps(string(content))
+
+		start := 0
+		if record, exists := applied[name]; exists {
+			if record.Checksum != checksum {
+				return fmt.Errorf(constants.RED+"migration %s was modified after it was applied"+constants.RESET, name)
+			}
+			if record.AppliedSteps >= record.StepCount {
+				continue
+			}
+			start = migration.ResumePoint(steps, record.AppliedSteps)
+			fmt.Printf(constants.YELLOW+"Resuming migration %s at step %d of %d"+constants.RESET+"\n", name, start+1, len(steps))
+		}
+
+		if err := applyMigration(pool, ctx, name, checksum, steps, start); err != nil {
+			return err
+		}
+
+		fmt.Printf(constants.GREEN+"✔ Applied migration %s (%d steps)"+constants.RESET+"\n", name, len(steps))
+		pending++
+	}
+
+	if pending == 0 {
+		fmt.Printf(constants.GREEN + "Database is up to date\n" + constants.RESET)
+	}
+
+	return nil
+}
+
+func applyMigration(pool *pgxpool.Pool, ctx context.Context, name string, checksum string, steps []migration.MigrationStep, start int) error {
+	conn, err := pool.Acquire(ctx)
+	if err != nil {
+		return fmt.Errorf(constants.RED+"failed to acquire connection: %v"+constants.RESET, err)
+	}
+	defer conn.Release()
+
+	record := func(exec func(context.Context, string, ...interface{}) (pgconn.CommandTag, error), appliedSteps int) error {
+		_, err := exec(ctx, constants.INSERT_MIGRATION, checksum, name, len(steps), appliedSteps)
+		return err
+	}
+
+	var tx pgx.Tx
+	commit := func(appliedSteps int) error {
+		if tx == nil {
+			return nil
+		}
+		if err := record(tx.Exec, appliedSteps); err != nil {
+			tx.Rollback(ctx)
+			tx = nil
+			return err
+		}
+		err := tx.Commit(ctx)
+		tx = nil
+		return err
+	}
+
+	if len(steps) == 0 {
+		if err := record(conn.Exec, 0); err != nil {
+			return fmt.Errorf(constants.RED+"failed to record migration %s: %v"+constants.RESET, name, err)
+		}
+		return nil
+	}
+
+	for i, step := range steps[:start] {
+		if strings.HasPrefix(step.SQL, "SET ") {
+			if _, err := conn.Exec(ctx, step.SQL); err != nil {
+				return fmt.Errorf(constants.RED+"migration %s: failed to restore session setting from step %d: %v"+constants.RESET, name, i+1, err)
+			}
+		}
+	}
+
+	for i := start; i < len(steps); i++ {
+		step := steps[i]
+		if step.NonTransactional {
+			if err := commit(i); err != nil {
+				return fmt.Errorf(constants.RED+"migration %s: failed to commit before step %d: %v"+constants.RESET, name, i+1, err)
+			}
+			if _, err := conn.Exec(ctx, step.SQL); err != nil {
+				return fmt.Errorf(constants.RED+"migration %s: step %d failed outside a transaction, rerun deploy to resume from it: %v"+constants.RESET, name, i+1, err)
+			}
+			if err := record(conn.Exec, i+1); err != nil {
+				return fmt.Errorf(constants.RED+"failed to record progress of migration %s: %v"+constants.RESET, name, err)
+			}
+			continue
+		}
+
+		if tx == nil {
+			if tx, err = conn.Begin(ctx); err != nil {
+				return fmt.Errorf(constants.RED+"migration %s: failed to begin transaction: %v"+constants.RESET, name, err)
+			}
+		}
+
+		if _, err := tx.Exec(ctx, step.SQL); err != nil {
+			tx.Rollback(ctx)
+			return fmt.Errorf(constants.RED+"migration %s: step %d failed: %v"+constants.RESET, name, i+1, err)
+		}
+	}
+
+	if err := commit(len(steps)); err != nil {
+		return fmt.Errorf(constants.RED+"migration %s: failed to commit: %v"+constants.RESET, name, err)
+	}
+
+	return nil
+}
+
+func fetchAppliedMigrations(pool *pgxpool.Pool, ctx context.Context) (map[string]appliedMigration, error) {
+	rows, err := pool.Query(ctx, constants.FETCH_ALL_MIGRATIONS)
+	if err != nil {
+		return nil, fmt.Errorf(constants.RED+"failed to fetch applied migrations: %v"+constants.RESET, err)
+	}
+	defer rows.Close()
+
+	applied := make(map[string]appliedMigration)
+	for rows.Next() {
+		var id, checksum, name string
+		var appliedAt time.Time
+		var stepCount, appliedSteps int
+		if err := rows.Scan(&id, &checksum, &appliedAt, &name, &stepCount, &appliedSteps); err != nil {
+			return nil, fmt.Errorf(constants.RED+"scan migration row error: %v"+constants.RESET, err)
+		}
+		applied[name] = appliedMigration{Checksum: checksum, StepCount: stepCount, AppliedSteps: appliedSteps}
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf(constants.RED+"row iteration error: %v"+constants.RESET, err)
+	}
+
+	return applied, nil
+}
+
+func readMigrationNames() ([]string, error) {
+	if _, err := os.Stat(constants.MIGRATION_DIR); os.IsNotExist(err) {
+		return []string{}, nil
+	}
+
+	entries, err := os.ReadDir(constants.MIGRATION_DIR)
+	if err != nil {
+		return nil, fmt.Errorf(constants.RED+"failed to read migration directory: %v"+constants.RESET, err)
+	}
+
+	var names []string
+	for _, entry := range entries {
+		if entry.IsDir() {
+			names = append(names, entry.Name())
+		}
+	}
+
+	sort.Strings(names)
+	return names, nil
+}
+
+func computeChecksum(content []byte) string {
+	sum := sha256.Sum256(content)
+	return hex.EncodeToString(sum[:])
+}
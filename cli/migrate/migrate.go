@@ -15,17 +15,40 @@ type MigrateCommand struct {
 	migrationName string
 	fromSchema    *ast.SchemaAST
 	toSchema      *ast.SchemaAST
+	options       migration.MigrationOptions
 }
 
 func NewMigrateCommand(migrationName string, fromSchema *ast.SchemaAST, toSchema *ast.SchemaAST) *MigrateCommand {
+	return NewMigrateCommandWithOptions(migrationName, fromSchema, toSchema, migration.MigrationOptions{})
+}
+
+func NewMigrateCommandWithOptions(migrationName string, fromSchema *ast.SchemaAST, toSchema *ast.SchemaAST, options migration.MigrationOptions) *MigrateCommand {
+	if options.Concurrent && options.LockTimeout == "" {
+		options.LockTimeout = constants.DEFAULT_LOCK_TIMEOUT
+	}
+	if options.Concurrent && options.StatementTimeout == "" {
+		options.StatementTimeout = constants.DEFAULT_STATEMENT_TIMEOUT
+	}
+	if options.Autoincrement == "" {
+		options.Autoincrement = os.Getenv(constants.AUTOINCREMENT_ENV)
+	}
+	if options.Autoincrement == "" {
+		options.Autoincrement = constants.AUTOINCREMENT_IDENTITY
+	}
+
 	return &MigrateCommand{
 		migrationName: migrationName,
 		fromSchema:    fromSchema,
 		toSchema:      toSchema,
+		options:       options,
 	}
 }
 
 func (mc *MigrateCommand) Execute() error {
+	if mc.options.Autoincrement != constants.AUTOINCREMENT_IDENTITY && mc.options.Autoincrement != constants.AUTOINCREMENT_SEQUENCE {
+		return fmt.Errorf("invalid %s value '%s': expected '%s' or '%s'", constants.AUTOINCREMENT_ENV, mc.options.Autoincrement, constants.AUTOINCREMENT_IDENTITY, constants.AUTOINCREMENT_SEQUENCE)
+	}
+
 	timestamp := time.Now().Format("20060102150405")
 
 	migrationFolderName := fmt.Sprintf("%s_%s", timestamp, mc.migrationName)
@@ -37,7 +60,7 @@ func (mc *MigrateCommand) Execute() error {
 		return fmt.Errorf("failed to create migration directory: %v", err)
 	}
 
-	engine := migration.NewMigrationEngine(mc.fromSchema, mc.toSchema)
+	engine := migration.NewMigrationEngineWithOptions(mc.fromSchema, mc.toSchema, mc.options)
 	migrationSQL, err := engine.GenerateMigration()
 	if err != nil {
 		return fmt.Errorf("failed to generate migration: %v", err)
@@ -54,10 +77,19 @@ func (mc *MigrateCommand) Execute() error {
 	fmt.Printf("  Directory: %s\n", migrationPath)
 	fmt.Printf("  SQL File: %s\n", queryFilePath)
 
+	for _, warning := range engine.Warnings() {
+		fmt.Printf("%sWarning: %s%s\n", constants.YELLOW, warning, constants.RESET)
+	}
+
 	return nil
 }
 
 func GenerateMigration(migrationName string, fromSchema *ast.SchemaAST, toSchema *ast.SchemaAST) error {
 	cmd := NewMigrateCommand(migrationName, fromSchema, toSchema)
 	return cmd.Execute()
+}
+
+func GenerateConcurrentMigration(migrationName string, fromSchema *ast.SchemaAST, toSchema *ast.SchemaAST) error {
+	cmd := NewMigrateCommandWithOptions(migrationName, fromSchema, toSchema, migration.MigrationOptions{Concurrent: true})
+	return cmd.Execute()
 }
\ No newline at end of file
@@ -20,12 +20,44 @@ func PullSchema(client *pgxpool.Pool, ctx context.Context) error {
 		log.Fatalf("failed to generate enum schema: %v", err)
 	}
 
-	classSchema, err := sync.GetClasses(client, ctx, arr)
+	sequenceSchema, err := sync.GetSequences(client, ctx)
+	if err != nil {
+		log.Fatalf("failed to generate sequence schema: %v", err)
+	}
+
+	domainSchema, domainBases, err := sync.GetDomains(client, ctx)
+	if err != nil {
+		log.Fatalf("failed to generate domain schema: %v", err)
+	}
+
+	for name := range domainBases {
+		arr = append(arr, name)
+	}
+
+	typeSchema, compositeNames, err := sync.GetCompositeTypes(client, ctx, arr)
+	if err != nil {
+		log.Fatalf("failed to generate type schema: %v", err)
+	}
+	arr = append(arr, compositeNames...)
+
+	classSchema, err := sync.GetClasses(client, ctx, arr, domainBases)
 	if err != nil {
 		log.Fatalf("failed to generate class schema: %v", err)
 	}
 
-	schemaAST, err := ast.BuildSchemaAST(enumSchema, classSchema)
+	viewSchema, err := sync.GetViews(client, ctx, arr)
+	if err != nil {
+		log.Fatalf("failed to generate view schema: %v", err)
+	}
+
+	schemaAST, err := ast.BuildSchemaAST(&ast.SchemaContent{
+		Enums:     enumSchema,
+		Sequences: sequenceSchema,
+		Types:     typeSchema,
+		Domains:   domainSchema,
+		Classes:   classSchema,
+		Views:     viewSchema,
+	})
 	if err != nil {
 		return fmt.Errorf("failed to build AST: %v", err)
 	}
@@ -35,15 +67,14 @@ func PullSchema(client *pgxpool.Pool, ctx context.Context) error {
 	}
 	var fullSchema strings.Builder
 
-	if enumSchema != "" {
-		fullSchema.WriteString(enumSchema)
-		if classSchema != "" {
+	for _, block := range []string{enumSchema, sequenceSchema, domainSchema, typeSchema, classSchema, viewSchema} {
+		if block == "" {
+			continue
+		}
+		if fullSchema.Len() != 0 {
 			fullSchema.WriteString("\n\n")
 		}
-	}
-
-	if classSchema != "" {
-		fullSchema.WriteString(classSchema)
+		fullSchema.WriteString(block)
 	}
 
 	if fullSchema.Len() != 0 {
@@ -15,12 +15,12 @@ func ValidateSchemaFile(filePath string) error {
 		return fmt.Errorf("schema file not found: %s", filePath)
 	}
 
-	enumContent, classContent, err := utils.ReadAndSeparateSchema(filePath)
+	content, err := utils.ReadAndSeparateSchema(filePath)
 	if err != nil {
 		return fmt.Errorf("failed to read schema file: %v", err)
 	}
 
-	schemaAST, err := ast.BuildSchemaAST(enumContent, classContent)
+	schemaAST, err := ast.BuildSchemaAST(content)
 	if err != nil {
 		return fmt.Errorf("failed to build AST: %v", err)
 	}
@@ -37,12 +37,12 @@ func ValidateSchemaFileWithDetails(filePath string) ([]validation.ValidationErro
 		return nil, fmt.Errorf("schema file not found: %s", filePath)
 	}
 
-	enumContent, classContent, err := utils.ReadAndSeparateSchema(filePath)
+	content, err := utils.ReadAndSeparateSchema(filePath)
 	if err != nil {
 		return nil, fmt.Errorf("failed to read schema file: %v", err)
 	}
 
-	schemaAST, err := ast.BuildSchemaAST(enumContent, classContent)
+	schemaAST, err := ast.BuildSchemaAST(content)
 	if err != nil {
 		return nil, fmt.Errorf("failed to build AST: %v", err)
 	}
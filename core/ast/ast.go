@@ -7,41 +7,91 @@ import (
 	"strings"
 
 	"github.com/rit3sh-x/blaze/core/ast/class"
+	"github.com/rit3sh-x/blaze/core/ast/composite"
+	"github.com/rit3sh-x/blaze/core/ast/domain"
 	"github.com/rit3sh-x/blaze/core/ast/enum"
+	"github.com/rit3sh-x/blaze/core/ast/sequence"
+	"github.com/rit3sh-x/blaze/core/ast/view"
 	"github.com/rit3sh-x/blaze/core/constants"
 )
 
 type SchemaAST struct {
-	Enums   map[string]*enum.Enum
-	Classes []*class.Class
+	Enums      map[string]*enum.Enum
+	Sequences  map[string]*sequence.Sequence
+	Composites map[string]*composite.CompositeType
+	Domains    map[string]*domain.Domain
+	Classes    []*class.Class
+	Views      map[string]*view.View
+}
+
+type SchemaContent struct {
+	Enums     string
+	Sequences string
+	Types     string
+	Domains   string
+	Classes   string
+	Views     string
 }
 
 type ASTBuilder struct {
-	enumValidator  *enum.EnumValidator
-	classValidator *class.ClassValidator
+	enumValidator      *enum.EnumValidator
+	sequenceValidator  *sequence.SequenceValidator
+	compositeValidator *composite.CompositeValidator
+	domainValidator    *domain.DomainValidator
+	classValidator     *class.ClassValidator
+	viewValidator      *view.ViewValidator
 }
 
 func NewASTBuilder() *ASTBuilder {
 	return &ASTBuilder{
-		enumValidator: enum.NewEnumValidator(),
+		enumValidator:      enum.NewEnumValidator(),
+		sequenceValidator:  sequence.NewSequenceValidator(),
+		compositeValidator: composite.NewCompositeValidator(),
+		domainValidator:    domain.NewDomainValidator(),
+		viewValidator:      view.NewViewValidator(),
 	}
 }
 
-func (ab *ASTBuilder) BuildAST(enumContent string, classContent string) (*SchemaAST, error) {
+func (ab *ASTBuilder) BuildAST(content *SchemaContent) (*SchemaAST, error) {
 	ast := &SchemaAST{
-		Enums:   make(map[string]*enum.Enum),
-		Classes: []*class.Class{},
+		Enums:      make(map[string]*enum.Enum),
+		Sequences:  make(map[string]*sequence.Sequence),
+		Composites: make(map[string]*composite.CompositeType),
+		Domains:    make(map[string]*domain.Domain),
+		Classes:    []*class.Class{},
+		Views:      make(map[string]*view.View),
 	}
 
-	if err := ab.parseEnums(enumContent, ast); err != nil {
+	if content == nil {
+		return ast, nil
+	}
+
+	if err := ab.parseEnums(content.Enums, ast); err != nil {
 		return nil, fmt.Errorf("enum parsing failed: %v", err)
 	}
 
+	if err := ab.parseSequences(content.Sequences, ast); err != nil {
+		return nil, fmt.Errorf("sequence parsing failed: %v", err)
+	}
+
+	if err := ab.parseDomains(content.Domains, ast); err != nil {
+		return nil, fmt.Errorf("domain parsing failed: %v", err)
+	}
+
+	if err := ab.parseComposites(content.Types, ast); err != nil {
+		return nil, fmt.Errorf("type parsing failed: %v", err)
+	}
+
 	ab.classValidator = class.NewClassValidator(ast.Enums)
+	ab.classValidator.RegisterUserTypes(ast.Composites, ast.Domains)
 
-	if err := ab.parseClasses(classContent, ast); err != nil {
+	if err := ab.parseClasses(content.Classes, ast); err != nil {
 		return nil, fmt.Errorf("class parsing failed: %v", err)
 	}
+
+	if err := ab.parseViews(content.Views, ast); err != nil {
+		return nil, fmt.Errorf("view parsing failed: %v", err)
+	}
 	return ast, nil
 }
 
@@ -65,6 +115,75 @@ func (ab *ASTBuilder) parseEnums(enumContent string, ast *SchemaAST) error {
     return nil
 }
 
+func (ab *ASTBuilder) parseSequences(sequenceContent string, ast *SchemaAST) error {
+	if strings.TrimSpace(sequenceContent) == "" {
+		return nil
+	}
+
+	sequencePattern := regexp.MustCompile(`(?m)^` + constants.KEYWORD_SEQUENCE + `\s+[a-zA-Z][a-zA-Z0-9_]{0,62}\s*\{[^{}]*\}`)
+	sequenceDefs := sequencePattern.FindAllString(sequenceContent, -1)
+
+	for i, sequenceDef := range sequenceDefs {
+		parsedSequence, err := ab.sequenceValidator.ParseSequence(sequenceDef, i)
+		if err != nil {
+			return fmt.Errorf("failed to parse sequence at position %d: %v", i, err)
+		}
+
+		if _, exists := ast.Sequences[parsedSequence.Name]; exists {
+			return fmt.Errorf("duplicate sequence '%s'", parsedSequence.Name)
+		}
+		ast.Sequences[parsedSequence.Name] = parsedSequence
+	}
+
+	return nil
+}
+
+func (ab *ASTBuilder) parseDomains(domainContent string, ast *SchemaAST) error {
+	if strings.TrimSpace(domainContent) == "" {
+		return nil
+	}
+
+	domainPattern := regexp.MustCompile(`(?m)^` + constants.KEYWORD_DOMAIN + `\s+[A-Za-z_][A-Za-z0-9_]*\s*=.*$`)
+	domainDefs := domainPattern.FindAllString(domainContent, -1)
+
+	for i, domainDef := range domainDefs {
+		parsedDomain, err := ab.domainValidator.ParseDomain(domainDef, i)
+		if err != nil {
+			return fmt.Errorf("failed to parse domain at position %d: %v", i, err)
+		}
+
+		if _, exists := ast.Domains[parsedDomain.Name]; exists {
+			return fmt.Errorf("duplicate domain '%s'", parsedDomain.Name)
+		}
+		ast.Domains[parsedDomain.Name] = parsedDomain
+	}
+
+	return nil
+}
+
+func (ab *ASTBuilder) parseComposites(typeContent string, ast *SchemaAST) error {
+	if strings.TrimSpace(typeContent) == "" {
+		return nil
+	}
+
+	typePattern := regexp.MustCompile(`(?m)^` + constants.KEYWORD_TYPE + `\s+[A-Za-z_][A-Za-z0-9_]*\s*\{[^{}]*\}`)
+	typeDefs := typePattern.FindAllString(typeContent, -1)
+
+	for i, typeDef := range typeDefs {
+		parsedType, err := ab.compositeValidator.ParseComposite(typeDef, i)
+		if err != nil {
+			return fmt.Errorf("failed to parse type at position %d: %v", i, err)
+		}
+
+		if _, exists := ast.Composites[parsedType.Name]; exists {
+			return fmt.Errorf("duplicate type '%s'", parsedType.Name)
+		}
+		ast.Composites[parsedType.Name] = parsedType
+	}
+
+	return nil
+}
+
 func (ab *ASTBuilder) parseClasses(classContent string, ast *SchemaAST) error {
     if strings.TrimSpace(classContent) == "" {
         return nil
@@ -84,18 +203,57 @@ func (ab *ASTBuilder) parseClasses(classContent string, ast *SchemaAST) error {
     return nil
 }
 
+func (ab *ASTBuilder) parseViews(viewContent string, ast *SchemaAST) error {
+	if strings.TrimSpace(viewContent) == "" {
+		return nil
+	}
+
+	viewPattern := regexp.MustCompile(`(?m)^(?:` + constants.KEYWORD_VIEW + `|` + constants.KEYWORD_MATERIALIZED_VIEW + `)\s+[A-Za-z_][A-Za-z0-9_]*\s*\{(?:[^{}"]|"(?:[^"\\]|\\.)*")*\}(?:\s*@@` + constants.VIEW_ATTR_SQL + `\("(?:[^"\\]|\\.)*"\))?`)
+	viewDefs := viewPattern.FindAllString(viewContent, -1)
+
+	for i, viewDef := range viewDefs {
+		parsedView, err := ab.viewValidator.ParseView(viewDef, i)
+		if err != nil {
+			return fmt.Errorf("failed to parse view at position %d: %v", i, err)
+		}
+
+		if _, exists := ast.Views[parsedView.Name]; exists {
+			return fmt.Errorf("duplicate view '%s'", parsedView.Name)
+		}
+		ast.Views[parsedView.Name] = parsedView
+	}
+
+	return nil
+}
+
 func (ast *SchemaAST) String() string {
 	var parts []string
-	parts = append(parts, fmt.Sprintf("Schema AST: %d enums, %d classes", len(ast.Enums), len(ast.Classes)))
+	parts = append(parts, fmt.Sprintf("Schema AST: %d enums, %d sequences, %d types, %d domains, %d classes, %d views", len(ast.Enums), len(ast.Sequences), len(ast.Composites), len(ast.Domains), len(ast.Classes), len(ast.Views)))
 
 	for _, e := range ast.Enums {
 		parts = append(parts, fmt.Sprintf("  enum %s", e.Name))
 	}
 
+	for _, s := range ast.Sequences {
+		parts = append(parts, fmt.Sprintf("  sequence %s", s.Name))
+	}
+
+	for _, t := range ast.Composites {
+		parts = append(parts, fmt.Sprintf("  type %s (%d fields)", t.Name, len(t.Fields)))
+	}
+
+	for _, d := range ast.Domains {
+		parts = append(parts, fmt.Sprintf("  domain %s", d.Name))
+	}
+
 	for _, c := range ast.Classes {
 		parts = append(parts, fmt.Sprintf("  class %s (%d fields)", c.Name, len(c.Attributes.Fields)))
 	}
 
+	for _, v := range ast.Views {
+		parts = append(parts, fmt.Sprintf("  %s %s (%d fields)", v.Keyword(), v.Name, len(v.Fields)))
+	}
+
 	return strings.Join(parts, "\n")
 }
 
@@ -108,6 +266,117 @@ func (ast *SchemaAST) GetEnumByName(name string) *enum.Enum {
 	return nil
 }
 
+func (ast *SchemaAST) GetSequenceByName(name string) *sequence.Sequence {
+	if ast.Sequences == nil {
+		return nil
+	}
+	return ast.Sequences[name]
+}
+
+func (ast *SchemaAST) GetCompositeByName(name string) *composite.CompositeType {
+	if ast.Composites == nil {
+		return nil
+	}
+	return ast.Composites[name]
+}
+
+func (ast *SchemaAST) GetDomainByName(name string) *domain.Domain {
+	if ast.Domains == nil {
+		return nil
+	}
+	return ast.Domains[name]
+}
+
+func (ast *SchemaAST) GetCompositeOrder() []*composite.CompositeType {
+	var names []string
+	for name := range ast.Composites {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	placed := make(map[string]bool)
+	var result []*composite.CompositeType
+
+	var place func(name string, visiting map[string]bool)
+	place = func(name string, visiting map[string]bool) {
+		if placed[name] || visiting[name] {
+			return
+		}
+		visiting[name] = true
+		for _, fld := range ast.Composites[name].Fields {
+			if _, exists := ast.Composites[fld.Type]; exists {
+				place(fld.Type, visiting)
+			}
+		}
+		placed[name] = true
+		result = append(result, ast.Composites[name])
+	}
+
+	for _, name := range names {
+		place(name, make(map[string]bool))
+	}
+
+	return result
+}
+
+func (ast *SchemaAST) GetViewByName(name string) *view.View {
+	if ast.Views == nil {
+		return nil
+	}
+	return ast.Views[name]
+}
+
+func (ast *SchemaAST) GetViewDependencies(v *view.View) []string {
+	var dependencies []string
+	for _, cls := range ast.Classes {
+		if v.References(cls.Name) {
+			dependencies = append(dependencies, cls.Name)
+		}
+	}
+
+	var names []string
+	for name := range ast.Views {
+		if name != v.Name && v.References(name) {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	return append(dependencies, names...)
+}
+
+func (ast *SchemaAST) GetViewOrder() []*view.View {
+	var names []string
+	for name := range ast.Views {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	placed := make(map[string]bool)
+	var result []*view.View
+
+	var place func(name string, visiting map[string]bool)
+	place = func(name string, visiting map[string]bool) {
+		if placed[name] || visiting[name] {
+			return
+		}
+		visiting[name] = true
+		for _, dependency := range ast.GetViewDependencies(ast.Views[name]) {
+			if _, exists := ast.Views[dependency]; exists {
+				place(dependency, visiting)
+			}
+		}
+		placed[name] = true
+		result = append(result, ast.Views[name])
+	}
+
+	for _, name := range names {
+		place(name, make(map[string]bool))
+	}
+
+	return result
+}
+
 func (ast *SchemaAST) GetClassByName(name string) *class.Class {
 	for _, c := range ast.Classes {
 		if c.Name == name {
@@ -135,57 +404,101 @@ func (ast *SchemaAST) GetAllFieldTypes() []string {
 	return types
 }
 
-func (ast *SchemaAST) GetDependencyOrder() ([]*class.Class, error) {
-	graph := make(map[string][]string)
-	inDegree := make(map[string]int)
-
-	for _, cls := range ast.Classes {
-		graph[cls.Name] = []string{}
-		inDegree[cls.Name] = 0
-	}
+type CyclicReference struct {
+	Class  string
+	Field  string
+	Target string
+}
 
+func (ast *SchemaAST) GetDependencyOrder() ([]*class.Class, []CyclicReference) {
+	dependencies := make(map[string]map[string]bool)
 	for _, cls := range ast.Classes {
-		for _, field := range cls.Attributes.Fields {
-			baseType := field.GetBaseType()
-			if ast.GetClassByName(baseType) != nil {
-				graph[cls.Name] = append(graph[cls.Name], baseType)
-				inDegree[baseType]++
+		dependencies[cls.Name] = make(map[string]bool)
+		for _, fld := range cls.Attributes.Fields {
+			if !fld.HasRelation() {
+				continue
+			}
+			target := fld.AttributeDefinition.Relation.ToClass
+			if target != cls.Name && ast.GetClassByName(target) != nil {
+				dependencies[cls.Name][target] = true
 			}
 		}
 	}
 
-	var queue []string
-	for className, degree := range inDegree {
-		if degree == 0 {
-			queue = append(queue, className)
-		}
-	}
-
+	placed := make(map[string]bool)
 	var result []*class.Class
-	for len(queue) > 0 {
-		current := queue[0]
-		queue = queue[1:]
+	var cycles []CyclicReference
 
-		if cls := ast.GetClassByName(current); cls != nil {
+	for len(result) < len(ast.Classes) {
+		progressed := false
+		for _, cls := range ast.Classes {
+			if placed[cls.Name] || !ast.dependenciesPlaced(dependencies[cls.Name], placed) {
+				continue
+			}
+			placed[cls.Name] = true
 			result = append(result, cls)
+			progressed = true
+		}
+
+		if progressed {
+			continue
 		}
 
-		for _, neighbor := range graph[current] {
-			inDegree[neighbor]--
-			if inDegree[neighbor] == 0 {
-				queue = append(queue, neighbor)
+		for _, cls := range ast.Classes {
+			if placed[cls.Name] || !ast.reachesUnplaced(cls.Name, cls.Name, dependencies, placed, make(map[string]bool)) {
+				continue
+			}
+			for _, fld := range cls.Attributes.Fields {
+				if !fld.HasRelation() {
+					continue
+				}
+				target := fld.AttributeDefinition.Relation.ToClass
+				if dependencies[cls.Name][target] && !placed[target] && ast.reachesUnplaced(target, cls.Name, dependencies, placed, make(map[string]bool)) {
+					cycles = append(cycles, CyclicReference{
+						Class:  cls.Name,
+						Field:  fld.GetName(),
+						Target: target,
+					})
+				}
 			}
+			placed[cls.Name] = true
+			result = append(result, cls)
+			break
 		}
 	}
 
-	if len(result) != len(ast.Classes) {
-		return nil, fmt.Errorf("circular dependency detected")
+	return result, cycles
+}
+
+func (ast *SchemaAST) dependenciesPlaced(dependencies map[string]bool, placed map[string]bool) bool {
+	for target := range dependencies {
+		if !placed[target] {
+			return false
+		}
 	}
+	return true
+}
 
-	return result, nil
+func (ast *SchemaAST) reachesUnplaced(from string, target string, dependencies map[string]map[string]bool, placed map[string]bool, visited map[string]bool) bool {
+	for next := range dependencies[from] {
+		if placed[next] {
+			continue
+		}
+		if next == target {
+			return true
+		}
+		if visited[next] {
+			continue
+		}
+		visited[next] = true
+		if ast.reachesUnplaced(next, target, dependencies, placed, visited) {
+			return true
+		}
+	}
+	return false
 }
 
-func BuildSchemaAST(enumContent string, classContent string) (*SchemaAST, error) {
+func BuildSchemaAST(content *SchemaContent) (*SchemaAST, error) {
 	builder := NewASTBuilder()
-	return builder.BuildAST(enumContent, classContent)
+	return builder.BuildAST(content)
 }
\ No newline at end of file
@@ -0,0 +1,216 @@
+package ast
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestGetDependencyOrder(t *testing.T) {
+	tests := []struct {
+		name    string
+		classes string
+		order   []string
+		cycles  []CyclicReference
+	}{
+		{
+			name: "independent classes keep declaration order",
+			classes: `class B {
+  id Int @primaryKey
+}
+
+class A {
+  id Int @primaryKey
+}`,
+			order: []string{"B", "A"},
+		},
+		{
+			name: "referenced class comes first",
+			classes: `class Post {
+  id Int @primaryKey
+  authorId Int
+  author User @relation([authorId], [id])
+}
+
+class User {
+  id Int @primaryKey
+  posts Post[]
+}`,
+			order: []string{"User", "Post"},
+		},
+		{
+			name: "self relation is not a dependency",
+			classes: `class Node {
+  id Int @primaryKey
+  parentId Int?
+  parent Node? @relation([parentId], [id])
+  children Node[]
+}`,
+			order: []string{"Node"},
+		},
+		{
+			name: "chain",
+			classes: `class C {
+  id Int @primaryKey
+  bId Int
+  b B @relation([bId], [id])
+}
+
+class B {
+  id Int @primaryKey
+  aId Int
+  a A @relation([aId], [id])
+  cs C[]
+}
+
+class A {
+  id Int @primaryKey
+  bs B[]
+}`,
+			order: []string{"A", "B", "C"},
+		},
+		{
+			name: "two class cycle breaks one edge",
+			classes: `class Post {
+  id Int @primaryKey
+  authorId Int
+  author User @relation([authorId], [id])
+}
+
+class User {
+  id Int @primaryKey
+  teamId Int?
+  team Team? @relation([teamId], [id], deferrable: true)
+  posts Post[]
+}
+
+class Team {
+  id Int @primaryKey
+  ownerId Int
+  owner User @relation([ownerId], [id], deferrable: true)
+  members User[]
+}`,
+			order:  []string{"User", "Post", "Team"},
+			cycles: []CyclicReference{{Class: "User", Field: "team", Target: "Team"}},
+		},
+		{
+			name: "three class cycle",
+			classes: `class A {
+  id Int @primaryKey
+  bId Int?
+  b B? @relation([bId], [id])
+  cs C[]
+}
+
+class B {
+  id Int @primaryKey
+  cId Int?
+  c C? @relation([cId], [id])
+  as A[]
+}
+
+class C {
+  id Int @primaryKey
+  aId Int?
+  a A? @relation([aId], [id])
+  bs B[]
+}`,
+			order:  []string{"A", "C", "B"},
+			cycles: []CyclicReference{{Class: "A", Field: "b", Target: "B"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			schema, err := BuildSchemaAST(&SchemaContent{Classes: tt.classes})
+			if err != nil {
+				t.Fatalf("BuildSchemaAST() error: %v", err)
+			}
+
+			classes, cycles := schema.GetDependencyOrder()
+			var order []string
+			for _, cls := range classes {
+				order = append(order, cls.Name)
+			}
+			if !reflect.DeepEqual(order, tt.order) {
+				t.Errorf("order = %v, want %v", order, tt.order)
+			}
+			if !reflect.DeepEqual(cycles, tt.cycles) {
+				t.Errorf("cycles = %v, want %v", cycles, tt.cycles)
+			}
+		})
+	}
+}
+
+func TestBuildSchemaASTRejects(t *testing.T) {
+	tests := []struct {
+		name    string
+		classes string
+		err     string
+	}{
+		{
+			name: "duplicate unnamed indexes",
+			classes: `class Doc {
+id Int @primaryKey
+title String
+@@index([title])
+@@index([title], type: Hash)
+}`,
+			err: "duplicate index name 'idx_doc_title_index'",
+		},
+		{
+			name: "named index colliding with a derived name",
+			classes: `class Doc {
+id Int @primaryKey
+title String
+@@index([id], name: "idx_doc_title_index")
+@@index([title])
+}`,
+			err: "duplicate index name 'idx_doc_title_index'",
+		},
+		{
+			name: "now default on an interval",
+			classes: `class Shift {
+id Int @primaryKey
+length Interval @default(now())
+}`,
+			err: "callback 'now()' is not compatible with field type 'Interval'",
+		},
+		{
+			name: "policy without row level security",
+			classes: `class Doc {
+id Int @primaryKey
+tenantId Int
+@@policy(tenant_isolation, using: "true")
+}`,
+			err: "@@policy requires row level security to be enabled with @@rls",
+		},
+		{
+			name: "partition without a partition key",
+			classes: `class Event {
+id Int @primaryKey
+createdAt Timestamp
+@@partition(Event_old, default: true)
+}`,
+			err: "partition 'Event_old' requires @@partitionBy on the class",
+		},
+		{
+			name: "default partition on a hash key",
+			classes: `class Bucket {
+id Int @primaryKey
+@@partitionBy(hash: [id])
+@@partition(Bucket_x, default: true)
+}`,
+			err: "hash partitioning does not support a default partition",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := BuildSchemaAST(&SchemaContent{Classes: tt.classes})
+			if err == nil || !strings.Contains(err.Error(), tt.err) {
+				t.Fatalf("BuildSchemaAST() error = %v, want %q", err, tt.err)
+			}
+		})
+	}
+}
@@ -128,13 +128,20 @@ func (ap *AttributeParser) parseClassDirective(name, params string, attributes *
 	}
 
 	switch name {
-	case constants.CLASS_ATTR_PRIMARY_KEY, constants.CLASS_ATTR_UNIQUE, constants.CLASS_ATTR_INDEX, constants.CLASS_ATTR_TEXT_INDEX:
+	case constants.CLASS_ATTR_PRIMARY_KEY, constants.CLASS_ATTR_UNIQUE:
 		fields, err := ap.parseFieldArray(params)
 		if err != nil {
 			return fmt.Errorf("failed to parse field array for @@%s: %v", name, err)
 		}
 		directive.Value = fields
 
+	case constants.CLASS_ATTR_INDEX, constants.CLASS_ATTR_TEXT_INDEX:
+		index, err := directives.ParseIndex(params)
+		if err != nil {
+			return fmt.Errorf("failed to parse index for @@%s: %v", name, err)
+		}
+		directive.Value = index
+
 	case constants.CLASS_ATTR_CHECK:
 		if params == "" {
 			return fmt.Errorf("@@check requires a constraint expression")
@@ -145,6 +152,39 @@ func (ap *AttributeParser) parseClassDirective(name, params string, attributes *
 		}
 		directive.Value = constraint
 
+	case constants.CLASS_ATTR_RLS:
+		if params != "" {
+			return fmt.Errorf("@@rls does not take parameters")
+		}
+
+	case constants.CLASS_ATTR_POLICY:
+		policy, err := directives.ParsePolicy(params)
+		if err != nil {
+			return fmt.Errorf("failed to parse policy for @@%s: %v", name, err)
+		}
+		directive.Value = policy
+
+	case constants.CLASS_ATTR_PARTITION_BY:
+		partitionBy, err := directives.ParsePartitionBy(params)
+		if err != nil {
+			return fmt.Errorf("failed to parse partition key for @@%s: %v", name, err)
+		}
+		directive.Value = partitionBy
+
+	case constants.CLASS_ATTR_PARTITION:
+		partition, err := directives.ParsePartition(params)
+		if err != nil {
+			return fmt.Errorf("failed to parse partition for @@%s: %v", name, err)
+		}
+		directive.Value = partition
+
+	case constants.CLASS_ATTR_EXCLUDE:
+		exclusion, err := directives.ParseExclusion(params)
+		if err != nil {
+			return fmt.Errorf("failed to parse exclusion for @@%s: %v", name, err)
+		}
+		directive.Value = exclusion
+
 	default:
 		return fmt.Errorf("unknown class directive '@@%s'", name)
 	}
@@ -216,6 +256,33 @@ func (ap *AttributeParser) validateClassAttributes(attributes *ClassAttributes,
 		return fmt.Errorf("directive-field validation failed: %v", err)
 	}
 
+	if err := ap.validateIndexNames(attributes, cls); err != nil {
+		return fmt.Errorf("index validation failed: %v", err)
+	}
+
+	return nil
+}
+
+func (ap *AttributeParser) validateIndexNames(attributes *ClassAttributes, cls string) error {
+	names := make(map[string]bool)
+	for _, directive := range attributes.Directives {
+		isText := directive.Name == constants.CLASS_ATTR_TEXT_INDEX
+		if directive.Name != constants.CLASS_ATTR_INDEX && !isText {
+			continue
+		}
+
+		index, err := directive.GetIndex()
+		if err != nil {
+			continue
+		}
+
+		name := index.GetName(cls, isText)
+		if names[name] {
+			return fmt.Errorf("duplicate index name '%s' in @@%s; give one of the indexes a distinct name", name, directive.Name)
+		}
+		names[name] = true
+	}
+
 	return nil
 }
 
@@ -233,11 +300,35 @@ func (ap *AttributeParser) validateDirectiveFieldReferences(attributes *ClassAtt
 				return fmt.Errorf("failed to get fields from directive @@%s: %v", directive.Name, err)
 			}
 
+			if index, err := directive.GetIndex(); err == nil {
+				fields = append(fields, index.Include...)
+			}
+
 			for _, fieldName := range fields {
 				if !fieldNames[fieldName] {
 					return fmt.Errorf("directive @@%s references non-existent field '%s'", directive.Name, fieldName)
 				}
 			}
+		case constants.CLASS_ATTR_PARTITION_BY:
+			partitionBy, err := directive.GetPartitionBy()
+			if err != nil {
+				return err
+			}
+			for _, fieldName := range partitionBy.Columns {
+				if !fieldNames[fieldName] {
+					return fmt.Errorf("directive @@%s references non-existent field '%s'", directive.Name, fieldName)
+				}
+			}
+		case constants.CLASS_ATTR_EXCLUDE:
+			exclusion, err := directive.GetExclusion()
+			if err != nil {
+				return err
+			}
+			for _, fieldName := range exclusion.GetFields() {
+				if !fieldNames[fieldName] {
+					return fmt.Errorf("directive @@%s references non-existent field '%s'", directive.Name, fieldName)
+				}
+			}
 		}
 	}
 
@@ -281,10 +372,61 @@ func (ca *ClassAttributes) GetTextIndexDirective() *directives.ClassDirective {
 	return ca.GetDirectiveByName(constants.CLASS_ATTR_TEXT_INDEX)
 }
 
+func (ca *ClassAttributes) GetIndexDirectives() []*directives.ClassDirective {
+	return directives.GetClassDirectivesByName(ca.Directives, constants.CLASS_ATTR_INDEX)
+}
+
+func (ca *ClassAttributes) GetTextIndexDirectives() []*directives.ClassDirective {
+	return directives.GetClassDirectivesByName(ca.Directives, constants.CLASS_ATTR_TEXT_INDEX)
+}
+
 func (ca *ClassAttributes) GetCheckDirective() *directives.ClassDirective {
 	return ca.GetDirectiveByName(constants.CLASS_ATTR_CHECK)
 }
 
+func (ca *ClassAttributes) GetRLSDirective() *directives.ClassDirective {
+	return ca.GetDirectiveByName(constants.CLASS_ATTR_RLS)
+}
+
+func (ca *ClassAttributes) GetPolicies() []*directives.Policy {
+	var policies []*directives.Policy
+	for _, directive := range directives.GetClassPolicyDirectives(ca.Directives) {
+		if policy, err := directive.GetPolicy(); err == nil {
+			policies = append(policies, policy)
+		}
+	}
+	return policies
+}
+
+func (ca *ClassAttributes) GetPartitionBy() *directives.PartitionBy {
+	if directive := ca.GetDirectiveByName(constants.CLASS_ATTR_PARTITION_BY); directive != nil {
+		if partitionBy, err := directive.GetPartitionBy(); err == nil {
+			return partitionBy
+		}
+	}
+	return nil
+}
+
+func (ca *ClassAttributes) GetPartitions() []*directives.Partition {
+	var partitions []*directives.Partition
+	for _, directive := range directives.GetClassPartitionDirectives(ca.Directives) {
+		if partition, err := directive.GetPartition(); err == nil {
+			partitions = append(partitions, partition)
+		}
+	}
+	return partitions
+}
+
+func (ca *ClassAttributes) GetExclusions() []*directives.Exclusion {
+	var exclusions []*directives.Exclusion
+	for _, directive := range directives.GetClassExcludeDirectives(ca.Directives) {
+		if exclusion, err := directive.GetExclusion(); err == nil {
+			exclusions = append(exclusions, exclusion)
+		}
+	}
+	return exclusions
+}
+
 func (ca *ClassAttributes) HasPrimaryKey() bool {
 	return ca.HasDirective(constants.CLASS_ATTR_PRIMARY_KEY)
 }
@@ -305,6 +447,10 @@ func (ca *ClassAttributes) HasCheck() bool {
 	return ca.HasDirective(constants.CLASS_ATTR_CHECK)
 }
 
+func (ca *ClassAttributes) HasRLS() bool {
+	return ca.HasDirective(constants.CLASS_ATTR_RLS)
+}
+
 func (ca *ClassAttributes) GetFieldNames() []string {
 	var names []string
 	for _, field := range ca.Fields {
@@ -4,8 +4,12 @@ import (
 	"fmt"
 	"regexp"
 	"strings"
+	"time"
 
 	"github.com/rit3sh-x/blaze/core/ast/class/attributes"
+	"github.com/rit3sh-x/blaze/core/ast/class/directives"
+	"github.com/rit3sh-x/blaze/core/ast/composite"
+	"github.com/rit3sh-x/blaze/core/ast/domain"
 	"github.com/rit3sh-x/blaze/core/ast/enum"
 	"github.com/rit3sh-x/blaze/core/ast/field"
 	"github.com/rit3sh-x/blaze/core/constants"
@@ -75,9 +79,24 @@ func (cv *ClassValidator) ParseClass(definition string, position int) (*Class, e
 func (cv *ClassValidator) validateBalancedBraces(content string) error {
     braceCount := 0
     parenCount := 0
+    inString := false
+    escaped := false
     
     for _, char := range content {
+        if inString {
+            if escaped {
+                escaped = false
+            } else if char == '\\' {
+                escaped = true
+            } else if char == '"' {
+                inString = false
+            }
+            continue
+        }
+
         switch char {
+        case '"':
+            inString = true
         case '{':
             braceCount++
         case '}':
@@ -240,6 +259,52 @@ func (c *Class) GetPrimaryKeyFields() []string {
 	return c.Attributes.GetPrimaryKeyFields()
 }
 
+func (c *Class) IsPartitioned() bool {
+	return c.Attributes.GetPartitionBy() != nil
+}
+
+func (c *Class) GetTablePrimaryKeyFields() []string {
+	pkFields := c.GetPrimaryKeyFields()
+	partitionBy := c.Attributes.GetPartitionBy()
+	if partitionBy == nil || len(pkFields) == 0 {
+		return pkFields
+	}
+
+	merged := append([]string{}, pkFields...)
+	for _, column := range partitionBy.Columns {
+		found := false
+		for _, pkField := range pkFields {
+			if pkField == column {
+				found = true
+				break
+			}
+		}
+		if !found {
+			merged = append(merged, column)
+		}
+	}
+	return merged
+}
+
+func (c *Class) GetPartitions(now time.Time) []*directives.Partition {
+	partitions := c.Attributes.GetPartitions()
+	partitionBy := c.Attributes.GetPartitionBy()
+	if partitionBy == nil {
+		return partitions
+	}
+
+	declared := make(map[string]bool)
+	for _, partition := range partitions {
+		declared[partition.Name] = true
+	}
+	for _, partition := range partitionBy.IntervalPartitions(c.Name, now) {
+		if !declared[partition.Name] {
+			partitions = append(partitions, partition)
+		}
+	}
+	return partitions
+}
+
 func (c *Class) GetRelationFields() []*field.Field {
 	return c.Attributes.GetRelationFields()
 }
@@ -256,6 +321,16 @@ func (cv *ClassValidator) GetEnumRegistry() map[string]*enum.Enum {
 	return cv.enumRegistry
 }
 
+func (cv *ClassValidator) RegisterUserTypes(composites map[string]*composite.CompositeType, domains map[string]*domain.Domain) {
+	defaultValidator := cv.attributeParser.GetFieldValidator().GetAttributeValidator().GetDefaultValidator()
+	for name := range composites {
+		defaultValidator.RegisterComposite(name)
+	}
+	for name, d := range domains {
+		defaultValidator.RegisterDomain(name, d.BaseType)
+	}
+}
+
 func (cv *ClassValidator) GetAttributeParser() *attributes.AttributeParser {
 	return cv.attributeParser
 }
\ No newline at end of file
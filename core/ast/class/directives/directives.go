@@ -35,6 +35,16 @@ func (av *DirectiveValidator) ValidateClassDirective(attr *ClassDirective) error
 		return av.validateClassTextIndexDirective(attr)
 	case constants.CLASS_ATTR_CHECK:
 		return av.validateClassCheckDirective(attr)
+	case constants.CLASS_ATTR_RLS:
+		return av.validateClassRLSDirective(attr)
+	case constants.CLASS_ATTR_POLICY:
+		return av.validateClassPolicyDirective(attr)
+	case constants.CLASS_ATTR_PARTITION_BY:
+		return av.validateClassPartitionByDirective(attr)
+	case constants.CLASS_ATTR_PARTITION:
+		return av.validateClassPartitionDirective(attr)
+	case constants.CLASS_ATTR_EXCLUDE:
+		return av.validateClassExcludeDirective(attr)
 	default:
 		return fmt.Errorf("unknown class directive '@@%s'", attr.Name)
 	}
@@ -79,13 +89,13 @@ func (av *DirectiveValidator) validateClassIndexDirective(attr *ClassDirective)
 		return fmt.Errorf("@@index directive requires field array parameter")
 	}
 
-	fields, ok := attr.Value.([]string)
+	index, ok := attr.Value.(*Index)
 	if !ok {
-		return fmt.Errorf("@@index directive value must be an array of field names")
+		return fmt.Errorf("@@index directive value must be an index definition")
 	}
 
-	if len(fields) == 0 {
-		return fmt.Errorf("@@index directive requires at least one field")
+	if err := index.Validate(false); err != nil {
+		return fmt.Errorf("@@index directive is invalid: %v", err)
 	}
 
 	return nil
@@ -96,13 +106,13 @@ func (av *DirectiveValidator) validateClassTextIndexDirective(attr *ClassDirecti
 		return fmt.Errorf("@@textIndex directive requires field array parameter")
 	}
 
-	fields, ok := attr.Value.([]string)
+	index, ok := attr.Value.(*Index)
 	if !ok {
-		return fmt.Errorf("@@textIndex directive value must be an array of field names")
+		return fmt.Errorf("@@textIndex directive value must be an index definition")
 	}
 
-	if len(fields) == 0 {
-		return fmt.Errorf("@@textIndex directive requires at least one field")
+	if err := index.Validate(true); err != nil {
+		return fmt.Errorf("@@textIndex directive is invalid: %v", err)
 	}
 
 	return nil
@@ -126,19 +136,126 @@ func (av *DirectiveValidator) validateClassCheckDirective(attr *ClassDirective)
 	return nil
 }
 
+func (av *DirectiveValidator) validateClassRLSDirective(attr *ClassDirective) error {
+	if attr.Value != nil {
+		return fmt.Errorf("@@rls directive does not take parameters")
+	}
+
+	return nil
+}
+
+func (av *DirectiveValidator) validateClassPolicyDirective(attr *ClassDirective) error {
+	if attr.Value == nil {
+		return fmt.Errorf("@@policy directive requires a policy definition")
+	}
+
+	policy, ok := attr.Value.(*Policy)
+	if !ok {
+		return fmt.Errorf("@@policy directive value must be a policy definition")
+	}
+
+	if err := policy.Validate(); err != nil {
+		return fmt.Errorf("@@policy directive is invalid: %v", err)
+	}
+
+	return nil
+}
+
+func (av *DirectiveValidator) validateClassPartitionByDirective(attr *ClassDirective) error {
+	if attr.Value == nil {
+		return fmt.Errorf("@@partitionBy directive requires a partition strategy")
+	}
+
+	partitionBy, ok := attr.Value.(*PartitionBy)
+	if !ok {
+		return fmt.Errorf("@@partitionBy directive value must be a partition key definition")
+	}
+
+	if err := partitionBy.Validate(); err != nil {
+		return fmt.Errorf("@@partitionBy directive is invalid: %v", err)
+	}
+
+	return nil
+}
+
+func (av *DirectiveValidator) validateClassPartitionDirective(attr *ClassDirective) error {
+	if attr.Value == nil {
+		return fmt.Errorf("@@partition directive requires a partition definition")
+	}
+
+	if _, ok := attr.Value.(*Partition); !ok {
+		return fmt.Errorf("@@partition directive value must be a partition definition")
+	}
+
+	return nil
+}
+
+func (av *DirectiveValidator) validateClassExcludeDirective(attr *ClassDirective) error {
+	if attr.Value == nil {
+		return fmt.Errorf("@@exclude directive requires an exclusion definition")
+	}
+
+	exclusion, ok := attr.Value.(*Exclusion)
+	if !ok {
+		return fmt.Errorf("@@exclude directive value must be an exclusion definition")
+	}
+
+	if err := exclusion.Validate(); err != nil {
+		return fmt.Errorf("@@exclude directive is invalid: %v", err)
+	}
+
+	return nil
+}
+
 func (av *DirectiveValidator) ValidateMultipleClassDirectives(attrs []*ClassDirective) error {
 	if len(attrs) == 0 {
 		return nil
 	}
 
 	directiveCount := make(map[string]int)
+	indexNames := make(map[string]bool)
+	policyNames := make(map[string]bool)
+	partitionNames := make(map[string]bool)
+	exclusionNames := make(map[string]bool)
+	var partitions []*Partition
+	var partitionBy *PartitionBy
 
 	for _, attr := range attrs {
 		if attr == nil {
 			continue
 		}
 
-		directiveCount[attr.Name]++
+		if index, ok := attr.Value.(*Index); ok {
+			if index.Name != "" {
+				if indexNames[index.Name] {
+					return fmt.Errorf("duplicate index name '%s' found", index.Name)
+				}
+				indexNames[index.Name] = true
+			}
+		} else if policy, ok := attr.Value.(*Policy); ok {
+			if policyNames[policy.Name] {
+				return fmt.Errorf("duplicate policy name '%s' found", policy.Name)
+			}
+			policyNames[policy.Name] = true
+		} else if partition, ok := attr.Value.(*Partition); ok {
+			if partitionNames[partition.Name] {
+				return fmt.Errorf("duplicate partition name '%s' found", partition.Name)
+			}
+			partitionNames[partition.Name] = true
+			partitions = append(partitions, partition)
+		} else if exclusion, ok := attr.Value.(*Exclusion); ok {
+			if exclusion.Name != "" {
+				if exclusionNames[exclusion.Name] {
+					return fmt.Errorf("duplicate exclusion name '%s' found", exclusion.Name)
+				}
+				exclusionNames[exclusion.Name] = true
+			}
+		} else {
+			if key, ok := attr.Value.(*PartitionBy); ok {
+				partitionBy = key
+			}
+			directiveCount[attr.Name]++
+		}
 
 		if directiveCount[attr.Name] > 1 {
 			return fmt.Errorf("duplicate class directive '@@%s' found", attr.Name)
@@ -149,6 +266,23 @@ func (av *DirectiveValidator) ValidateMultipleClassDirectives(attrs []*ClassDire
 		}
 	}
 
+	if len(policyNames) > 0 && directiveCount[constants.CLASS_ATTR_RLS] == 0 {
+		return fmt.Errorf("@@policy requires row level security to be enabled with @@rls")
+	}
+
+	defaults := 0
+	for _, partition := range partitions {
+		if err := partition.Validate(partitionBy); err != nil {
+			return fmt.Errorf("@@partition directive is invalid: %v", err)
+		}
+		if partition.Default {
+			defaults++
+		}
+	}
+	if defaults > 1 {
+		return fmt.Errorf("only one default partition is allowed")
+	}
+
 	return nil
 }
 
@@ -161,6 +295,16 @@ func GetClassDirectiveByName(attrs []*ClassDirective, name string) *ClassDirecti
 	return nil
 }
 
+func GetClassDirectivesByName(attrs []*ClassDirective, name string) []*ClassDirective {
+	var result []*ClassDirective
+	for _, attr := range attrs {
+		if attr != nil && attr.Name == name {
+			result = append(result, attr)
+		}
+	}
+	return result
+}
+
 func HasClassDirective(attrs []*ClassDirective, name string) bool {
 	return GetClassDirectiveByName(attrs, name) != nil
 }
@@ -185,6 +329,26 @@ func GetClassCheckDirective(attrs []*ClassDirective) *ClassDirective {
 	return GetClassDirectiveByName(attrs, constants.CLASS_ATTR_CHECK)
 }
 
+func GetClassRLSDirective(attrs []*ClassDirective) *ClassDirective {
+	return GetClassDirectiveByName(attrs, constants.CLASS_ATTR_RLS)
+}
+
+func GetClassPolicyDirectives(attrs []*ClassDirective) []*ClassDirective {
+	return GetClassDirectivesByName(attrs, constants.CLASS_ATTR_POLICY)
+}
+
+func GetClassPartitionByDirective(attrs []*ClassDirective) *ClassDirective {
+	return GetClassDirectiveByName(attrs, constants.CLASS_ATTR_PARTITION_BY)
+}
+
+func GetClassPartitionDirectives(attrs []*ClassDirective) []*ClassDirective {
+	return GetClassDirectivesByName(attrs, constants.CLASS_ATTR_PARTITION)
+}
+
+func GetClassExcludeDirectives(attrs []*ClassDirective) []*ClassDirective {
+	return GetClassDirectivesByName(attrs, constants.CLASS_ATTR_EXCLUDE)
+}
+
 func HasClassPrimaryKey(attrs []*ClassDirective) bool {
 	return HasClassDirective(attrs, constants.CLASS_ATTR_PRIMARY_KEY)
 }
@@ -205,6 +369,10 @@ func HasClassCheck(attrs []*ClassDirective) bool {
 	return HasClassDirective(attrs, constants.CLASS_ATTR_CHECK)
 }
 
+func HasClassRLS(attrs []*ClassDirective) bool {
+	return HasClassDirective(attrs, constants.CLASS_ATTR_RLS)
+}
+
 func (cd *ClassDirective) String() string {
 	return fmt.Sprintf("@@%s", cd.Name)
 }
@@ -218,6 +386,10 @@ func (cd *ClassDirective) GetFields() ([]string, error) {
 		return nil, fmt.Errorf("directive @@%s has no value", cd.Name)
 	}
 
+	if index, ok := cd.Value.(*Index); ok {
+		return index.GetFields(), nil
+	}
+
 	fields, ok := cd.Value.([]string)
 	if !ok {
 		return nil, fmt.Errorf("directive @@%s value is not a string array", cd.Name)
@@ -226,6 +398,19 @@ func (cd *ClassDirective) GetFields() ([]string, error) {
 	return fields, nil
 }
 
+func (cd *ClassDirective) GetIndex() (*Index, error) {
+	if cd.Value == nil {
+		return nil, fmt.Errorf("directive @@%s has no value", cd.Name)
+	}
+
+	index, ok := cd.Value.(*Index)
+	if !ok {
+		return nil, fmt.Errorf("directive @@%s value is not an index definition", cd.Name)
+	}
+
+	return index, nil
+}
+
 func (cd *ClassDirective) GetConstraint() (string, error) {
 	if cd.Value == nil {
 		return "", fmt.Errorf("directive @@%s has no value", cd.Name)
@@ -238,3 +423,55 @@ func (cd *ClassDirective) GetConstraint() (string, error) {
 
 	return constraint, nil
 }
+
+func (cd *ClassDirective) GetPolicy() (*Policy, error) {
+	if cd.Value == nil {
+		return nil, fmt.Errorf("directive @@%s has no value", cd.Name)
+	}
+
+	policy, ok := cd.Value.(*Policy)
+	if !ok {
+		return nil, fmt.Errorf("directive @@%s value is not a policy definition", cd.Name)
+	}
+
+	return policy, nil
+}
+
+func (cd *ClassDirective) GetPartitionBy() (*PartitionBy, error) {
+	if cd.Value == nil {
+		return nil, fmt.Errorf("directive @@%s has no value", cd.Name)
+	}
+
+	partitionBy, ok := cd.Value.(*PartitionBy)
+	if !ok {
+		return nil, fmt.Errorf("directive @@%s value is not a partition key definition", cd.Name)
+	}
+
+	return partitionBy, nil
+}
+
+func (cd *ClassDirective) GetPartition() (*Partition, error) {
+	if cd.Value == nil {
+		return nil, fmt.Errorf("directive @@%s has no value", cd.Name)
+	}
+
+	partition, ok := cd.Value.(*Partition)
+	if !ok {
+		return nil, fmt.Errorf("directive @@%s value is not a partition definition", cd.Name)
+	}
+
+	return partition, nil
+}
+
+func (cd *ClassDirective) GetExclusion() (*Exclusion, error) {
+	if cd.Value == nil {
+		return nil, fmt.Errorf("directive @@%s has no value", cd.Name)
+	}
+
+	exclusion, ok := cd.Value.(*Exclusion)
+	if !ok {
+		return nil, fmt.Errorf("directive @@%s value is not an exclusion definition", cd.Name)
+	}
+
+	return exclusion, nil
+}
@@ -0,0 +1,257 @@
+package directives
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/rit3sh-x/blaze/core/constants"
+)
+
+type ExclusionElement struct {
+	Field      string
+	Expression string
+	Operator   string
+}
+
+type Exclusion struct {
+	Elements []ExclusionElement
+	Type     string
+	Where    string
+	Name     string
+}
+
+var (
+	exclusionElementPattern  = regexp.MustCompile(`(?is)^(.+?)\s+WITH\s+(\S+)$`)
+	exclusionOperatorPattern = regexp.MustCompile(`^[-+*/<>=~!@#%^&|` + "`" + `?]+$`)
+	exclusionSQLPattern      = regexp.MustCompile(`(?is)^(?:CONSTRAINT\s+"?([a-zA-Z0-9_]+)"?\s+)?EXCLUDE\s+USING\s+([a-zA-Z_]+)\s*`)
+)
+
+func ParseExclusion(params string) (*Exclusion, error) {
+	params = strings.TrimSpace(params)
+	if params == "" {
+		return nil, fmt.Errorf("exclusion definition cannot be empty")
+	}
+
+	exclusion := &Exclusion{}
+
+	for _, item := range splitTopLevel(params) {
+		matches := indexOptionPattern.FindStringSubmatch(item)
+		if matches == nil {
+			if exclusion.Elements != nil {
+				return nil, fmt.Errorf("exclusion elements specified more than once")
+			}
+			elements, err := parseExclusionElements(item)
+			if err != nil {
+				return nil, err
+			}
+			exclusion.Elements = elements
+			continue
+		}
+
+		key := matches[1]
+		value := strings.TrimSpace(matches[2])
+
+		switch key {
+		case "using":
+			exclusion.Type = value
+		case "where":
+			exclusion.Where = unquoteIndexValue(value)
+		case "name":
+			exclusion.Name = unquoteIndexValue(value)
+		default:
+			return nil, fmt.Errorf("unknown exclusion parameter: %s", key)
+		}
+	}
+
+	if len(exclusion.Elements) == 0 {
+		return nil, fmt.Errorf("exclusion requires at least one element")
+	}
+
+	return exclusion, nil
+}
+
+func parseExclusionElements(value string) ([]ExclusionElement, error) {
+	value = strings.TrimSpace(value)
+	if !strings.HasPrefix(value, "[") || !strings.HasSuffix(value, "]") {
+		return nil, fmt.Errorf("exclusion elements must be enclosed in brackets")
+	}
+
+	var elements []ExclusionElement
+	for _, item := range splitTopLevel(value[1 : len(value)-1]) {
+		element, err := parseExclusionElement(item, indexFieldPattern)
+		if err != nil {
+			return nil, err
+		}
+		elements = append(elements, element)
+	}
+
+	return elements, nil
+}
+
+func parseExclusionElement(item string, fieldPattern *regexp.Regexp) (ExclusionElement, error) {
+	matches := exclusionElementPattern.FindStringSubmatch(strings.TrimSpace(item))
+	if matches == nil {
+		return ExclusionElement{}, fmt.Errorf("invalid exclusion element '%s', expected '<field> WITH <operator>'", item)
+	}
+
+	element := ExclusionElement{Operator: matches[2]}
+	target := strings.TrimSpace(matches[1])
+
+	if fieldMatches := fieldPattern.FindStringSubmatch(target); fieldMatches != nil {
+		element.Field = fieldMatches[len(fieldMatches)-1]
+	} else {
+		element.Expression = stripWrappingParens(target)
+	}
+
+	return element, nil
+}
+
+func (ex *Exclusion) Validate() error {
+	if len(ex.Elements) == 0 {
+		return fmt.Errorf("exclusion requires at least one element")
+	}
+
+	if ex.Type != "" && !constants.IsValidExclusionIndexType(ex.Type) {
+		return fmt.Errorf("invalid exclusion index type '%s'. Valid types: %v", ex.Type, constants.ValidExclusionIndexTypes)
+	}
+
+	for _, element := range ex.Elements {
+		if !exclusionOperatorPattern.MatchString(element.Operator) {
+			return fmt.Errorf("invalid exclusion operator '%s'", element.Operator)
+		}
+	}
+
+	if ex.Name != "" && !indexNamePattern.MatchString(ex.Name) {
+		return fmt.Errorf("invalid exclusion name '%s'", ex.Name)
+	}
+
+	return nil
+}
+
+func (ex *Exclusion) GetType() string {
+	if ex.Type == "" {
+		return constants.INDEX_TYPE_GIST
+	}
+	return ex.Type
+}
+
+func (ex *Exclusion) GetFields() []string {
+	var fields []string
+	for _, element := range ex.Elements {
+		if element.Field != "" {
+			fields = append(fields, element.Field)
+		}
+	}
+	return fields
+}
+
+func (ex *Exclusion) GetName(className string) string {
+	if ex.Name != "" {
+		return ex.Name
+	}
+
+	var parts []string
+	for _, element := range ex.Elements {
+		if element.Field != "" {
+			parts = append(parts, element.Field)
+		} else {
+			parts = append(parts, strings.Trim(indexSanitizePattern.ReplaceAllString(element.Expression, "_"), "_"))
+		}
+	}
+
+	return strings.ToLower(fmt.Sprintf("excl_%s_%s", className, strings.Join(parts, "_")))
+}
+
+func (ex *Exclusion) NeedsBtreeGist() bool {
+	if ex.GetType() != constants.INDEX_TYPE_GIST {
+		return false
+	}
+	for _, element := range ex.Elements {
+		if element.Operator == "=" || element.Operator == "<>" {
+			return true
+		}
+	}
+	return false
+}
+
+func (ex *Exclusion) ClauseSQL() string {
+	elements := make([]string, len(ex.Elements))
+	for i, element := range ex.Elements {
+		target := fmt.Sprintf(`"%s"`, element.Field)
+		if element.Field == "" {
+			target = fmt.Sprintf("(%s)", element.Expression)
+		}
+		elements[i] = fmt.Sprintf("%s WITH %s", target, element.Operator)
+	}
+
+	clause := fmt.Sprintf("EXCLUDE USING %s (%s)", constants.PGIndexMethodMapping[ex.GetType()], strings.Join(elements, ", "))
+	if ex.Where != "" {
+		clause += fmt.Sprintf(" WHERE (%s)", ex.Where)
+	}
+
+	return clause
+}
+
+func (ex *Exclusion) SQL(className string) string {
+	return fmt.Sprintf(`CONSTRAINT "%s" %s`, ex.GetName(className), ex.ClauseSQL())
+}
+
+func (ex *Exclusion) Equals(other *Exclusion) bool {
+	return ex.ClauseSQL() == other.ClauseSQL()
+}
+
+func (ex *Exclusion) String() string {
+	elements := make([]string, len(ex.Elements))
+	for i, element := range ex.Elements {
+		target := element.Field
+		if element.Field == "" {
+			target = fmt.Sprintf("(%s)", element.Expression)
+		}
+		elements[i] = fmt.Sprintf("%s WITH %s", target, element.Operator)
+	}
+
+	parts := []string{fmt.Sprintf("using: %s", ex.GetType()), fmt.Sprintf("[%s]", strings.Join(elements, ", "))}
+	if ex.Where != "" {
+		parts = append(parts, fmt.Sprintf("where: \"%s\"", ex.Where))
+	}
+	if ex.Name != "" {
+		parts = append(parts, fmt.Sprintf("name: \"%s\"", ex.Name))
+	}
+
+	return strings.Join(parts, ", ")
+}
+
+func ParseExclusionSQL(definition string) (*Exclusion, string, error) {
+	definition = strings.TrimSpace(strings.TrimSuffix(strings.TrimSpace(definition), ";"))
+
+	matches := exclusionSQLPattern.FindStringSubmatch(definition)
+	if matches == nil {
+		return nil, "", fmt.Errorf("unrecognized exclusion definition: %s", definition)
+	}
+
+	exclusion := &Exclusion{}
+	if indexType, ok := constants.ReversePGIndexMethodMapping[strings.ToLower(matches[2])]; ok && indexType != constants.INDEX_TYPE_GIST {
+		exclusion.Type = indexType
+	}
+
+	elementList, rest, err := splitParenthesized(definition[len(matches[0]):])
+	if err != nil {
+		return nil, "", err
+	}
+
+	for _, item := range splitTopLevel(elementList) {
+		element, err := parseExclusionElement(item, indexSQLColumnPattern)
+		if err != nil {
+			return nil, "", err
+		}
+		exclusion.Elements = append(exclusion.Elements, element)
+	}
+
+	rest = strings.TrimSpace(rest)
+	if len(rest) >= len("WHERE") && strings.EqualFold(rest[:len("WHERE")], "WHERE") {
+		exclusion.Where = stripWrappingParens(strings.TrimSpace(rest[len("WHERE"):]))
+	}
+
+	return exclusion, matches[1], nil
+}
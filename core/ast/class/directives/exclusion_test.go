@@ -0,0 +1,103 @@
+package directives
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseExclusion(t *testing.T) {
+	tests := []struct {
+		name      string
+		params    string
+		want      *Exclusion
+		sql       string
+		btreeGist bool
+		err       string
+	}{
+		{
+			name:   "fields with a predicate",
+			params: `using: Gist, [roomId WITH =, during WITH &&], where: "NOT cancelled"`,
+			want: &Exclusion{
+				Elements: []ExclusionElement{{Field: "roomId", Operator: "="}, {Field: "during", Operator: "&&"}},
+				Type:     "Gist",
+				Where:    "NOT cancelled",
+			},
+			sql:       `CONSTRAINT "excl_booking_roomid_during" EXCLUDE USING gist ("roomId" WITH =, "during" WITH &&) WHERE (NOT cancelled)`,
+			btreeGist: true,
+		},
+		{
+			name:   "named expression element",
+			params: `[(upper(during)) WITH &&], name: "booking_end_once"`,
+			want: &Exclusion{
+				Elements: []ExclusionElement{{Expression: "upper(during)", Operator: "&&"}},
+				Name:     "booking_end_once",
+			},
+			sql: `CONSTRAINT "booking_end_once" EXCLUDE USING gist ((upper(during)) WITH &&)`,
+		},
+		{name: "empty", params: "", err: "exclusion definition cannot be empty"},
+		{name: "no elements", params: `using: Gist`, err: "exclusion requires at least one element"},
+		{name: "elements twice", params: `[a WITH =], [b WITH =]`, err: "exclusion elements specified more than once"},
+		{name: "unbracketed elements", params: `a WITH =`, err: "exclusion elements must be enclosed in brackets"},
+		{name: "missing operator", params: `[roomId]`, err: "invalid exclusion element 'roomId'"},
+		{name: "unknown parameter", params: `[a WITH =], deferrable: true`, err: "unknown exclusion parameter: deferrable"},
+		{name: "invalid type", params: `using: Gin, [a WITH =]`, err: "invalid exclusion index type 'Gin'"},
+		{name: "invalid operator", params: `[a WITH overlaps]`, err: "invalid exclusion operator 'overlaps'"},
+		{name: "invalid name", params: `[a WITH =], name: "bad name"`, err: "invalid exclusion name 'bad name'"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exclusion, err := ParseExclusion(tt.params)
+			if err == nil {
+				err = exclusion.Validate()
+			}
+			if tt.err != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.err) {
+					t.Fatalf("error = %v, want %q", err, tt.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(exclusion, tt.want) {
+				t.Errorf("ParseExclusion() = %#v, want %#v", exclusion, tt.want)
+			}
+			sql := exclusion.SQL("Booking")
+			if sql != tt.sql {
+				t.Errorf("SQL() = %s, want %s", sql, tt.sql)
+			}
+			if exclusion.NeedsBtreeGist() != tt.btreeGist {
+				t.Errorf("NeedsBtreeGist() = %v, want %v", exclusion.NeedsBtreeGist(), tt.btreeGist)
+			}
+
+			reparsed, err := ParseExclusion(exclusion.String())
+			if err != nil || !reparsed.Equals(exclusion) || reparsed.GetName("Booking") != exclusion.GetName("Booking") {
+				t.Errorf("String() = %s does not parse back: %v", exclusion.String(), err)
+			}
+			introspected, name, err := ParseExclusionSQL(sql)
+			if err != nil || name != exclusion.GetName("Booking") || !introspected.Equals(exclusion) {
+				t.Errorf("ParseExclusionSQL(%s) = %#v named %s, %v", sql, introspected, name, err)
+			}
+		})
+	}
+}
+
+func TestParseExclusionSQL(t *testing.T) {
+	exclusion, name, err := ParseExclusionSQL(`EXCLUDE USING gist ("roomId" WITH =, during WITH &&) WHERE ((NOT cancelled))`)
+	if err != nil {
+		t.Fatalf("ParseExclusionSQL() error: %v", err)
+	}
+	want := &Exclusion{
+		Elements: []ExclusionElement{{Field: "roomId", Operator: "="}, {Field: "during", Operator: "&&"}},
+		Where:    "NOT cancelled",
+	}
+	if name != "" || !reflect.DeepEqual(exclusion, want) {
+		t.Errorf("ParseExclusionSQL() = %#v named %q, want %#v", exclusion, name, want)
+	}
+
+	if _, _, err := ParseExclusionSQL(`UNIQUE ("roomId")`); err == nil {
+		t.Errorf("ParseExclusionSQL() accepted a unique constraint")
+	}
+}
@@ -0,0 +1,454 @@
+package directives
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/rit3sh-x/blaze/core/constants"
+)
+
+type IndexColumn struct {
+	Field      string
+	Expression string
+	Sort       string
+}
+
+type Index struct {
+	Columns []IndexColumn
+	Type    string
+	Where   string
+	Include []string
+	Name    string
+}
+
+var (
+	indexOptionPattern     = regexp.MustCompile(`(?s)^([a-zA-Z_][a-zA-Z0-9_]*)\s*:\s*(.+)$`)
+	indexFieldPattern      = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]*$`)
+	indexSortedPattern     = regexp.MustCompile(`^([a-zA-Z_][a-zA-Z0-9_]*)\s*\(\s*sort\s*:\s*([a-zA-Z]+)\s*\)$`)
+	indexNamePattern       = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]{0,62}$`)
+	indexSQLPattern        = regexp.MustCompile(`(?is)^CREATE\s+(?:UNIQUE\s+)?INDEX\s+(?:CONCURRENTLY\s+)?(?:IF\s+NOT\s+EXISTS\s+)?"?([a-zA-Z0-9_]+)"?\s+ON\s+(?:ONLY\s+)?(?:[a-zA-Z0-9_]+\.)?"?([a-zA-Z0-9_]+)"?\s*(?:USING\s+([a-zA-Z]+)\s*)?\(`)
+	indexSQLColumnPattern  = regexp.MustCompile(`^"?([a-zA-Z_][a-zA-Z0-9_]*)"?$`)
+	indexSQLSortPattern    = regexp.MustCompile(`(?i)\s+(ASC|DESC)(?:\s+NULLS\s+(?:FIRST|LAST))?$`)
+	indexSQLLiteralPattern = regexp.MustCompile(`'[^']*'(?:::[a-zA-Z]+)?`)
+	indexSQLIdentPattern   = regexp.MustCompile(`"([^"]+)"|\b([a-zA-Z_][a-zA-Z0-9_]*)\b`)
+	indexSanitizePattern   = regexp.MustCompile(`[^a-zA-Z0-9]+`)
+)
+
+func ParseIndex(params string) (*Index, error) {
+	params = strings.TrimSpace(params)
+	if params == "" {
+		return nil, fmt.Errorf("index definition cannot be empty")
+	}
+
+	index := &Index{}
+	items := splitTopLevel(params)
+
+	hasOptions := false
+	for _, item := range items {
+		if indexOptionPattern.MatchString(item) {
+			hasOptions = true
+			break
+		}
+	}
+
+	if !hasOptions && !strings.HasPrefix(params, "[") {
+		params = "[" + params + "]"
+		items = []string{params}
+	}
+
+	for _, item := range items {
+		matches := indexOptionPattern.FindStringSubmatch(item)
+		if matches == nil {
+			if index.Columns != nil {
+				return nil, fmt.Errorf("index fields specified more than once")
+			}
+			columns, err := parseIndexColumns(item)
+			if err != nil {
+				return nil, err
+			}
+			index.Columns = columns
+			continue
+		}
+
+		key := matches[1]
+		value := strings.TrimSpace(matches[2])
+
+		switch key {
+		case "fields":
+			if index.Columns != nil {
+				return nil, fmt.Errorf("index fields specified more than once")
+			}
+			columns, err := parseIndexColumns(value)
+			if err != nil {
+				return nil, err
+			}
+			index.Columns = columns
+		case "type":
+			index.Type = value
+		case "where":
+			index.Where = unquoteIndexValue(value)
+		case "include":
+			include, err := parseIndexFieldList(value)
+			if err != nil {
+				return nil, fmt.Errorf("invalid include list: %v", err)
+			}
+			index.Include = include
+		case "name":
+			index.Name = unquoteIndexValue(value)
+		default:
+			return nil, fmt.Errorf("unknown index parameter: %s", key)
+		}
+	}
+
+	if len(index.Columns) == 0 {
+		return nil, fmt.Errorf("index requires at least one field")
+	}
+
+	return index, nil
+}
+
+func parseIndexColumns(value string) ([]IndexColumn, error) {
+	value = strings.TrimSpace(value)
+	if !strings.HasPrefix(value, "[") || !strings.HasSuffix(value, "]") {
+		return nil, fmt.Errorf("index fields must be enclosed in brackets")
+	}
+
+	var columns []IndexColumn
+	for _, item := range splitTopLevel(value[1 : len(value)-1]) {
+		if matches := indexSortedPattern.FindStringSubmatch(item); matches != nil {
+			columns = append(columns, IndexColumn{Field: matches[1], Sort: matches[2]})
+		} else if indexFieldPattern.MatchString(item) {
+			columns = append(columns, IndexColumn{Field: item})
+		} else if strings.Contains(item, "(") {
+			columns = append(columns, IndexColumn{Expression: item})
+		} else {
+			return nil, fmt.Errorf("invalid index field '%s'", item)
+		}
+	}
+
+	if len(columns) == 0 {
+		return nil, fmt.Errorf("index fields cannot be empty")
+	}
+
+	return columns, nil
+}
+
+func parseIndexFieldList(value string) ([]string, error) {
+	value = strings.TrimSpace(value)
+	if !strings.HasPrefix(value, "[") || !strings.HasSuffix(value, "]") {
+		return nil, fmt.Errorf("field list must be enclosed in brackets")
+	}
+
+	var fields []string
+	for _, item := range splitTopLevel(value[1 : len(value)-1]) {
+		if !indexFieldPattern.MatchString(item) {
+			return nil, fmt.Errorf("invalid field name '%s'", item)
+		}
+		fields = append(fields, item)
+	}
+
+	if len(fields) == 0 {
+		return nil, fmt.Errorf("field list cannot be empty")
+	}
+
+	return fields, nil
+}
+
+func unquoteIndexValue(value string) string {
+	if len(value) >= 2 && strings.HasPrefix(value, "\"") && strings.HasSuffix(value, "\"") {
+		return value[1 : len(value)-1]
+	}
+	return value
+}
+
+func splitTopLevel(input string) []string {
+	var parts []string
+	var current strings.Builder
+	depth := 0
+	var quote rune
+	escaped := false
+
+	for _, r := range input {
+		switch {
+		case escaped:
+			escaped = false
+		case quote != 0 && r == '\\':
+			escaped = true
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			}
+		case r == '"' || r == '\'':
+			quote = r
+		case r == '(' || r == '[':
+			depth++
+		case r == ')' || r == ']':
+			depth--
+		case r == ',' && depth == 0:
+			if part := strings.TrimSpace(current.String()); part != "" {
+				parts = append(parts, part)
+			}
+			current.Reset()
+			continue
+		}
+		current.WriteRune(r)
+	}
+
+	if part := strings.TrimSpace(current.String()); part != "" {
+		parts = append(parts, part)
+	}
+
+	return parts
+}
+
+func (idx *Index) Validate(isText bool) error {
+	if len(idx.Columns) == 0 {
+		return fmt.Errorf("index requires at least one field")
+	}
+
+	if idx.Type != "" && !constants.IsValidIndexType(idx.Type) {
+		return fmt.Errorf("invalid index type '%s'. Valid types: %v", idx.Type, constants.ValidIndexTypes)
+	}
+
+	if isText && idx.Type != "" && idx.Type != constants.INDEX_TYPE_GIN && idx.Type != constants.INDEX_TYPE_GIST {
+		return fmt.Errorf("text index type must be %s or %s", constants.INDEX_TYPE_GIN, constants.INDEX_TYPE_GIST)
+	}
+
+	for _, column := range idx.Columns {
+		if column.Sort != "" && !constants.IsValidIndexSort(column.Sort) {
+			return fmt.Errorf("invalid sort order '%s'. Valid values: %v", column.Sort, constants.ValidIndexSorts)
+		}
+		if column.Sort != "" && idx.GetType() != constants.INDEX_TYPE_BTREE {
+			return fmt.Errorf("sort order is only supported by %s indexes", constants.INDEX_TYPE_BTREE)
+		}
+		if isText && column.Expression != "" {
+			return fmt.Errorf("text index does not support expressions")
+		}
+	}
+
+	if idx.GetType() == constants.INDEX_TYPE_HASH && len(idx.Columns) > 1 {
+		return fmt.Errorf("%s index supports only a single field", constants.INDEX_TYPE_HASH)
+	}
+
+	if len(idx.Include) > 0 && (isText || (idx.GetType() != constants.INDEX_TYPE_BTREE && idx.GetType() != constants.INDEX_TYPE_GIST)) {
+		return fmt.Errorf("include is only supported by %s and %s indexes", constants.INDEX_TYPE_BTREE, constants.INDEX_TYPE_GIST)
+	}
+
+	if idx.Name != "" && !indexNamePattern.MatchString(idx.Name) {
+		return fmt.Errorf("invalid index name '%s'", idx.Name)
+	}
+
+	return nil
+}
+
+func (idx *Index) GetType() string {
+	if idx.Type == "" {
+		return constants.INDEX_TYPE_BTREE
+	}
+	return idx.Type
+}
+
+func (idx *Index) GetFields() []string {
+	var fields []string
+	for _, column := range idx.Columns {
+		if column.Field != "" {
+			fields = append(fields, column.Field)
+		}
+	}
+	return fields
+}
+
+func (idx *Index) GetName(className string, isText bool) string {
+	if idx.Name != "" {
+		return idx.Name
+	}
+
+	var parts []string
+	for _, column := range idx.Columns {
+		if column.Field != "" {
+			parts = append(parts, column.Field)
+		} else {
+			parts = append(parts, strings.Trim(indexSanitizePattern.ReplaceAllString(column.Expression, "_"), "_"))
+		}
+	}
+
+	suffix := "index"
+	if isText {
+		suffix = "text_index"
+	}
+
+	return strings.ToLower(fmt.Sprintf("idx_%s_%s_%s", className, strings.Join(parts, "_"), suffix))
+}
+
+func (idx *Index) IsSimple() bool {
+	if idx.Type != "" || idx.Where != "" || len(idx.Include) > 0 || idx.Name != "" {
+		return false
+	}
+	for _, column := range idx.Columns {
+		if column.Field == "" || column.Sort != "" {
+			return false
+		}
+	}
+	return true
+}
+
+func (idx *Index) String() string {
+	var columns []string
+	for _, column := range idx.Columns {
+		switch {
+		case column.Expression != "":
+			columns = append(columns, column.Expression)
+		case column.Sort != "":
+			columns = append(columns, fmt.Sprintf("%s(sort: %s)", column.Field, column.Sort))
+		default:
+			columns = append(columns, column.Field)
+		}
+	}
+
+	fieldList := fmt.Sprintf("[%s]", strings.Join(columns, ", "))
+	if idx.IsSimple() {
+		return fieldList
+	}
+
+	parts := []string{fmt.Sprintf("fields: %s", fieldList)}
+	if idx.Type != "" {
+		parts = append(parts, fmt.Sprintf("type: %s", idx.Type))
+	}
+	if idx.Where != "" {
+		parts = append(parts, fmt.Sprintf("where: \"%s\"", idx.Where))
+	}
+	if len(idx.Include) > 0 {
+		parts = append(parts, fmt.Sprintf("include: [%s]", strings.Join(idx.Include, ", ")))
+	}
+	if idx.Name != "" {
+		parts = append(parts, fmt.Sprintf("name: \"%s\"", idx.Name))
+	}
+
+	return strings.Join(parts, ", ")
+}
+
+func ParseIndexSQL(definition string) (*Index, string, bool, error) {
+	definition = strings.TrimSpace(strings.TrimSuffix(strings.TrimSpace(definition), ";"))
+
+	matches := indexSQLPattern.FindStringSubmatchIndex(definition)
+	if matches == nil {
+		return nil, "", false, fmt.Errorf("invalid index definition")
+	}
+
+	name := definition[matches[2]:matches[3]]
+	table := definition[matches[4]:matches[5]]
+	method := ""
+	if matches[6] != -1 {
+		method = strings.ToLower(definition[matches[6]:matches[7]])
+	}
+
+	columnList, rest, err := splitParenthesized(definition[matches[1]-1:])
+	if err != nil {
+		return nil, "", false, err
+	}
+
+	index := &Index{Name: name}
+	isText := strings.Contains(strings.ToLower(columnList), "_trgm_ops")
+
+	if isText {
+		if method == "gist" {
+			index.Type = constants.INDEX_TYPE_GIST
+		}
+		stripped := indexSQLLiteralPattern.ReplaceAllString(columnList, "")
+		for _, ident := range indexSQLIdentPattern.FindAllStringSubmatch(stripped, -1) {
+			field := ident[1]
+			if field == "" {
+				field = ident[2]
+				lower := strings.ToLower(field)
+				if strings.HasSuffix(lower, "_trgm_ops") || lower == "text" {
+					continue
+				}
+			}
+			index.Columns = append(index.Columns, IndexColumn{Field: field})
+		}
+	} else {
+		if indexType, ok := constants.ReversePGIndexMethodMapping[method]; ok && indexType != constants.INDEX_TYPE_BTREE {
+			index.Type = indexType
+		}
+		for _, item := range splitTopLevel(columnList) {
+			column := IndexColumn{}
+			if sortMatches := indexSQLSortPattern.FindStringSubmatch(item); sortMatches != nil {
+				if strings.ToUpper(sortMatches[1]) == "DESC" {
+					column.Sort = constants.INDEX_SORT_DESC
+				} else {
+					column.Sort = constants.INDEX_SORT_ASC
+				}
+				item = strings.TrimSpace(item[:len(item)-len(sortMatches[0])])
+			}
+			if fieldMatches := indexSQLColumnPattern.FindStringSubmatch(item); fieldMatches != nil {
+				column.Field = fieldMatches[1]
+			} else {
+				column.Expression = stripWrappingParens(item)
+			}
+			index.Columns = append(index.Columns, column)
+		}
+	}
+
+	rest = strings.TrimSpace(rest)
+	if len(rest) >= len("INCLUDE") && strings.EqualFold(rest[:len("INCLUDE")], "INCLUDE") {
+		includeList, remaining, err := splitParenthesized(strings.TrimSpace(rest[len("INCLUDE"):]))
+		if err != nil {
+			return nil, "", false, err
+		}
+		for _, item := range splitTopLevel(includeList) {
+			index.Include = append(index.Include, strings.Trim(item, `"`))
+		}
+		rest = strings.TrimSpace(remaining)
+	}
+
+	if len(rest) >= len("WHERE") && strings.EqualFold(rest[:len("WHERE")], "WHERE") {
+		index.Where = stripWrappingParens(strings.TrimSpace(rest[len("WHERE"):]))
+	}
+
+	if len(index.Columns) == 0 {
+		return nil, "", false, fmt.Errorf("index %s has no columns", name)
+	}
+
+	return index, table, isText, nil
+}
+
+func splitParenthesized(input string) (string, string, error) {
+	if !strings.HasPrefix(input, "(") {
+		return "", "", fmt.Errorf("expected '(' in index definition")
+	}
+
+	depth := 0
+	var quote rune
+	for i, r := range input {
+		switch {
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			}
+		case r == '"' || r == '\'':
+			quote = r
+		case r == '(':
+			depth++
+		case r == ')':
+			depth--
+			if depth == 0 {
+				return strings.TrimSpace(input[1:i]), input[i+1:], nil
+			}
+		}
+	}
+
+	return "", "", fmt.Errorf("unbalanced parentheses in index definition")
+}
+
+func stripWrappingParens(input string) string {
+	input = strings.TrimSpace(input)
+	for strings.HasPrefix(input, "(") {
+		inner, rest, err := splitParenthesized(input)
+		if err != nil || strings.TrimSpace(rest) != "" {
+			break
+		}
+		input = inner
+	}
+	return input
+}
@@ -0,0 +1,467 @@
+package directives
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rit3sh-x/blaze/core/constants"
+)
+
+type PartitionBy struct {
+	Strategy string
+	Columns  []string
+	Interval string
+	Start    string
+	Premake  int
+}
+
+type Partition struct {
+	Name      string
+	Values    []string
+	From      []string
+	To        []string
+	Modulus   int
+	Remainder int
+	Default   bool
+}
+
+var (
+	partitionNamePattern     = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]{0,62}$`)
+	partitionStartPattern    = regexp.MustCompile(`^(\d{4})-(\d{2})(?:-01)?$`)
+	partitionKeySQLPattern   = regexp.MustCompile(`(?is)^(RANGE|LIST|HASH)\s*\((.*)\)$`)
+	partitionOfSQLPattern    = regexp.MustCompile(`(?is)^CREATE\s+TABLE\s+(?:IF\s+NOT\s+EXISTS\s+)?"?([a-zA-Z0-9_]+)"?\s+PARTITION\s+OF\s+"?([a-zA-Z0-9_]+)"?\s+(.*)$`)
+	partitionRangeSQLPattern = regexp.MustCompile(`(?is)^FOR\s+VALUES\s+FROM\s*\((.*)\)\s*TO\s*\((.*)\)$`)
+	partitionListSQLPattern  = regexp.MustCompile(`(?is)^FOR\s+VALUES\s+IN\s*\((.*)\)$`)
+	partitionHashSQLPattern  = regexp.MustCompile(`(?is)^FOR\s+VALUES\s+WITH\s*\(\s*MODULUS\s+(\d+)\s*,\s*REMAINDER\s+(\d+)\s*\)$`)
+	partitionCastPattern     = regexp.MustCompile(`::[a-zA-Z_ ]+(?:\(\d+\))?$`)
+)
+
+func ParsePartitionBy(params string) (*PartitionBy, error) {
+	params = strings.TrimSpace(params)
+	if params == "" {
+		return nil, fmt.Errorf("partition key cannot be empty")
+	}
+
+	partitionBy := &PartitionBy{}
+
+	for _, item := range splitTopLevel(params) {
+		matches := indexOptionPattern.FindStringSubmatch(item)
+		if matches == nil {
+			return nil, fmt.Errorf("invalid partition parameter '%s'", item)
+		}
+
+		key := matches[1]
+		value := strings.TrimSpace(matches[2])
+
+		switch key {
+		case constants.PARTITION_STRATEGY_RANGE, constants.PARTITION_STRATEGY_LIST, constants.PARTITION_STRATEGY_HASH:
+			if partitionBy.Strategy != "" {
+				return nil, fmt.Errorf("partition strategy specified more than once")
+			}
+			columns, err := parseIndexFieldList(value)
+			if err != nil {
+				return nil, fmt.Errorf("invalid partition key: %v", err)
+			}
+			partitionBy.Strategy = key
+			partitionBy.Columns = columns
+		case "interval":
+			partitionBy.Interval = value
+		case "start":
+			partitionBy.Start = unquoteIndexValue(value)
+		case "premake":
+			premake, err := strconv.Atoi(value)
+			if err != nil {
+				return nil, fmt.Errorf("premake must be an integer, got '%s'", value)
+			}
+			partitionBy.Premake = premake
+		default:
+			return nil, fmt.Errorf("unknown partition parameter: %s", key)
+		}
+	}
+
+	return partitionBy, nil
+}
+
+func (pb *PartitionBy) Validate() error {
+	if pb.Strategy == "" || len(pb.Columns) == 0 {
+		return fmt.Errorf("partition strategy is required. Valid strategies: %s, %s, %s",
+			constants.PARTITION_STRATEGY_RANGE, constants.PARTITION_STRATEGY_LIST, constants.PARTITION_STRATEGY_HASH)
+	}
+
+	if pb.Strategy == constants.PARTITION_STRATEGY_LIST && len(pb.Columns) != 1 {
+		return fmt.Errorf("%s partitioning requires exactly one column", pb.Strategy)
+	}
+
+	if pb.Interval == "" {
+		if pb.Start != "" || pb.Premake != 0 {
+			return fmt.Errorf("start and premake require an interval")
+		}
+		return nil
+	}
+
+	if pb.Interval != constants.PARTITION_INTERVAL_MONTHLY {
+		return fmt.Errorf("invalid partition interval '%s'. Valid intervals: %s", pb.Interval, constants.PARTITION_INTERVAL_MONTHLY)
+	}
+
+	if pb.Strategy != constants.PARTITION_STRATEGY_RANGE || len(pb.Columns) != 1 {
+		return fmt.Errorf("interval partitioning requires a single %s partition column", constants.PARTITION_STRATEGY_RANGE)
+	}
+
+	if _, err := pb.StartMonth(); err != nil {
+		return err
+	}
+
+	if pb.Premake < 0 {
+		return fmt.Errorf("premake cannot be negative")
+	}
+
+	return nil
+}
+
+func (pb *PartitionBy) StartMonth() (time.Time, error) {
+	matches := partitionStartPattern.FindStringSubmatch(pb.Start)
+	if matches == nil {
+		return time.Time{}, fmt.Errorf("interval partitioning requires start: \"YYYY-MM\", got '%s'", pb.Start)
+	}
+
+	year, _ := strconv.Atoi(matches[1])
+	month, _ := strconv.Atoi(matches[2])
+	if month < 1 || month > 12 {
+		return time.Time{}, fmt.Errorf("invalid start month '%s'", pb.Start)
+	}
+
+	return time.Date(year, time.Month(month), 1, 0, 0, 0, 0, time.UTC), nil
+}
+
+func (pb *PartitionBy) IntervalPartitions(className string, now time.Time) []*Partition {
+	if pb.Interval != constants.PARTITION_INTERVAL_MONTHLY {
+		return nil
+	}
+
+	start, err := pb.StartMonth()
+	if err != nil {
+		return nil
+	}
+
+	end := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC).AddDate(0, pb.Premake, 0)
+
+	var partitions []*Partition
+	for month := start; !month.After(end); month = month.AddDate(0, 1, 0) {
+		partitions = append(partitions, &Partition{
+			Name: IntervalPartitionName(className, month),
+			From: []string{month.Format("2006-01-02")},
+			To:   []string{month.AddDate(0, 1, 0).Format("2006-01-02")},
+		})
+	}
+
+	return partitions
+}
+
+func IntervalPartitionName(className string, month time.Time) string {
+	return fmt.Sprintf("%s_%s", className, month.Format("2006_01"))
+}
+
+func IsIntervalPartitionName(className string, name string) bool {
+	suffix, found := strings.CutPrefix(name, className+"_")
+	if !found {
+		return false
+	}
+	_, err := time.Parse("2006_01", suffix)
+	return err == nil
+}
+
+func (pb *PartitionBy) KeyEquals(other *PartitionBy) bool {
+	if pb == nil || other == nil {
+		return pb == other
+	}
+	return pb.Strategy == other.Strategy && strings.Join(pb.Columns, ",") == strings.Join(other.Columns, ",")
+}
+
+func (pb *PartitionBy) SQL() string {
+	columns := make([]string, len(pb.Columns))
+	for i, column := range pb.Columns {
+		columns[i] = fmt.Sprintf(`"%s"`, column)
+	}
+	return fmt.Sprintf("PARTITION BY %s (%s)", strings.ToUpper(pb.Strategy), strings.Join(columns, ", "))
+}
+
+func (pb *PartitionBy) String() string {
+	parts := []string{fmt.Sprintf("%s: [%s]", pb.Strategy, strings.Join(pb.Columns, ", "))}
+	if pb.Interval != "" {
+		parts = append(parts, fmt.Sprintf("interval: %s", pb.Interval))
+		parts = append(parts, fmt.Sprintf("start: \"%s\"", pb.Start))
+		if pb.Premake != 0 {
+			parts = append(parts, fmt.Sprintf("premake: %d", pb.Premake))
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
+func ParsePartitionKeySQL(definition string) (*PartitionBy, error) {
+	matches := partitionKeySQLPattern.FindStringSubmatch(strings.TrimSpace(definition))
+	if matches == nil {
+		return nil, fmt.Errorf("unrecognized partition key: %s", definition)
+	}
+
+	partitionBy := &PartitionBy{Strategy: strings.ToLower(matches[1])}
+	for _, column := range splitTopLevel(matches[2]) {
+		column = strings.Trim(strings.TrimSpace(column), `"`)
+		if !indexFieldPattern.MatchString(column) {
+			return nil, fmt.Errorf("unsupported partition key expression: %s", column)
+		}
+		partitionBy.Columns = append(partitionBy.Columns, column)
+	}
+
+	return partitionBy, nil
+}
+
+func ParsePartition(params string) (*Partition, error) {
+	params = strings.TrimSpace(params)
+	if params == "" {
+		return nil, fmt.Errorf("partition definition cannot be empty")
+	}
+
+	partition := &Partition{}
+	hasModulus := false
+	hasRemainder := false
+
+	for i, item := range splitTopLevel(params) {
+		matches := indexOptionPattern.FindStringSubmatch(item)
+		if matches == nil {
+			if i != 0 {
+				return nil, fmt.Errorf("partition name must be the first parameter")
+			}
+			partition.Name = unquoteIndexValue(item)
+			continue
+		}
+
+		key := matches[1]
+		value := strings.TrimSpace(matches[2])
+
+		switch key {
+		case "name":
+			partition.Name = unquoteIndexValue(value)
+		case "in":
+			partition.Values = parsePartitionValues(value)
+		case "from":
+			partition.From = parsePartitionValues(value)
+		case "to":
+			partition.To = parsePartitionValues(value)
+		case "modulus", "remainder":
+			number, err := strconv.Atoi(value)
+			if err != nil {
+				return nil, fmt.Errorf("%s must be an integer, got '%s'", key, value)
+			}
+			if key == "modulus" {
+				partition.Modulus = number
+				hasModulus = true
+			} else {
+				partition.Remainder = number
+				hasRemainder = true
+			}
+		case "default":
+			partition.Default = value == "true"
+		default:
+			return nil, fmt.Errorf("unknown partition parameter: %s", key)
+		}
+	}
+
+	if hasModulus != hasRemainder {
+		return nil, fmt.Errorf("hash partitions require both modulus and remainder")
+	}
+
+	return partition, nil
+}
+
+func parsePartitionValues(value string) []string {
+	value = strings.TrimSpace(value)
+	if strings.HasPrefix(value, "[") && strings.HasSuffix(value, "]") {
+		value = value[1 : len(value)-1]
+	}
+
+	var values []string
+	for _, item := range splitTopLevel(value) {
+		values = append(values, unquoteIndexValue(strings.TrimSpace(item)))
+	}
+	return values
+}
+
+func (p *Partition) Strategy() string {
+	switch {
+	case p.Default:
+		return ""
+	case len(p.Values) > 0:
+		return constants.PARTITION_STRATEGY_LIST
+	case len(p.From) > 0 || len(p.To) > 0:
+		return constants.PARTITION_STRATEGY_RANGE
+	default:
+		return constants.PARTITION_STRATEGY_HASH
+	}
+}
+
+func (p *Partition) Validate(partitionBy *PartitionBy) error {
+	if !partitionNamePattern.MatchString(p.Name) {
+		return fmt.Errorf("invalid partition name '%s'", p.Name)
+	}
+
+	if partitionBy == nil {
+		return fmt.Errorf("partition '%s' requires @@%s on the class", p.Name, constants.CLASS_ATTR_PARTITION_BY)
+	}
+
+	if p.Default {
+		if partitionBy.Strategy == constants.PARTITION_STRATEGY_HASH {
+			return fmt.Errorf("partition '%s': %s partitioning does not support a default partition", p.Name, partitionBy.Strategy)
+		}
+		if len(p.Values) > 0 || len(p.From) > 0 || len(p.To) > 0 || p.Modulus != 0 {
+			return fmt.Errorf("partition '%s': a default partition cannot declare bounds", p.Name)
+		}
+		return nil
+	}
+
+	if p.Strategy() != partitionBy.Strategy {
+		return fmt.Errorf("partition '%s' bounds do not match %s partitioning", p.Name, partitionBy.Strategy)
+	}
+
+	switch partitionBy.Strategy {
+	case constants.PARTITION_STRATEGY_RANGE:
+		if len(p.From) != len(partitionBy.Columns) || len(p.To) != len(partitionBy.Columns) {
+			return fmt.Errorf("partition '%s' requires from and to bounds for each partition column", p.Name)
+		}
+	case constants.PARTITION_STRATEGY_HASH:
+		if p.Modulus <= 0 || p.Remainder < 0 || p.Remainder >= p.Modulus {
+			return fmt.Errorf("partition '%s' requires modulus > 0 and 0 <= remainder < modulus", p.Name)
+		}
+	}
+
+	return nil
+}
+
+func (p *Partition) BoundSQL() string {
+	switch p.Strategy() {
+	case constants.PARTITION_STRATEGY_LIST:
+		return fmt.Sprintf("FOR VALUES IN (%s)", partitionValuesSQL(p.Values))
+	case constants.PARTITION_STRATEGY_RANGE:
+		return fmt.Sprintf("FOR VALUES FROM (%s) TO (%s)", partitionValuesSQL(p.From), partitionValuesSQL(p.To))
+	case constants.PARTITION_STRATEGY_HASH:
+		return fmt.Sprintf("FOR VALUES WITH (MODULUS %d, REMAINDER %d)", p.Modulus, p.Remainder)
+	default:
+		return "DEFAULT"
+	}
+}
+
+func (p *Partition) SQL(parentName string) string {
+	return fmt.Sprintf(`CREATE TABLE "%s" PARTITION OF "%s" %s`, p.Name, parentName, p.BoundSQL())
+}
+
+func partitionValuesSQL(values []string) string {
+	formatted := make([]string, len(values))
+	for i, value := range values {
+		switch strings.ToUpper(value) {
+		case "MINVALUE", "MAXVALUE", "NULL":
+			formatted[i] = strings.ToUpper(value)
+		default:
+			formatted[i] = fmt.Sprintf("'%s'", strings.ReplaceAll(value, "'", "''"))
+		}
+	}
+	return strings.Join(formatted, ", ")
+}
+
+func (p *Partition) Equals(other *Partition) bool {
+	return p.Name == other.Name && p.BoundSQL() == other.BoundSQL()
+}
+
+func (p *Partition) String() string {
+	parts := []string{p.Name}
+	switch p.Strategy() {
+	case constants.PARTITION_STRATEGY_LIST:
+		parts = append(parts, fmt.Sprintf("in: [%s]", partitionValuesSchema(p.Values)))
+	case constants.PARTITION_STRATEGY_RANGE:
+		parts = append(parts, fmt.Sprintf("from: %s", partitionBoundSchema(p.From)))
+		parts = append(parts, fmt.Sprintf("to: %s", partitionBoundSchema(p.To)))
+	case constants.PARTITION_STRATEGY_HASH:
+		parts = append(parts, fmt.Sprintf("modulus: %d, remainder: %d", p.Modulus, p.Remainder))
+	default:
+		parts = append(parts, "default: true")
+	}
+	return strings.Join(parts, ", ")
+}
+
+func partitionValuesSchema(values []string) string {
+	formatted := make([]string, len(values))
+	for i, value := range values {
+		switch strings.ToUpper(value) {
+		case "MINVALUE", "MAXVALUE", "NULL":
+			formatted[i] = strings.ToUpper(value)
+		default:
+			formatted[i] = fmt.Sprintf("\"%s\"", value)
+		}
+	}
+	return strings.Join(formatted, ", ")
+}
+
+func partitionBoundSchema(values []string) string {
+	if len(values) == 1 {
+		return partitionValuesSchema(values)
+	}
+	return fmt.Sprintf("[%s]", partitionValuesSchema(values))
+}
+
+func ParsePartitionSQL(definition string) (*Partition, string, error) {
+	definition = strings.TrimSpace(strings.TrimSuffix(strings.TrimSpace(definition), ";"))
+
+	matches := partitionOfSQLPattern.FindStringSubmatch(definition)
+	if matches == nil {
+		return nil, "", fmt.Errorf("unrecognized partition definition: %s", definition)
+	}
+
+	partition, err := ParsePartitionBoundSQL(matches[1], matches[3])
+	if err != nil {
+		return nil, "", err
+	}
+
+	return partition, matches[2], nil
+}
+
+func ParsePartitionBoundSQL(name string, bound string) (*Partition, error) {
+	bound = strings.TrimSpace(bound)
+	partition := &Partition{Name: name}
+
+	if strings.EqualFold(bound, "DEFAULT") {
+		partition.Default = true
+		return partition, nil
+	}
+
+	if matches := partitionRangeSQLPattern.FindStringSubmatch(bound); matches != nil {
+		partition.From = parsePartitionSQLValues(matches[1])
+		partition.To = parsePartitionSQLValues(matches[2])
+		return partition, nil
+	}
+
+	if matches := partitionListSQLPattern.FindStringSubmatch(bound); matches != nil {
+		partition.Values = parsePartitionSQLValues(matches[1])
+		return partition, nil
+	}
+
+	if matches := partitionHashSQLPattern.FindStringSubmatch(bound); matches != nil {
+		partition.Modulus, _ = strconv.Atoi(matches[1])
+		partition.Remainder, _ = strconv.Atoi(matches[2])
+		return partition, nil
+	}
+
+	return nil, fmt.Errorf("unrecognized bounds for partition %s: %s", name, bound)
+}
+
+func parsePartitionSQLValues(input string) []string {
+	var values []string
+	for _, item := range splitTopLevel(input) {
+		value := partitionCastPattern.ReplaceAllString(strings.TrimSpace(item), "")
+		if len(value) >= 2 && strings.HasPrefix(value, "'") && strings.HasSuffix(value, "'") {
+			value = strings.ReplaceAll(value[1:len(value)-1], "''", "'")
+		}
+		values = append(values, value)
+	}
+	return values
+}
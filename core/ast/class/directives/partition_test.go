@@ -0,0 +1,190 @@
+package directives
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParsePartitionBy(t *testing.T) {
+	tests := []struct {
+		name   string
+		params string
+		want   *PartitionBy
+		sql    string
+		err    string
+	}{
+		{
+			name:   "monthly range",
+			params: `range: [createdAt], interval: monthly, start: "2026-08", premake: 2`,
+			want:   &PartitionBy{Strategy: "range", Columns: []string{"createdAt"}, Interval: "monthly", Start: "2026-08", Premake: 2},
+			sql:    `PARTITION BY RANGE ("createdAt")`,
+		},
+		{
+			name:   "hash on several columns",
+			params: `hash: [tenantId, id]`,
+			want:   &PartitionBy{Strategy: "hash", Columns: []string{"tenantId", "id"}},
+			sql:    `PARTITION BY HASH ("tenantId", "id")`,
+		},
+		{name: "empty", params: "", err: "partition key cannot be empty"},
+		{name: "two strategies", params: `range: [a], list: [b]`, err: "partition strategy specified more than once"},
+		{name: "no strategy", params: `interval: monthly`, err: "partition strategy is required"},
+		{name: "unknown parameter", params: `range: [a], every: month`, err: "unknown partition parameter: every"},
+		{name: "list on two columns", params: `list: [a, b]`, err: "list partitioning requires exactly one column"},
+		{name: "start without interval", params: `range: [a], start: "2026-01"`, err: "start and premake require an interval"},
+		{name: "unknown interval", params: `range: [a], interval: weekly, start: "2026-01"`, err: "invalid partition interval 'weekly'"},
+		{name: "interval on hash", params: `hash: [a], interval: monthly, start: "2026-01"`, err: "interval partitioning requires a single range partition column"},
+		{name: "bad start", params: `range: [a], interval: monthly, start: "2026-13"`, err: "invalid start month '2026-13'"},
+		{name: "negative premake", params: `range: [a], interval: monthly, start: "2026-01", premake: -1`, err: "premake cannot be negative"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			partitionBy, err := ParsePartitionBy(tt.params)
+			if err == nil {
+				err = partitionBy.Validate()
+			}
+			if tt.err != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.err) {
+					t.Fatalf("error = %v, want %q", err, tt.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(partitionBy, tt.want) {
+				t.Errorf("ParsePartitionBy() = %#v, want %#v", partitionBy, tt.want)
+			}
+			if sql := partitionBy.SQL(); sql != tt.sql {
+				t.Errorf("SQL() = %s, want %s", sql, tt.sql)
+			}
+
+			reparsed, err := ParsePartitionBy(partitionBy.String())
+			if err != nil || !reflect.DeepEqual(reparsed, partitionBy) {
+				t.Errorf("String() = %s does not parse back: %v", partitionBy.String(), err)
+			}
+			keyed, err := ParsePartitionKeySQL(strings.TrimPrefix(tt.sql, "PARTITION BY "))
+			if err != nil || !keyed.KeyEquals(partitionBy) {
+				t.Errorf("ParsePartitionKeySQL() = %#v, %v", keyed, err)
+			}
+		})
+	}
+}
+
+func TestIntervalPartitions(t *testing.T) {
+	partitionBy := &PartitionBy{Strategy: "range", Columns: []string{"createdAt"}, Interval: "monthly", Start: "2026-11", Premake: 2}
+	now := time.Date(2026, time.December, 18, 9, 30, 0, 0, time.UTC)
+
+	var got []string
+	for _, partition := range partitionBy.IntervalPartitions("Event", now) {
+		got = append(got, partition.SQL("Event"))
+	}
+	want := []string{
+		`CREATE TABLE "Event_2026_11" PARTITION OF "Event" FOR VALUES FROM ('2026-11-01') TO ('2026-12-01')`,
+		`CREATE TABLE "Event_2026_12" PARTITION OF "Event" FOR VALUES FROM ('2026-12-01') TO ('2027-01-01')`,
+		`CREATE TABLE "Event_2027_01" PARTITION OF "Event" FOR VALUES FROM ('2027-01-01') TO ('2027-02-01')`,
+		`CREATE TABLE "Event_2027_02" PARTITION OF "Event" FOR VALUES FROM ('2027-02-01') TO ('2027-03-01')`,
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("IntervalPartitions() =\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+
+	if !IsIntervalPartitionName("Event", "Event_2027_01") || IsIntervalPartitionName("Event", "Event_old") || IsIntervalPartitionName("Event", "Other_2027_01") {
+		t.Errorf("IsIntervalPartitionName() does not recognise only generated names")
+	}
+}
+
+func TestParsePartition(t *testing.T) {
+	rangeKey := &PartitionBy{Strategy: "range", Columns: []string{"createdAt"}}
+	listKey := &PartitionBy{Strategy: "list", Columns: []string{"code"}}
+	hashKey := &PartitionBy{Strategy: "hash", Columns: []string{"id"}}
+
+	tests := []struct {
+		name        string
+		params      string
+		partitionBy *PartitionBy
+		sql         string
+		err         string
+	}{
+		{
+			name:        "range from minvalue",
+			params:      `Event_old, from: MINVALUE, to: "2026-08-01"`,
+			partitionBy: rangeKey,
+			sql:         `CREATE TABLE "Event_old" PARTITION OF "Event" FOR VALUES FROM (MINVALUE) TO ('2026-08-01')`,
+		},
+		{
+			name:        "list values",
+			params:      `Region_eu, in: ["eu", "uk"]`,
+			partitionBy: listKey,
+			sql:         `CREATE TABLE "Region_eu" PARTITION OF "Event" FOR VALUES IN ('eu', 'uk')`,
+		},
+		{
+			name:        "default",
+			params:      `Region_other, default: true`,
+			partitionBy: listKey,
+			sql:         `CREATE TABLE "Region_other" PARTITION OF "Event" DEFAULT`,
+		},
+		{
+			name:        "hash",
+			params:      `name: Bucket_1, modulus: 2, remainder: 1`,
+			partitionBy: hashKey,
+			sql:         `CREATE TABLE "Bucket_1" PARTITION OF "Event" FOR VALUES WITH (MODULUS 2, REMAINDER 1)`,
+		},
+		{name: "empty", params: "", partitionBy: listKey, err: "partition definition cannot be empty"},
+		{name: "modulus without remainder", params: `Bucket_0, modulus: 2`, partitionBy: hashKey, err: "require both modulus and remainder"},
+		{name: "invalid name", params: `"bad name", default: true`, partitionBy: listKey, err: "invalid partition name 'bad name'"},
+		{name: "no partition key", params: `Region_eu, in: ["eu"]`, err: "requires @@partitionBy on the class"},
+		{name: "hash default", params: `Bucket_x, default: true`, partitionBy: hashKey, err: "hash partitioning does not support a default partition"},
+		{name: "mismatched bounds", params: `Region_eu, in: ["eu"]`, partitionBy: rangeKey, err: "bounds do not match range partitioning"},
+		{name: "missing range bound", params: `Event_old, from: MINVALUE`, partitionBy: rangeKey, err: "requires from and to bounds for each partition column"},
+		{name: "remainder out of range", params: `Bucket_2, modulus: 2, remainder: 2`, partitionBy: hashKey, err: "requires modulus > 0 and 0 <= remainder < modulus"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			partition, err := ParsePartition(tt.params)
+			if err == nil {
+				err = partition.Validate(tt.partitionBy)
+			}
+			if tt.err != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.err) {
+					t.Fatalf("error = %v, want %q", err, tt.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			sql := partition.SQL("Event")
+			if sql != tt.sql {
+				t.Errorf("SQL() = %s, want %s", sql, tt.sql)
+			}
+
+			reparsed, err := ParsePartition(partition.String())
+			if err != nil || !reparsed.Equals(partition) {
+				t.Errorf("String() = %s does not parse back: %v", partition.String(), err)
+			}
+			introspected, table, err := ParsePartitionSQL(sql + ";")
+			if err != nil || table != "Event" || !introspected.Equals(partition) {
+				t.Errorf("ParsePartitionSQL(%s) = %#v on %s, %v", sql, introspected, table, err)
+			}
+		})
+	}
+}
+
+func TestParsePartitionBoundSQL(t *testing.T) {
+	partition, err := ParsePartitionBoundSQL("Event_2026_08", `FOR VALUES FROM ('2026-08-01 00:00:00'::timestamp without time zone) TO ('2026-09-01 00:00:00'::timestamp without time zone)`)
+	if err != nil {
+		t.Fatalf("ParsePartitionBoundSQL() error: %v", err)
+	}
+	want := &Partition{Name: "Event_2026_08", From: []string{"2026-08-01 00:00:00"}, To: []string{"2026-09-01 00:00:00"}}
+	if !reflect.DeepEqual(partition, want) {
+		t.Errorf("ParsePartitionBoundSQL() = %#v, want %#v", partition, want)
+	}
+
+	if _, err := ParsePartitionBoundSQL("Event_x", "FOR VALUES SOMEHOW"); err == nil {
+		t.Errorf("ParsePartitionBoundSQL() accepted unrecognised bounds")
+	}
+}
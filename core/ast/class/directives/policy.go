@@ -0,0 +1,243 @@
+package directives
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/rit3sh-x/blaze/core/constants"
+)
+
+type Policy struct {
+	Name    string
+	Command string
+	Roles   []string
+	Using   string
+	Check   string
+}
+
+var (
+	policyNamePattern   = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]{0,62}$`)
+	policyRolePattern   = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]{0,62}$`)
+	policyPlainRole     = regexp.MustCompile(`^[a-z_][a-z0-9_]*$`)
+	policySQLPattern    = regexp.MustCompile(`(?is)^CREATE\s+POLICY\s+"?([a-zA-Z0-9_]+)"?\s+ON\s+(?:[a-zA-Z0-9_]+\.)?"?([a-zA-Z0-9_]+)"?\s*(.*)$`)
+	policySQLForPattern = regexp.MustCompile(`(?is)^FOR\s+(ALL|SELECT|INSERT|UPDATE|DELETE)\s*`)
+	policySQLToPattern  = regexp.MustCompile(`(?is)^TO\s+((?:"[^"]+"|[a-zA-Z_][a-zA-Z0-9_]*)(?:\s*,\s*(?:"[^"]+"|[a-zA-Z_][a-zA-Z0-9_]*))*)\s*`)
+	policySQLAsPattern  = regexp.MustCompile(`(?is)^AS\s+PERMISSIVE\s*`)
+	policySQLCheck      = regexp.MustCompile(`(?i)^WITH\s+CHECK\s*`)
+)
+
+func ParsePolicy(params string) (*Policy, error) {
+	params = strings.TrimSpace(params)
+	if params == "" {
+		return nil, fmt.Errorf("policy definition cannot be empty")
+	}
+
+	policy := &Policy{Command: constants.POLICY_FOR_ALL}
+
+	for i, item := range splitTopLevel(params) {
+		matches := indexOptionPattern.FindStringSubmatch(item)
+		if matches == nil {
+			if i != 0 {
+				return nil, fmt.Errorf("policy name must be the first parameter")
+			}
+			policy.Name = unquoteIndexValue(item)
+			continue
+		}
+
+		key := matches[1]
+		value := strings.TrimSpace(matches[2])
+
+		switch key {
+		case "name":
+			policy.Name = unquoteIndexValue(value)
+		case "for":
+			policy.Command = value
+		case "to":
+			roles, err := parsePolicyRoles(value)
+			if err != nil {
+				return nil, err
+			}
+			policy.Roles = NormalizePolicyRoles(roles)
+		case "using":
+			policy.Using = unquotePolicyExpression(value)
+		case "check":
+			policy.Check = unquotePolicyExpression(value)
+		default:
+			return nil, fmt.Errorf("unknown policy parameter: %s", key)
+		}
+	}
+
+	return policy, nil
+}
+
+func parsePolicyRoles(value string) ([]string, error) {
+	if strings.HasPrefix(value, "[") && strings.HasSuffix(value, "]") {
+		value = value[1 : len(value)-1]
+	}
+
+	var roles []string
+	for _, item := range splitTopLevel(value) {
+		role := unquoteIndexValue(item)
+		if !policyRolePattern.MatchString(role) {
+			return nil, fmt.Errorf("invalid policy role '%s'", item)
+		}
+		roles = append(roles, role)
+	}
+
+	if len(roles) == 0 {
+		return nil, fmt.Errorf("policy role list cannot be empty")
+	}
+
+	return roles, nil
+}
+
+func unquotePolicyExpression(value string) string {
+	if len(value) >= 2 && strings.HasPrefix(value, "\"") && strings.HasSuffix(value, "\"") {
+		value = strings.ReplaceAll(value[1:len(value)-1], `\"`, `"`)
+	}
+	return strings.TrimSpace(value)
+}
+
+func (p *Policy) Validate() error {
+	if !policyNamePattern.MatchString(p.Name) {
+		return fmt.Errorf("invalid policy name '%s'", p.Name)
+	}
+
+	if _, ok := constants.PolicyCommandMapping[p.Command]; !ok {
+		return fmt.Errorf("invalid policy command '%s'. Valid commands: %s, %s, %s, %s, %s", p.Command,
+			constants.POLICY_FOR_SELECT, constants.POLICY_FOR_INSERT, constants.POLICY_FOR_UPDATE, constants.POLICY_FOR_DELETE, constants.POLICY_FOR_ALL)
+	}
+
+	if p.Using == "" && p.Check == "" {
+		return fmt.Errorf("policy '%s' requires a using or check expression", p.Name)
+	}
+
+	if p.Command == constants.POLICY_FOR_INSERT && p.Using != "" {
+		return fmt.Errorf("policy '%s': %s policies only accept a check expression", p.Name, constants.POLICY_FOR_INSERT)
+	}
+
+	if (p.Command == constants.POLICY_FOR_SELECT || p.Command == constants.POLICY_FOR_DELETE) && p.Check != "" {
+		return fmt.Errorf("policy '%s': %s policies only accept a using expression", p.Name, p.Command)
+	}
+
+	return nil
+}
+
+func (p *Policy) SQL(tableName string) string {
+	var builder strings.Builder
+	builder.WriteString(fmt.Sprintf(`CREATE POLICY "%s" ON "%s" FOR %s`, p.Name, tableName, constants.PolicyCommandMapping[p.Command]))
+
+	if len(p.Roles) > 0 {
+		roles := make([]string, len(p.Roles))
+		for i, role := range p.Roles {
+			roles[i] = role
+			if !policyPlainRole.MatchString(role) {
+				roles[i] = fmt.Sprintf(`"%s"`, role)
+			}
+		}
+		builder.WriteString(" TO " + strings.Join(roles, ", "))
+	}
+
+	if p.Using != "" {
+		builder.WriteString(fmt.Sprintf(" USING (%s)", p.Using))
+	}
+
+	if p.Check != "" {
+		builder.WriteString(fmt.Sprintf(" WITH CHECK (%s)", p.Check))
+	}
+
+	return builder.String()
+}
+
+func (p *Policy) Equals(other *Policy) bool {
+	return p.Name == other.Name && p.Command == other.Command && p.Using == other.Using && p.Check == other.Check &&
+		strings.Join(p.Roles, ",") == strings.Join(other.Roles, ",")
+}
+
+func (p *Policy) String() string {
+	parts := []string{p.Name}
+	if p.Command != constants.POLICY_FOR_ALL {
+		parts = append(parts, fmt.Sprintf("for: %s", p.Command))
+	}
+	if len(p.Roles) == 1 {
+		parts = append(parts, fmt.Sprintf("to: %s", p.Roles[0]))
+	} else if len(p.Roles) > 1 {
+		parts = append(parts, fmt.Sprintf("to: [%s]", strings.Join(p.Roles, ", ")))
+	}
+	if p.Using != "" {
+		parts = append(parts, fmt.Sprintf("using: \"%s\"", strings.ReplaceAll(p.Using, `"`, `\"`)))
+	}
+	if p.Check != "" {
+		parts = append(parts, fmt.Sprintf("check: \"%s\"", strings.ReplaceAll(p.Check, `"`, `\"`)))
+	}
+	return strings.Join(parts, ", ")
+}
+
+func PolicyCommandFromSQL(command string) string {
+	command = strings.ToUpper(strings.TrimSpace(command))
+	for name, sql := range constants.PolicyCommandMapping {
+		if sql == command {
+			return name
+		}
+	}
+	return constants.POLICY_FOR_ALL
+}
+
+func NormalizePolicyRoles(roles []string) []string {
+	var normalized []string
+	for _, role := range roles {
+		role = strings.Trim(strings.TrimSpace(role), `"`)
+		if role != "" && strings.ToLower(role) != "public" {
+			normalized = append(normalized, role)
+		}
+	}
+	sort.Strings(normalized)
+	return normalized
+}
+
+func ParsePolicySQL(definition string) (*Policy, string, error) {
+	definition = strings.TrimSpace(strings.TrimSuffix(strings.TrimSpace(definition), ";"))
+
+	matches := policySQLPattern.FindStringSubmatch(definition)
+	if matches == nil {
+		return nil, "", fmt.Errorf("unrecognized policy definition: %s", definition)
+	}
+
+	policy := &Policy{Name: matches[1], Command: constants.POLICY_FOR_ALL}
+	rest := strings.TrimSpace(matches[3])
+
+	if asMatch := policySQLAsPattern.FindString(rest); asMatch != "" {
+		rest = rest[len(asMatch):]
+	}
+
+	if forMatch := policySQLForPattern.FindStringSubmatch(rest); forMatch != nil {
+		policy.Command = PolicyCommandFromSQL(forMatch[1])
+		rest = rest[len(forMatch[0]):]
+	}
+
+	if toMatch := policySQLToPattern.FindStringSubmatch(rest); toMatch != nil {
+		policy.Roles = NormalizePolicyRoles(strings.Split(toMatch[1], ","))
+		rest = rest[len(toMatch[0]):]
+	}
+
+	if upper := strings.ToUpper(rest); strings.HasPrefix(upper, "USING") {
+		expression, remaining, err := splitParenthesized(strings.TrimSpace(rest[len("USING"):]))
+		if err != nil {
+			return nil, "", fmt.Errorf("invalid USING clause in policy %s: %v", policy.Name, err)
+		}
+		policy.Using = expression
+		rest = strings.TrimSpace(remaining)
+	}
+
+	if checkMatch := policySQLCheck.FindString(rest); checkMatch != "" {
+		expression, _, err := splitParenthesized(rest[len(checkMatch):])
+		if err != nil {
+			return nil, "", fmt.Errorf("invalid WITH CHECK clause in policy %s: %v", policy.Name, err)
+		}
+		policy.Check = expression
+	}
+
+	return policy, matches[2], nil
+}
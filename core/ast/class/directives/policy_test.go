@@ -0,0 +1,102 @@
+package directives
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParsePolicy(t *testing.T) {
+	tests := []struct {
+		name   string
+		params string
+		want   *Policy
+		sql    string
+		err    string
+	}{
+		{
+			name:   "defaults to all commands",
+			params: `tenant_isolation, using: "\"tenantId\" = current_setting('app.tenant_id')::int"`,
+			want:   &Policy{Name: "tenant_isolation", Command: "All", Using: `"tenantId" = current_setting('app.tenant_id')::int`},
+			sql:    `CREATE POLICY "tenant_isolation" ON "Doc" FOR ALL USING ("tenantId" = current_setting('app.tenant_id')::int)`,
+		},
+		{
+			name:   "insert policy with roles",
+			params: `tenant_insert, for: Insert, to: [app_user, Admin, public], check: "true"`,
+			want:   &Policy{Name: "tenant_insert", Command: "Insert", Roles: []string{"Admin", "app_user"}, Check: "true"},
+			sql:    `CREATE POLICY "tenant_insert" ON "Doc" FOR INSERT TO "Admin", app_user WITH CHECK (true)`,
+		},
+		{
+			name:   "named parameter",
+			params: `name: owner_only, for: Update, using: "owner = current_user", check: "owner = current_user"`,
+			want:   &Policy{Name: "owner_only", Command: "Update", Using: "owner = current_user", Check: "owner = current_user"},
+			sql:    `CREATE POLICY "owner_only" ON "Doc" FOR UPDATE USING (owner = current_user) WITH CHECK (owner = current_user)`,
+		},
+		{name: "empty", params: "", err: "policy definition cannot be empty"},
+		{name: "unknown parameter", params: `p, when: "true"`, err: "unknown policy parameter: when"},
+		{name: "name not first", params: `using: "true", p`, err: "policy name must be the first parameter"},
+		{name: "invalid role", params: `p, to: "bad role", using: "true"`, err: "invalid policy role"},
+		{name: "invalid command", params: `p, for: Truncate, using: "true"`, err: "invalid policy command 'Truncate'"},
+		{name: "no expression", params: `p, for: Select`, err: "requires a using or check expression"},
+		{name: "insert with using", params: `p, for: Insert, using: "true"`, err: "only accept a check expression"},
+		{name: "select with check", params: `p, for: Select, check: "true"`, err: "only accept a using expression"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			policy, err := ParsePolicy(tt.params)
+			if err == nil {
+				err = policy.Validate()
+			}
+			if tt.err != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.err) {
+					t.Fatalf("error = %v, want %q", err, tt.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(policy, tt.want) {
+				t.Errorf("ParsePolicy() = %#v, want %#v", policy, tt.want)
+			}
+			if sql := policy.SQL("Doc"); sql != tt.sql {
+				t.Errorf("SQL() = %s, want %s", sql, tt.sql)
+			}
+
+			reparsed, err := ParsePolicy(policy.String())
+			if err != nil || !reparsed.Equals(policy) {
+				t.Errorf("String() = %s does not parse back: %v", policy.String(), err)
+			}
+		})
+	}
+}
+
+func TestParsePolicySQL(t *testing.T) {
+	tests := []struct {
+		definition string
+		table      string
+		want       *Policy
+	}{
+		{
+			definition: `CREATE POLICY tenant_isolation ON public."Doc" AS PERMISSIVE FOR ALL TO public USING (("tenantId" = (current_setting('app.tenant_id'::text))::integer));`,
+			table:      "Doc",
+			want:       &Policy{Name: "tenant_isolation", Command: "All", Using: `("tenantId" = (current_setting('app.tenant_id'::text))::integer)`},
+		},
+		{
+			definition: `CREATE POLICY "tenant_insert" ON "Doc" FOR INSERT TO app_user, "Admin" WITH CHECK (true)`,
+			table:      "Doc",
+			want:       &Policy{Name: "tenant_insert", Command: "Insert", Roles: []string{"Admin", "app_user"}, Check: "true"},
+		},
+	}
+
+	for _, tt := range tests {
+		policy, table, err := ParsePolicySQL(tt.definition)
+		if err != nil {
+			t.Fatalf("ParsePolicySQL(%q) error: %v", tt.definition, err)
+		}
+		if table != tt.table || !reflect.DeepEqual(policy, tt.want) {
+			t.Errorf("ParsePolicySQL(%q) = %#v on %s, want %#v on %s", tt.definition, policy, table, tt.want, tt.table)
+		}
+	}
+}
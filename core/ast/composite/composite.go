@@ -0,0 +1,155 @@
+package composite
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/rit3sh-x/blaze/core/constants"
+)
+
+type CompositeField struct {
+	Name       string
+	Type       string
+	IsArray    bool
+	IsOptional bool
+	Position   int
+}
+
+type CompositeType struct {
+	Name     string
+	Fields   []*CompositeField
+	Position int
+}
+
+type CompositeValidator struct {
+	compositeRegex    *regexp.Regexp
+	fieldRegex        *regexp.Regexp
+	identifierPattern *regexp.Regexp
+	reservedKeywords  map[string]bool
+}
+
+func NewCompositeValidator() *CompositeValidator {
+	reserved := map[string]bool{
+		constants.KEYWORD_ENUM:     true,
+		constants.KEYWORD_CLASS:    true,
+		constants.KEYWORD_SEQUENCE: true,
+		constants.KEYWORD_TYPE:     true,
+		constants.KEYWORD_DOMAIN:   true,
+	}
+
+	for _, scalarType := range constants.ScalarTypes {
+		reserved[strings.ToLower(string(scalarType))] = true
+	}
+
+	return &CompositeValidator{
+		compositeRegex:    regexp.MustCompile(`(?s)^` + constants.KEYWORD_TYPE + `\s+([A-Za-z_][A-Za-z0-9_]*)\s*\{([^{}]*)\}\s*$`),
+		fieldRegex:        regexp.MustCompile(`^([a-zA-Z_][a-zA-Z0-9_]{0,62})\s+([A-Za-z_][A-Za-z0-9_]*)(\[\])?(\?)?$`),
+		identifierPattern: regexp.MustCompile(`^[A-Z][a-zA-Z0-9_]{0,62}$`),
+		reservedKeywords:  reserved,
+	}
+}
+
+func (v *CompositeValidator) ValidateCompositeName(name string) error {
+	if name == "" {
+		return fmt.Errorf("type name cannot be empty")
+	}
+
+	if !v.identifierPattern.MatchString(name) {
+		return fmt.Errorf("invalid type name '%s': must start with uppercase letter, contain only alphanumeric characters/underscores, and be at most 63 characters", name)
+	}
+
+	if v.reservedKeywords[strings.ToLower(name)] {
+		return fmt.Errorf("type name '%s' is a reserved keyword", name)
+	}
+
+	return nil
+}
+
+func (v *CompositeValidator) ParseComposite(definition string, position int) (*CompositeType, error) {
+	definition = strings.TrimSpace(definition)
+
+	matches := v.compositeRegex.FindStringSubmatch(definition)
+	if matches == nil {
+		return nil, fmt.Errorf("invalid type definition: must match pattern 'type Name { field Type ... }'")
+	}
+
+	compositeType := &CompositeType{
+		Name:     matches[1],
+		Position: position,
+	}
+
+	if err := v.ValidateCompositeName(compositeType.Name); err != nil {
+		return nil, err
+	}
+
+	seen := make(map[string]bool)
+	for _, line := range strings.Split(matches[2], "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+
+		if strings.Contains(line, "@") {
+			return nil, fmt.Errorf("attributes are not supported on fields of type '%s': %s", compositeType.Name, line)
+		}
+
+		fieldMatches := v.fieldRegex.FindStringSubmatch(strings.Join(strings.Fields(line), " "))
+		if fieldMatches == nil {
+			return nil, fmt.Errorf("invalid field '%s' in type '%s': must match pattern 'name Type'", line, compositeType.Name)
+		}
+
+		if seen[fieldMatches[1]] {
+			return nil, fmt.Errorf("duplicate field '%s' in type '%s'", fieldMatches[1], compositeType.Name)
+		}
+		seen[fieldMatches[1]] = true
+
+		compositeType.Fields = append(compositeType.Fields, &CompositeField{
+			Name:       fieldMatches[1],
+			Type:       fieldMatches[2],
+			IsArray:    fieldMatches[3] != "",
+			IsOptional: fieldMatches[4] != "",
+			Position:   len(compositeType.Fields),
+		})
+	}
+
+	if len(compositeType.Fields) == 0 {
+		return nil, fmt.Errorf("type '%s' must have at least one field", compositeType.Name)
+	}
+
+	return compositeType, nil
+}
+
+func (c *CompositeType) GetFieldByName(name string) *CompositeField {
+	for _, field := range c.Fields {
+		if field.Name == name {
+			return field
+		}
+	}
+	return nil
+}
+
+func (c *CompositeType) String() string {
+	var builder strings.Builder
+	builder.WriteString(fmt.Sprintf("%s %s {\n", constants.KEYWORD_TYPE, c.Name))
+	for _, field := range c.Fields {
+		builder.WriteString(fmt.Sprintf("  %s %s\n", field.Name, field.TypeString()))
+	}
+	builder.WriteString("}")
+	return builder.String()
+}
+
+func (f *CompositeField) TypeString() string {
+	typeName := f.Type
+	if f.IsArray {
+		typeName += "[]"
+	}
+	if f.IsOptional {
+		typeName += "?"
+	}
+	return typeName
+}
+
+func (f *CompositeField) Equals(other *CompositeField) bool {
+	return f.Type == other.Type && f.IsArray == other.IsArray
+}
@@ -0,0 +1,237 @@
+package domain
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/rit3sh-x/blaze/core/ast/field/defaults"
+	"github.com/rit3sh-x/blaze/core/ast/field/native"
+	"github.com/rit3sh-x/blaze/core/constants"
+)
+
+type Domain struct {
+	Name         string
+	BaseType     string
+	NativeType   *native.NativeType
+	Check        string
+	Default      string
+	DefaultValue *defaults.DefaultValue
+	Position     int
+}
+
+type DomainValidator struct {
+	domainRegex       *regexp.Regexp
+	attributeRegex    *regexp.Regexp
+	identifierPattern *regexp.Regexp
+	reservedKeywords  map[string]bool
+	defaultValidator  *defaults.DefaultValidator
+}
+
+func NewDomainValidator() *DomainValidator {
+	reserved := map[string]bool{
+		constants.KEYWORD_ENUM:     true,
+		constants.KEYWORD_CLASS:    true,
+		constants.KEYWORD_SEQUENCE: true,
+		constants.KEYWORD_TYPE:     true,
+		constants.KEYWORD_DOMAIN:   true,
+	}
+
+	for _, scalarType := range constants.ScalarTypes {
+		reserved[strings.ToLower(string(scalarType))] = true
+	}
+
+	return &DomainValidator{
+		domainRegex:       regexp.MustCompile(`^` + constants.KEYWORD_DOMAIN + `\s+([A-Za-z_][A-Za-z0-9_]*)\s*=\s*([A-Za-z_][A-Za-z0-9_]*(?:\[\])?)\s*(.*)$`),
+		attributeRegex:    regexp.MustCompile(`^@([a-zA-Z_][a-zA-Z0-9_.]*)`),
+		identifierPattern: regexp.MustCompile(`^[A-Z][a-zA-Z0-9_]{0,62}$`),
+		reservedKeywords:  reserved,
+		defaultValidator:  defaults.NewDefaultValidator(nil),
+	}
+}
+
+func (v *DomainValidator) ValidateDomainName(name string) error {
+	if name == "" {
+		return fmt.Errorf("domain name cannot be empty")
+	}
+
+	if !v.identifierPattern.MatchString(name) {
+		return fmt.Errorf("invalid domain name '%s': must start with uppercase letter, contain only alphanumeric characters/underscores, and be at most 63 characters", name)
+	}
+
+	if v.reservedKeywords[strings.ToLower(name)] {
+		return fmt.Errorf("domain name '%s' is a reserved keyword", name)
+	}
+
+	return nil
+}
+
+func (v *DomainValidator) ParseDomain(definition string, position int) (*Domain, error) {
+	definition = strings.TrimSpace(definition)
+
+	matches := v.domainRegex.FindStringSubmatch(definition)
+	if matches == nil {
+		return nil, fmt.Errorf("invalid domain definition: must match pattern 'domain Name = Type @check(\"...\")'")
+	}
+
+	d := &Domain{
+		Name:     matches[1],
+		BaseType: matches[2],
+		Position: position,
+	}
+
+	if err := v.ValidateDomainName(d.Name); err != nil {
+		return nil, err
+	}
+
+	if strings.HasSuffix(d.BaseType, "[]") {
+		return nil, fmt.Errorf("domain '%s' cannot be based on an array type", d.Name)
+	}
+
+	if !constants.IsScalarType(d.BaseType) {
+		return nil, fmt.Errorf("domain '%s' must be based on a scalar type, got '%s'", d.Name, d.BaseType)
+	}
+
+	if err := v.parseAttributes(matches[3], d); err != nil {
+		return nil, fmt.Errorf("domain '%s': %v", d.Name, err)
+	}
+
+	return d, nil
+}
+
+func (v *DomainValidator) parseAttributes(attributesStr string, d *Domain) error {
+	remaining := strings.TrimSpace(attributesStr)
+	seen := make(map[string]bool)
+
+	for remaining != "" {
+		nameMatch := v.attributeRegex.FindStringSubmatch(remaining)
+		if nameMatch == nil {
+			return fmt.Errorf("unexpected token '%s'", remaining)
+		}
+
+		name := nameMatch[1]
+		remaining = remaining[len(nameMatch[0]):]
+
+		var value string
+		hasValue := strings.HasPrefix(remaining, "(")
+		if hasValue {
+			end := closingParen(remaining)
+			if end < 0 {
+				return fmt.Errorf("unbalanced parentheses in @%s", name)
+			}
+			value = strings.TrimSpace(remaining[1:end])
+			remaining = remaining[end+1:]
+		}
+		remaining = strings.TrimSpace(remaining)
+
+		if seen[name] {
+			return fmt.Errorf("duplicate attribute '@%s'", name)
+		}
+		seen[name] = true
+
+		switch {
+		case name == constants.DOMAIN_ATTR_CHECK:
+			check, err := unquote(value)
+			if err != nil || strings.TrimSpace(check) == "" {
+				return fmt.Errorf("@%s requires a quoted SQL expression", name)
+			}
+			d.Check = check
+		case name == constants.DOMAIN_ATTR_DEFAULT:
+			if value == "" {
+				return fmt.Errorf("@%s attribute requires a value", name)
+			}
+			defaultValue, err := v.defaultValidator.ValidateDefault(value, d.BaseType, false)
+			if err != nil {
+				return fmt.Errorf("invalid @%s value: %v", name, err)
+			}
+			if defaultValue.IsSequence() || (defaultValue.IsCallback() && defaultValue.GetValue() == constants.DEFAULT_AUTOINCREMENT_CALLBACK) {
+				return fmt.Errorf("@%s(%s) is not supported on domains", name, value)
+			}
+			d.Default = value
+			d.DefaultValue = defaultValue
+		case native.IsNativeAttribute(name):
+			var nativeValue interface{}
+			if hasValue {
+				nativeValue = value
+			}
+			nativeType, err := native.ParseNativeType(name, nativeValue)
+			if err != nil {
+				return err
+			}
+			if err := nativeType.ValidateForType(d.BaseType); err != nil {
+				return err
+			}
+			d.NativeType = nativeType
+		default:
+			return fmt.Errorf("unknown domain attribute '@%s'. Valid attributes: @%s, @%s, @%s<Type>", name, constants.DOMAIN_ATTR_CHECK, constants.DOMAIN_ATTR_DEFAULT, constants.FIELD_ATTR_NATIVE)
+		}
+	}
+
+	return nil
+}
+
+func closingParen(s string) int {
+	depth := 0
+	inString := false
+	escaped := false
+
+	for i, char := range s {
+		if inString {
+			if escaped {
+				escaped = false
+			} else if char == '\\' {
+				escaped = true
+			} else if char == '"' {
+				inString = false
+			}
+			continue
+		}
+
+		switch char {
+		case '"':
+			inString = true
+		case '(':
+			depth++
+		case ')':
+			depth--
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+
+	return -1
+}
+
+func unquote(value string) (string, error) {
+	if len(value) < 2 || value[0] != '"' || value[len(value)-1] != '"' {
+		return "", fmt.Errorf("value must be a double-quoted string")
+	}
+	return strings.ReplaceAll(value[1:len(value)-1], `\"`, `"`), nil
+}
+
+func (d *Domain) Equals(other *Domain) bool {
+	return d.BaseType == other.BaseType && d.NativeType.Equals(other.NativeType) && d.Check == other.Check && d.DefaultEquals(other)
+}
+
+func (d *Domain) DefaultEquals(other *Domain) bool {
+	if d.DefaultValue == nil || other.DefaultValue == nil {
+		return d.DefaultValue == other.DefaultValue
+	}
+	return fmt.Sprintf("%v", d.DefaultValue.GetValue()) == fmt.Sprintf("%v", other.DefaultValue.GetValue())
+}
+
+func (d *Domain) String() string {
+	var builder strings.Builder
+	builder.WriteString(fmt.Sprintf("%s %s = %s", constants.KEYWORD_DOMAIN, d.Name, d.BaseType))
+	if d.NativeType != nil {
+		builder.WriteString(" " + d.NativeType.String())
+	}
+	if d.Default != "" {
+		builder.WriteString(fmt.Sprintf(" @%s(%s)", constants.DOMAIN_ATTR_DEFAULT, d.Default))
+	}
+	if d.Check != "" {
+		builder.WriteString(fmt.Sprintf(" @%s(\"%s\")", constants.DOMAIN_ATTR_CHECK, strings.ReplaceAll(d.Check, `"`, `\"`)))
+	}
+	return builder.String()
+}
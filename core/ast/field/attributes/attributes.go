@@ -10,6 +10,7 @@ import (
 	"github.com/rit3sh-x/blaze/core/ast/enum"
 	"github.com/rit3sh-x/blaze/core/ast/field/defaults"
 	"github.com/rit3sh-x/blaze/core/ast/field/directives"
+	"github.com/rit3sh-x/blaze/core/ast/field/native"
 	"github.com/rit3sh-x/blaze/core/ast/field/relations"
 	"github.com/rit3sh-x/blaze/core/constants"
 )
@@ -29,6 +30,8 @@ type AttributeDefinition struct {
 	Directives   []*directives.FieldDirective
 	DefaultValue *defaults.DefaultValue
 	Relation     *relations.Relation
+	Computed     string
+	NativeType   *native.NativeType
 }
 
 type AttributeValidator struct {
@@ -99,6 +102,14 @@ func (av *AttributeValidator) ParseFieldDefinition(line string, className string
 		return nil, fmt.Errorf("failed to process relation for field '%s': %v", fieldName, err)
 	}
 
+	if err := av.processComputed(fieldDef); err != nil {
+		return nil, fmt.Errorf("failed to process computed expression for field '%s': %v", fieldName, err)
+	}
+
+	if err := av.processNativeType(fieldDef); err != nil {
+		return nil, fmt.Errorf("failed to process native type for field '%s': %v", fieldName, err)
+	}
+
 	if err := av.ValidateFieldDefinition(fieldDef, className); err != nil {
 		return nil, fmt.Errorf("field validation failed for '%s': %v", fieldName, err)
 	}
@@ -130,6 +141,14 @@ func (av *AttributeValidator) determineFieldKind(baseType string) string {
 		return constants.FIELD_KIND_ENUM
 	}
 
+	if av.defaultValidator.IsComposite(baseType) {
+		return constants.FIELD_KIND_COMPOSITE
+	}
+
+	if _, exists := av.defaultValidator.GetDomainBaseType(baseType); exists {
+		return constants.FIELD_KIND_DOMAIN
+	}
+
 	return constants.FIELD_KIND_OBJECT
 }
 
@@ -148,6 +167,67 @@ func (av *AttributeValidator) processRelation(fieldDef *AttributeDefinition, cla
 	return nil
 }
 
+func (av *AttributeValidator) processComputed(fieldDef *AttributeDefinition) error {
+	computedAttr := fieldDef.GetAttribute(constants.FIELD_ATTR_COMPUTED)
+	if computedAttr == nil {
+		return nil
+	}
+
+	computedStr, ok := computedAttr.GetStringValue()
+	if !ok {
+		return fmt.Errorf("@computed value must be a string expression")
+	}
+
+	expression, err := ParseComputedExpression(computedStr)
+	if err != nil {
+		return err
+	}
+
+	fieldDef.Computed = expression
+	return nil
+}
+
+func (av *AttributeValidator) processNativeType(fieldDef *AttributeDefinition) error {
+	for _, attr := range fieldDef.Attributes {
+		if !native.IsNativeAttribute(attr.Name) {
+			continue
+		}
+
+		if fieldDef.NativeType != nil {
+			return fmt.Errorf("only one native type attribute is allowed per field")
+		}
+
+		nativeType, err := native.ParseNativeType(attr.Name, attr.Value)
+		if err != nil {
+			return err
+		}
+
+		if err := nativeType.ValidateForType(fieldDef.DataType); err != nil {
+			return err
+		}
+
+		fieldDef.NativeType = nativeType
+	}
+	return nil
+}
+
+func ParseComputedExpression(value string) (string, error) {
+	value = strings.TrimSpace(value)
+	if strings.HasPrefix(value, "\"") {
+		unquoted, err := strconv.Unquote(value)
+		if err != nil {
+			return "", fmt.Errorf("invalid @computed expression %s: %v", value, err)
+		}
+		value = strings.TrimSpace(unquoted)
+	}
+
+	if value == "" {
+		return "", fmt.Errorf("@computed expression cannot be empty")
+	}
+
+	return value, nil
+}
+
 func (av *AttributeValidator) parseFieldAttributes(attributesStr string, fieldDef *AttributeDefinition) error {
 	i := 0
 	for i < len(attributesStr) {
@@ -155,7 +235,7 @@ func (av *AttributeValidator) parseFieldAttributes(attributesStr string, fieldDe
 			i++
 
 			nameStart := i
-			for i < len(attributesStr) && (unicode.IsLetter(rune(attributesStr[i])) || unicode.IsDigit(rune(attributesStr[i])) || attributesStr[i] == '_') {
+			for i < len(attributesStr) && (unicode.IsLetter(rune(attributesStr[i])) || unicode.IsDigit(rune(attributesStr[i])) || attributesStr[i] == '_' || attributesStr[i] == '.') {
 				i++
 			}
 			attrName := attributesStr[nameStart:i]
@@ -166,11 +246,16 @@ func (av *AttributeValidator) parseFieldAttributes(attributesStr string, fieldDe
 				i++
 				level := 1
 				valueStart := i
+				inString := false
 				for i < len(attributesStr) && level > 0 {
-					switch attributesStr[i] {
-					case '(':
+					switch {
+					case inString && attributesStr[i] == '\\':
+						i++
+					case attributesStr[i] == '"':
+						inString = !inString
+					case !inString && attributesStr[i] == '(':
 						level++
-					case ')':
+					case !inString && attributesStr[i] == ')':
 						level--
 					}
 					i++
@@ -202,6 +287,7 @@ func (av *AttributeValidator) isDirective(attrName string) bool {
 	directives := []string{
 		constants.FIELD_ATTR_PRIMARY_KEY,
 		constants.FIELD_ATTR_UNIQUE,
+		constants.FIELD_ATTR_UPDATED_AT,
 	}
 
 	for _, directive := range directives {
@@ -231,6 +317,13 @@ func (av *AttributeValidator) ValidateFieldDefinition(fieldDef *AttributeDefinit
 	}
 
 	for _, attr := range fieldDef.Attributes {
+		if native.IsNativeAttribute(attr.Name) {
+			if err := av.validateNativeAttribute(attr, fieldDef); err != nil {
+				return err
+			}
+			continue
+		}
+
 		switch attr.Name {
 		case constants.FIELD_ATTR_DEFAULT:
 			if err := av.validateDefaultAttribute(attr, fieldDef.DataType, fieldDef.IsArray); err != nil {
@@ -240,6 +333,10 @@ func (av *AttributeValidator) ValidateFieldDefinition(fieldDef *AttributeDefinit
 			if err := av.validateRelationAttribute(attr, fieldDef, className); err != nil {
 				return err
 			}
+		case constants.FIELD_ATTR_COMPUTED:
+			if err := av.validateComputedAttribute(attr, fieldDef); err != nil {
+				return err
+			}
 		default:
 			return fmt.Errorf("unknown field attribute '@%s'", attr.Name)
 		}
@@ -297,6 +394,52 @@ func (av *AttributeValidator) validateDefaultAttribute(attr *Attribute, fieldTyp
 	return nil
 }
 
+func (av *AttributeValidator) validateComputedAttribute(attr *Attribute, fieldDef *AttributeDefinition) error {
+	if attr.Value == nil {
+		return fmt.Errorf("@computed attribute requires an expression")
+	}
+
+	computedStr, ok := attr.Value.(string)
+	if !ok {
+		return fmt.Errorf("@computed value must be a string expression")
+	}
+
+	if _, err := ParseComputedExpression(computedStr); err != nil {
+		return err
+	}
+
+	if fieldDef.Kind == constants.FIELD_KIND_OBJECT {
+		return fmt.Errorf("@computed cannot be used on relation fields")
+	}
+
+	if fieldDef.IsPrimaryKey() {
+		return fmt.Errorf("@computed field cannot be a primary key")
+	}
+
+	if fieldDef.IsUpdatedAt() {
+		return fmt.Errorf("@computed and @updatedAt cannot be used together")
+	}
+
+	return nil
+}
+
+func (av *AttributeValidator) validateNativeAttribute(attr *Attribute, fieldDef *AttributeDefinition) error {
+	nativeType, err := native.ParseNativeType(attr.Name, attr.Value)
+	if err != nil {
+		return err
+	}
+
+	if fieldDef.Kind == constants.FIELD_KIND_DOMAIN {
+		return fmt.Errorf("%s cannot be used on domain fields; declare it on domain '%s' instead", nativeType.String(), fieldDef.DataType)
+	}
+
+	if fieldDef.Kind != constants.FIELD_KIND_SCALAR {
+		return fmt.Errorf("%s can only be used on scalar fields", nativeType.String())
+	}
+
+	return nativeType.ValidateForType(fieldDef.DataType)
+}
+
 func (av *AttributeValidator) validateRelationAttribute(attr *Attribute, fieldDef *AttributeDefinition, className string) error {
 	if attr.Value == nil {
 		return fmt.Errorf("@relation attribute requires parameters")
@@ -374,10 +517,18 @@ func (av *AttributeValidator) GetDirectiveValidator() *directives.DirectiveValid
 }
 
 func (av *AttributeValidator) ValidateAttribute(attr *Attribute, fieldType string, isOptional bool, isArray bool) error {
+	if native.IsNativeAttribute(attr.Name) {
+		nativeType, err := native.ParseNativeType(attr.Name, attr.Value)
+		if err != nil {
+			return err
+		}
+		return nativeType.ValidateForType(fieldType)
+	}
+
 	switch attr.Name {
 	case constants.FIELD_ATTR_DEFAULT:
 		return av.validateDefaultAttribute(attr, fieldType, isArray)
-	case constants.FIELD_ATTR_RELATION:
+	case constants.FIELD_ATTR_RELATION, constants.FIELD_ATTR_COMPUTED:
 		return nil
 	default:
 		return fmt.Errorf("unknown field attribute '@%s'", attr.Name)
@@ -406,11 +557,11 @@ func (av *AttributeValidator) ParseAttributeFromString(attrStr string) (*Attribu
 		}
 
 		switch attrName {
-		case constants.FIELD_ATTR_DEFAULT, constants.FIELD_ATTR_RELATION:
+		case constants.FIELD_ATTR_DEFAULT, constants.FIELD_ATTR_RELATION, constants.FIELD_ATTR_COMPUTED:
 			if attrValue == "" {
 				return nil, fmt.Errorf("@%s attribute requires non-empty parameters", attrName)
 			}
-		case constants.FIELD_ATTR_PRIMARY_KEY, constants.FIELD_ATTR_UNIQUE:
+		case constants.FIELD_ATTR_PRIMARY_KEY, constants.FIELD_ATTR_UNIQUE, constants.FIELD_ATTR_UPDATED_AT:
 			return nil, fmt.Errorf("@%s attribute does not accept parameters", attrName)
 		}
 
@@ -430,6 +581,8 @@ func (av *AttributeValidator) ParseAttributeFromString(attrStr string) (*Attribu
 			return nil, fmt.Errorf("@default attribute requires parameters")
 		case constants.FIELD_ATTR_RELATION:
 			return nil, fmt.Errorf("@relation attribute requires parameters")
+		case constants.FIELD_ATTR_COMPUTED:
+			return nil, fmt.Errorf("@computed attribute requires an expression")
 		}
 
 		return &Attribute{
@@ -440,11 +593,17 @@ func (av *AttributeValidator) ParseAttributeFromString(attrStr string) (*Attribu
 }
 
 func (av *AttributeValidator) isValidAttributeName(name string) bool {
+	if native.IsNativeAttribute(name) {
+		return true
+	}
+
 	validAttributes := []string{
 		constants.FIELD_ATTR_PRIMARY_KEY,
 		constants.FIELD_ATTR_UNIQUE,
 		constants.FIELD_ATTR_DEFAULT,
 		constants.FIELD_ATTR_RELATION,
+		constants.FIELD_ATTR_UPDATED_AT,
+		constants.FIELD_ATTR_COMPUTED,
 	}
 
 	for _, valid := range validAttributes {
@@ -461,7 +620,8 @@ func (av *AttributeValidator) ValidateMultipleAttributes(attrs []*Attribute, fie
 	}
 
 	attributeCount := make(map[string]int)
-	var hasDefault, hasRelation bool
+	var hasDefault, hasRelation, hasComputed bool
+	nativeCount := 0
 
 	for _, attr := range attrs {
 		if attr == nil {
@@ -483,12 +643,26 @@ func (av *AttributeValidator) ValidateMultipleAttributes(attrs []*Attribute, fie
 			hasDefault = true
 		case constants.FIELD_ATTR_RELATION:
 			hasRelation = true
+		case constants.FIELD_ATTR_COMPUTED:
+			hasComputed = true
+		}
+
+		if native.IsNativeAttribute(attr.Name) {
+			nativeCount++
 		}
 	}
 
+	if nativeCount > 1 {
+		return fmt.Errorf("only one native type attribute is allowed per field")
+	}
+
 	if hasRelation && hasDefault {
 	}
 
+	if hasComputed && hasDefault {
+		return fmt.Errorf("@computed and @default cannot be used together")
+	}
+
 	return nil
 }
 
@@ -520,6 +694,10 @@ func (fd *AttributeDefinition) IsUnique() bool {
 	return fd.HasDirective(constants.FIELD_ATTR_UNIQUE)
 }
 
+func (fd *AttributeDefinition) IsUpdatedAt() bool {
+	return fd.HasDirective(constants.FIELD_ATTR_UPDATED_AT)
+}
+
 func (fd *AttributeDefinition) HasDefault() bool {
 	return fd.DefaultValue != nil
 }
@@ -532,6 +710,18 @@ func (fd *AttributeDefinition) GetRelation() *relations.Relation {
 	return fd.Relation
 }
 
+func (fd *AttributeDefinition) IsComputed() bool {
+	return fd.Computed != ""
+}
+
+func (fd *AttributeDefinition) GetComputedExpression() string {
+	return fd.Computed
+}
+
+func (fd *AttributeDefinition) GetNativeType() *native.NativeType {
+	return fd.NativeType
+}
+
 func (fd *AttributeDefinition) String() string {
 	var builder strings.Builder
 
@@ -656,6 +846,8 @@ func (ad *AttributeDefinition) Clone() *AttributeDefinition {
 		IsArray:      ad.IsArray,
 		DefaultValue: ad.DefaultValue,
 		Relation:     ad.Relation,
+		Computed:     ad.Computed,
+		NativeType:   ad.NativeType,
 	}
 
 	for _, attr := range ad.Attributes {
@@ -6,6 +6,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"math"
+	"net/netip"
 	"regexp"
 	"strconv"
 	"strings"
@@ -23,11 +24,19 @@ type DefaultValue struct {
 }
 
 type DefaultValidator struct {
-	enums           map[string]*enum.Enum
-	callbackPattern *regexp.Regexp
-	stringPattern   *regexp.Regexp
-	arrayPattern    *regexp.Regexp
-	elementRegex    *regexp.Regexp
+	enums              map[string]*enum.Enum
+	composites         map[string]bool
+	domains            map[string]string
+	callbackPattern    *regexp.Regexp
+	nextvalPattern     *regexp.Regexp
+	stringPattern      *regexp.Regexp
+	intervalPattern    *regexp.Regexp
+	uuidPattern        *regexp.Regexp
+	decimalPattern     *regexp.Regexp
+	rangePattern       *regexp.Regexp
+	isoIntervalPattern *regexp.Regexp
+	arrayPattern       *regexp.Regexp
+	elementRegex       *regexp.Regexp
 }
 
 func NewDefaultValidator(enums map[string]*enum.Enum) *DefaultValidator {
@@ -36,11 +45,19 @@ func NewDefaultValidator(enums map[string]*enum.Enum) *DefaultValidator {
 	arrayPattern := regexp.MustCompile(`^\s*\[.*\]\s*$`)
 
 	dv := &DefaultValidator{
-		enums:           enums,
-		callbackPattern: callbackPattern,
-		stringPattern:   stringPattern,
-		arrayPattern:    arrayPattern,
-		elementRegex:    regexp.MustCompile(`(?:"(?:[^"\\]|\\.)*"|'(?:[^'\\]|\\.)*'|[^,]+)`),
+		enums:              enums,
+		composites:         make(map[string]bool),
+		domains:            make(map[string]string),
+		callbackPattern:    callbackPattern,
+		nextvalPattern:     regexp.MustCompile(`^` + constants.DEFAULT_NEXTVAL_FUNCTION + `\(\s*([A-Za-z_][A-Za-z0-9_]*)\s*\)$`),
+		stringPattern:      stringPattern,
+		intervalPattern:    regexp.MustCompile(`^(?i)(?:[+-]?\d+(?:\.\d+)?\s*(?:microseconds?|milliseconds?|seconds?|secs?|minutes?|mins?|hours?|days?|weeks?|months?|mons?|years?|decades?|centuries|century|millenniums?)\s*)*(?:[+-]?\d+:\d{2}(?::\d{2}(?:\.\d+)?)?)?$`),
+		uuidPattern:        regexp.MustCompile(`^(?i)[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}$`),
+		decimalPattern:     regexp.MustCompile(`^[+-]?(?:\d+(?:\.\d*)?|\.\d+)$`),
+		rangePattern:       regexp.MustCompile(`^([\[(])\s*("[^"]*"|[^,]*?)\s*,\s*("[^"]*"|[^,]*?)\s*([\])])$`),
+		isoIntervalPattern: regexp.MustCompile(`^P(?:\d+Y)?(?:\d+M)?(?:\d+W)?(?:\d+D)?(?:T(?:\d+H)?(?:\d+M)?(?:\d+(?:\.\d+)?S)?)?$`),
+		arrayPattern:       arrayPattern,
+		elementRegex:       regexp.MustCompile(`(?:"(?:[^"\\]|\\.)*"|'(?:[^'\\]|\\.)*'|[^,]+)`),
 	}
 
 	return dv
@@ -68,6 +85,14 @@ func (dv *DefaultValidator) ValidateDefault(defaultStr string, fieldType string,
 		}
 	}
 
+	if domainBase, exists := dv.domains[baseType]; exists {
+		baseType = domainBase
+	}
+
+	if dv.composites[baseType] {
+		return nil, fmt.Errorf("default values are not supported on composite type '%s'", baseType)
+	}
+
 	if isArray {
 		if !dv.arrayPattern.MatchString(defaultStr) {
 			return nil, fmt.Errorf("array field requires array default value syntax [element1, element2, ...], got: %s", defaultStr)
@@ -79,8 +104,12 @@ func (dv *DefaultValidator) ValidateDefault(defaultStr string, fieldType string,
 		return nil, fmt.Errorf("non-array field cannot have array default value: %s", defaultStr)
 	}
 
+	if matches := dv.nextvalPattern.FindStringSubmatch(defaultStr); matches != nil {
+		return dv.validateNextval(matches[1], baseType)
+	}
+
 	if dv.callbackPattern.MatchString(defaultStr) {
-		return dv.validateCallback(defaultStr, fieldType)
+		return dv.validateCallback(defaultStr, baseType)
 	}
 
 	if dv.isEnumType(baseType) {
@@ -232,6 +261,21 @@ func (dv *DefaultValidator) validateCallback(callback string, fieldType string)
 	}, nil
 }
 
+func (dv *DefaultValidator) validateNextval(sequenceName string, fieldType string) (*DefaultValue, error) {
+	scalarType, exists := constants.GetScalarType(fieldType)
+	if !exists || !constants.IsCallbackCompatibleWithType(constants.DEFAULT_AUTOINCREMENT_CALLBACK, scalarType) {
+		return nil, fmt.Errorf("%s(%s) can only be used with Int, BigInt or SmallInt fields, got '%s'",
+			constants.DEFAULT_NEXTVAL_FUNCTION, sequenceName, fieldType)
+	}
+
+	return &DefaultValue{
+		Value:    sequenceName,
+		Type:     constants.KEYWORD_SEQUENCE,
+		DataType: fieldType,
+		IsArray:  false,
+	}, nil
+}
+
 func (dv *DefaultValidator) validateEnumDefault(defaultStr string, enumType string) (*DefaultValue, error) {
 	enumDef, exists := dv.enums[enumType]
 	if !exists {
@@ -286,14 +330,26 @@ func (dv *DefaultValidator) validateScalarDefault(defaultStr string, fieldType s
 		return dv.validateFloatDefault(defaultStr, fieldType)
 	case constants.STRING:
 		return dv.validateStringDefault(defaultStr, fieldType)
+	case constants.UUID:
+		return dv.validateUuidDefault(defaultStr, fieldType)
+	case constants.DECIMAL:
+		return dv.validateDecimalDefault(defaultStr, fieldType)
+	case constants.INET, constants.CIDR:
+		return dv.validateNetworkDefault(defaultStr, fieldType)
+	case constants.INT4RANGE, constants.TSTZRANGE:
+		return dv.validateRangeDefault(defaultStr, fieldType)
 	case constants.BOOLEAN:
 		return dv.validateBooleanDefault(defaultStr, fieldType)
 	case constants.CHAR:
 		return dv.validateCharDefault(defaultStr, fieldType)
 	case constants.DATE:
 		return dv.validateDateDefault(defaultStr, fieldType)
-	case constants.TIMESTAMP:
+	case constants.TIMESTAMP, constants.TIMESTAMPTZ:
 		return dv.validateTimestampDefault(defaultStr, fieldType)
+	case constants.TIME:
+		return dv.validateTimeDefault(defaultStr, fieldType)
+	case constants.INTERVAL:
+		return dv.validateIntervalDefault(defaultStr, fieldType)
 	case constants.JSON:
 		return dv.validateJsonDefault(defaultStr, fieldType)
 	case constants.BYTES:
@@ -496,6 +552,8 @@ func (dv *DefaultValidator) validateTimestampDefault(defaultStr string, fieldTyp
 		"2006-01-02T15:04:05",
 		"2006-01-02 15:04:05.000",
 		"2006-01-02T15:04:05.000",
+		"2006-01-02 15:04:05-07",
+		"2006-01-02 15:04:05.999999-07",
 	}
 
 	for _, format := range timestampFormats {
@@ -513,6 +571,171 @@ func (dv *DefaultValidator) validateTimestampDefault(defaultStr string, fieldTyp
 		defaultStr)
 }
 
+func (dv *DefaultValidator) validateTimeDefault(defaultStr string, fieldType string) (*DefaultValue, error) {
+	cleanValue := defaultStr
+	if dv.stringPattern.MatchString(defaultStr) {
+		cleanValue = defaultStr[1 : len(defaultStr)-1]
+	}
+
+	cleanValue = strings.TrimSpace(cleanValue)
+
+	timeFormats := []string{
+		"15:04",
+		"15:04:05",
+		"15:04:05.999999",
+	}
+
+	for _, format := range timeFormats {
+		if _, err := time.Parse(format, cleanValue); err == nil {
+			return &DefaultValue{
+				Value:    cleanValue,
+				Type:     "literal",
+				DataType: fieldType,
+				IsArray:  false,
+			}, nil
+		}
+	}
+
+	return nil, fmt.Errorf("invalid time default value '%s'. Expected formats: HH:MM, HH:MM:SS or HH:MM:SS.ffffff", defaultStr)
+}
+
+func (dv *DefaultValidator) validateIntervalDefault(defaultStr string, fieldType string) (*DefaultValue, error) {
+	cleanValue := defaultStr
+	if dv.stringPattern.MatchString(defaultStr) {
+		cleanValue = defaultStr[1 : len(defaultStr)-1]
+	}
+
+	cleanValue = strings.TrimSpace(cleanValue)
+
+	if cleanValue == "" || (!dv.intervalPattern.MatchString(cleanValue) && !dv.isoIntervalPattern.MatchString(cleanValue)) {
+		return nil, fmt.Errorf("invalid interval default value '%s'. Expected a PostgreSQL interval such as '1 day', '2 hours 30 minutes', '01:30:00' or an ISO 8601 duration such as 'P1DT2H'", defaultStr)
+	}
+
+	return &DefaultValue{
+		Value:    cleanValue,
+		Type:     "literal",
+		DataType: fieldType,
+		IsArray:  false,
+	}, nil
+}
+
+func (dv *DefaultValidator) validateUuidDefault(defaultStr string, fieldType string) (*DefaultValue, error) {
+	cleanValue := defaultStr
+	if dv.stringPattern.MatchString(defaultStr) {
+		cleanValue = defaultStr[1 : len(defaultStr)-1]
+	}
+
+	cleanValue = strings.TrimSpace(cleanValue)
+
+	if !dv.uuidPattern.MatchString(cleanValue) {
+		return nil, fmt.Errorf("invalid uuid default value '%s'. Expected format: xxxxxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxxx", defaultStr)
+	}
+
+	return &DefaultValue{
+		Value:    strings.ToLower(cleanValue),
+		Type:     "literal",
+		DataType: fieldType,
+		IsArray:  false,
+	}, nil
+}
+
+func (dv *DefaultValidator) validateDecimalDefault(defaultStr string, fieldType string) (*DefaultValue, error) {
+	cleanValue := defaultStr
+	if dv.stringPattern.MatchString(defaultStr) {
+		cleanValue = defaultStr[1 : len(defaultStr)-1]
+	}
+
+	cleanValue = strings.TrimSpace(cleanValue)
+
+	if !dv.decimalPattern.MatchString(cleanValue) {
+		return nil, fmt.Errorf("invalid decimal default value '%s' for type %s", defaultStr, fieldType)
+	}
+
+	return &DefaultValue{
+		Value:    cleanValue,
+		Type:     "literal",
+		DataType: fieldType,
+		IsArray:  false,
+	}, nil
+}
+
+func (dv *DefaultValidator) validateNetworkDefault(defaultStr string, fieldType string) (*DefaultValue, error) {
+	cleanValue := defaultStr
+	if dv.stringPattern.MatchString(defaultStr) {
+		cleanValue = defaultStr[1 : len(defaultStr)-1]
+	}
+
+	cleanValue = strings.TrimSpace(cleanValue)
+
+	if fieldType == constants.CIDR.String() {
+		prefix, err := netip.ParsePrefix(cleanValue)
+		if err != nil {
+			return nil, fmt.Errorf("invalid cidr default value '%s': %v", defaultStr, err)
+		}
+		if prefix.Masked() != prefix {
+			return nil, fmt.Errorf("invalid cidr default value '%s': host bits must be zero (did you mean '%s'?)", defaultStr, prefix.Masked())
+		}
+	} else if _, err := netip.ParsePrefix(cleanValue); err != nil {
+		if _, err := netip.ParseAddr(cleanValue); err != nil {
+			return nil, fmt.Errorf("invalid inet default value '%s': expected an IP address or address/prefix", defaultStr)
+		}
+	}
+
+	return &DefaultValue{
+		Value:    cleanValue,
+		Type:     "literal",
+		DataType: fieldType,
+		IsArray:  false,
+	}, nil
+}
+
+func (dv *DefaultValidator) validateRangeDefault(defaultStr string, fieldType string) (*DefaultValue, error) {
+	cleanValue := defaultStr
+	if dv.stringPattern.MatchString(defaultStr) {
+		cleanValue = defaultStr[1 : len(defaultStr)-1]
+	}
+
+	cleanValue = strings.TrimSpace(strings.ReplaceAll(cleanValue, `\"`, `"`))
+
+	if strings.EqualFold(cleanValue, "empty") {
+		return &DefaultValue{
+			Value:    "empty",
+			Type:     "literal",
+			DataType: fieldType,
+			IsArray:  false,
+		}, nil
+	}
+
+	matches := dv.rangePattern.FindStringSubmatch(cleanValue)
+	if matches == nil {
+		return nil, fmt.Errorf("invalid range default value '%s'. Expected format: [lower,upper), (lower,upper], or 'empty'", defaultStr)
+	}
+
+	for _, bound := range []string{matches[2], matches[3]} {
+		bound = strings.Trim(bound, `"`)
+		if bound == "" {
+			continue
+		}
+
+		var err error
+		if fieldType == constants.INT4RANGE.String() {
+			_, err = dv.validateIntDefault(bound, constants.INT.String())
+		} else {
+			_, err = dv.validateTimestampDefault(bound, constants.TIMESTAMPTZ.String())
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid bound in range default value '%s': %v", defaultStr, err)
+		}
+	}
+
+	return &DefaultValue{
+		Value:    cleanValue,
+		Type:     "literal",
+		DataType: fieldType,
+		IsArray:  false,
+	}, nil
+}
+
 func (dv *DefaultValidator) validateJsonDefault(defaultStr string, fieldType string) (*DefaultValue, error) {
 	cleanValue := defaultStr
 	if dv.stringPattern.MatchString(defaultStr) {
@@ -588,6 +811,23 @@ func (dv *DefaultValidator) GetEnum(enumName string) (*enum.Enum, bool) {
 	return e, exists
 }
 
+func (dv *DefaultValidator) RegisterComposite(name string) {
+	dv.composites[name] = true
+}
+
+func (dv *DefaultValidator) RegisterDomain(name string, baseType string) {
+	dv.domains[name] = baseType
+}
+
+func (dv *DefaultValidator) IsComposite(typeName string) bool {
+	return dv.composites[typeName]
+}
+
+func (dv *DefaultValidator) GetDomainBaseType(domainName string) (string, bool) {
+	baseType, exists := dv.domains[domainName]
+	return baseType, exists
+}
+
 func (dv *DefaultValidator) IsValidCallback(callback string) bool {
 	return constants.IsValidCallback(callback)
 }
@@ -622,6 +862,9 @@ func (dv *DefaultValue) String() string {
 	if dv.Type == "callback" {
 		return dv.Value.(string)
 	}
+	if dv.Type == constants.KEYWORD_SEQUENCE {
+		return fmt.Sprintf("%s(%v)", constants.DEFAULT_NEXTVAL_FUNCTION, dv.Value)
+	}
 	if dv.IsArray {
 		if arr, ok := dv.Value.([]interface{}); ok {
 			var elements []string
@@ -650,6 +893,10 @@ func (dv *DefaultValue) IsEnum() bool {
 	return dv.Type == "enum"
 }
 
+func (dv *DefaultValue) IsSequence() bool {
+	return dv.Type == constants.KEYWORD_SEQUENCE
+}
+
 func (dv *DefaultValue) IsLiteral() bool {
 	return dv.Type == "literal"
 }
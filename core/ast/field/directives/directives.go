@@ -27,6 +27,8 @@ func (av *DirectiveValidator) ValidateDirective(attr *FieldDirective, fieldType
 		return av.validatePrimaryKeyDirective(attr, isOptional, isArray)
 	case constants.FIELD_ATTR_UNIQUE:
 		return av.validateUniqueDirective(attr, isArray)
+	case constants.FIELD_ATTR_UPDATED_AT:
+		return av.validateUpdatedAtDirective(attr, fieldType, isArray)
 	default:
 		return fmt.Errorf("unknown field directive '@%s'", attr.Name)
 	}
@@ -55,13 +57,26 @@ func (av *DirectiveValidator) validateUniqueDirective(attr *FieldDirective, isAr
 	return nil
 }
 
+func (av *DirectiveValidator) validateUpdatedAtDirective(attr *FieldDirective, fieldType string, isArray bool) error {
+	if isArray {
+		return fmt.Errorf("@updatedAt field cannot be an array")
+	}
+	if fieldType != constants.TIMESTAMP.String() && fieldType != constants.TIMESTAMPTZ.String() && fieldType != constants.DATE.String() {
+		return fmt.Errorf("@updatedAt can only be used with Timestamp, TimestampTz or Date fields, got '%s'", fieldType)
+	}
+	if attr.Value != nil {
+		return fmt.Errorf("@updatedAt directive does not accept parameters")
+	}
+	return nil
+}
+
 func (av *DirectiveValidator) ValidateMultipleDirectives(attrs []*FieldDirective, fieldType string, isOptional bool, isArray bool) error {
 	if len(attrs) == 0 {
 		return nil
 	}
 
 	DirectiveCount := make(map[string]int)
-	var hasPrimaryKey, hasUnique bool
+	var hasPrimaryKey, hasUnique, hasUpdatedAt bool
 
 	for _, attr := range attrs {
 		if attr == nil {
@@ -83,6 +98,8 @@ func (av *DirectiveValidator) ValidateMultipleDirectives(attrs []*FieldDirective
 			hasPrimaryKey = true
 		case constants.FIELD_ATTR_UNIQUE:
 			hasUnique = true
+		case constants.FIELD_ATTR_UPDATED_AT:
+			hasUpdatedAt = true
 		}
 	}
 
@@ -90,6 +107,10 @@ func (av *DirectiveValidator) ValidateMultipleDirectives(attrs []*FieldDirective
 		return fmt.Errorf("@primarykey and @unique cannot be used together (primary key is inherently unique)")
 	}
 
+	if hasUpdatedAt && hasPrimaryKey {
+		return fmt.Errorf("@updatedAt field cannot be a primary key")
+	}
+
 	return nil
 }
 
@@ -150,4 +171,8 @@ func HasPrimaryKey(attrs []*FieldDirective) bool {
 
 func HasUnique(attrs []*FieldDirective) bool {
 	return HasDirective(attrs, constants.FIELD_ATTR_UNIQUE)
+}
+
+func HasUpdatedAt(attrs []*FieldDirective) bool {
+	return HasDirective(attrs, constants.FIELD_ATTR_UPDATED_AT)
 }
\ No newline at end of file
@@ -6,6 +6,7 @@ import (
 
 	"github.com/rit3sh-x/blaze/core/ast/enum"
 	"github.com/rit3sh-x/blaze/core/ast/field/attributes"
+	"github.com/rit3sh-x/blaze/core/ast/field/native"
 	"github.com/rit3sh-x/blaze/core/constants"
 )
 
@@ -143,6 +144,34 @@ func (f *Field) IsUnique() bool {
 	return f.AttributeDefinition.IsUnique()
 }
 
+func (f *Field) IsUpdatedAt() bool {
+	if f.AttributeDefinition == nil {
+		return false
+	}
+	return f.AttributeDefinition.IsUpdatedAt()
+}
+
+func (f *Field) IsComputed() bool {
+	if f.AttributeDefinition == nil {
+		return false
+	}
+	return f.AttributeDefinition.IsComputed()
+}
+
+func (f *Field) GetComputedExpression() string {
+	if f.AttributeDefinition == nil {
+		return ""
+	}
+	return f.AttributeDefinition.GetComputedExpression()
+}
+
+func (f *Field) GetNativeType() *native.NativeType {
+	if f.AttributeDefinition == nil {
+		return nil
+	}
+	return f.AttributeDefinition.GetNativeType()
+}
+
 func (f *Field) GetKind() string {
 	if f.AttributeDefinition == nil {
 		return ""
@@ -164,6 +193,20 @@ func (f *Field) IsEnum() bool {
 	return f.AttributeDefinition.Kind == constants.FIELD_KIND_ENUM
 }
 
+func (f *Field) IsComposite() bool {
+	if f.AttributeDefinition == nil {
+		return false
+	}
+	return f.AttributeDefinition.Kind == constants.FIELD_KIND_COMPOSITE
+}
+
+func (f *Field) IsDomain() bool {
+	if f.AttributeDefinition == nil {
+		return false
+	}
+	return f.AttributeDefinition.Kind == constants.FIELD_KIND_DOMAIN
+}
+
 func (f *Field) IsObject() bool {
 	if f.AttributeDefinition == nil {
 		return false
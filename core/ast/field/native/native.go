@@ -0,0 +1,200 @@
+package native
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/rit3sh-x/blaze/core/constants"
+)
+
+type NativeType struct {
+	Name string
+	Args []int
+}
+
+type nativeTypeSpec struct {
+	sqlName string
+	scalars []constants.ScalarType
+	minArgs int
+	maxArgs int
+}
+
+const MAX_CHARACTER_LENGTH = 10485760
+
+var nativeTypeSpecs = map[string]nativeTypeSpec{
+	constants.NATIVE_TYPE_VARCHAR:     {sqlName: "VARCHAR", scalars: []constants.ScalarType{constants.STRING}, minArgs: 1, maxArgs: 1},
+	constants.NATIVE_TYPE_CHAR:        {sqlName: "CHAR", scalars: []constants.ScalarType{constants.STRING}, minArgs: 1, maxArgs: 1},
+	constants.NATIVE_TYPE_TEXT:        {sqlName: "TEXT", scalars: []constants.ScalarType{constants.STRING}, minArgs: 0, maxArgs: 0},
+	constants.NATIVE_TYPE_NUMERIC:     {sqlName: "NUMERIC", scalars: []constants.ScalarType{constants.NUMERIC, constants.DECIMAL}, minArgs: 1, maxArgs: 2},
+	constants.NATIVE_TYPE_TIMESTAMP:   {sqlName: "TIMESTAMP", scalars: []constants.ScalarType{constants.TIMESTAMP}, minArgs: 1, maxArgs: 1},
+	constants.NATIVE_TYPE_TIMESTAMPTZ: {sqlName: "TIMESTAMPTZ", scalars: []constants.ScalarType{constants.TIMESTAMPTZ}, minArgs: 1, maxArgs: 1},
+	constants.NATIVE_TYPE_TIME:        {sqlName: "TIME", scalars: []constants.ScalarType{constants.TIME}, minArgs: 1, maxArgs: 1},
+}
+
+func IsNativeAttribute(attrName string) bool {
+	return strings.HasPrefix(attrName, constants.FIELD_ATTR_NATIVE)
+}
+
+func ParseNativeType(attrName string, value interface{}) (*NativeType, error) {
+	name := strings.TrimPrefix(attrName, constants.FIELD_ATTR_NATIVE)
+	spec, exists := nativeTypeSpecs[name]
+	if !exists {
+		return nil, fmt.Errorf("unknown native type '@%s'. Valid native types: @%s", attrName, strings.Join(ValidNativeTypes(), ", @"))
+	}
+
+	nativeType := &NativeType{Name: name}
+
+	if value != nil {
+		valueStr, ok := value.(string)
+		if !ok {
+			return nil, fmt.Errorf("@%s arguments must be integers", attrName)
+		}
+		for _, raw := range strings.Split(valueStr, ",") {
+			raw = strings.TrimSpace(raw)
+			if raw == "" {
+				continue
+			}
+			arg, err := strconv.Atoi(raw)
+			if err != nil {
+				return nil, fmt.Errorf("@%s argument '%s' is not an integer", attrName, raw)
+			}
+			nativeType.Args = append(nativeType.Args, arg)
+		}
+	}
+
+	if len(nativeType.Args) < spec.minArgs || len(nativeType.Args) > spec.maxArgs {
+		if spec.minArgs == spec.maxArgs {
+			return nil, fmt.Errorf("@%s expects %d argument(s), got %d", attrName, spec.minArgs, len(nativeType.Args))
+		}
+		return nil, fmt.Errorf("@%s expects between %d and %d arguments, got %d", attrName, spec.minArgs, spec.maxArgs, len(nativeType.Args))
+	}
+
+	if err := nativeType.validateArgs(); err != nil {
+		return nil, err
+	}
+
+	return nativeType, nil
+}
+
+func (nt *NativeType) validateArgs() error {
+	switch nt.Name {
+	case constants.NATIVE_TYPE_VARCHAR, constants.NATIVE_TYPE_CHAR:
+		if nt.Args[0] < 1 || nt.Args[0] > MAX_CHARACTER_LENGTH {
+			return fmt.Errorf("%s length must be between 1 and %d, got %d", nt.String(), MAX_CHARACTER_LENGTH, nt.Args[0])
+		}
+	case constants.NATIVE_TYPE_NUMERIC:
+		if nt.Args[0] < 1 || nt.Args[0] > 1000 {
+			return fmt.Errorf("%s precision must be between 1 and 1000, got %d", nt.String(), nt.Args[0])
+		}
+		if len(nt.Args) == 2 && (nt.Args[1] < 0 || nt.Args[1] > nt.Args[0]) {
+			return fmt.Errorf("%s scale must be between 0 and the precision %d, got %d", nt.String(), nt.Args[0], nt.Args[1])
+		}
+	case constants.NATIVE_TYPE_TIMESTAMP, constants.NATIVE_TYPE_TIMESTAMPTZ, constants.NATIVE_TYPE_TIME:
+		if nt.Args[0] < 0 || nt.Args[0] > 6 {
+			return fmt.Errorf("%s precision must be between 0 and 6, got %d", nt.String(), nt.Args[0])
+		}
+	}
+	return nil
+}
+
+func (nt *NativeType) ValidateForType(fieldType string) error {
+	spec := nativeTypeSpecs[nt.Name]
+	for _, scalar := range spec.scalars {
+		if fieldType == scalar.String() {
+			return nil
+		}
+	}
+
+	var names []string
+	for _, scalar := range spec.scalars {
+		names = append(names, scalar.String())
+	}
+	return fmt.Errorf("%s cannot be used with field type '%s'. Compatible types: %s", nt.String(), fieldType, strings.Join(names, ", "))
+}
+
+func (nt *NativeType) SQL() string {
+	spec := nativeTypeSpecs[nt.Name]
+	if len(nt.Args) == 0 {
+		return spec.sqlName
+	}
+
+	args := make([]string, len(nt.Args))
+	for i, arg := range nt.Args {
+		args[i] = strconv.Itoa(arg)
+	}
+	return fmt.Sprintf("%s(%s)", spec.sqlName, strings.Join(args, ","))
+}
+
+func (nt *NativeType) String() string {
+	if len(nt.Args) == 0 {
+		return fmt.Sprintf("@%s%s", constants.FIELD_ATTR_NATIVE, nt.Name)
+	}
+
+	args := make([]string, len(nt.Args))
+	for i, arg := range nt.Args {
+		args[i] = strconv.Itoa(arg)
+	}
+	return fmt.Sprintf("@%s%s(%s)", constants.FIELD_ATTR_NATIVE, nt.Name, strings.Join(args, ", "))
+}
+
+func (nt *NativeType) Equals(other *NativeType) bool {
+	if nt == nil || other == nil {
+		return nt == other
+	}
+	return nt.SQL() == other.SQL()
+}
+
+func IsNarrowing(fieldType string, from *NativeType, to *NativeType) bool {
+	switch fieldType {
+	case constants.STRING.String():
+		fromLength, toLength := characterLength(from), characterLength(to)
+		return toLength != 0 && (fromLength == 0 || toLength < fromLength)
+	case constants.NUMERIC.String(), constants.DECIMAL.String():
+		if to == nil {
+			return false
+		}
+		if from == nil {
+			return true
+		}
+		fromPrecision, fromScale := numericBounds(from)
+		toPrecision, toScale := numericBounds(to)
+		return toScale < fromScale || toPrecision-toScale < fromPrecision-fromScale
+	case constants.TIMESTAMP.String(), constants.TIMESTAMPTZ.String(), constants.TIME.String():
+		return timestampPrecision(to) < timestampPrecision(from)
+	}
+	return false
+}
+
+func ValidNativeTypes() []string {
+	return []string{
+		constants.FIELD_ATTR_NATIVE + constants.NATIVE_TYPE_VARCHAR,
+		constants.FIELD_ATTR_NATIVE + constants.NATIVE_TYPE_CHAR,
+		constants.FIELD_ATTR_NATIVE + constants.NATIVE_TYPE_TEXT,
+		constants.FIELD_ATTR_NATIVE + constants.NATIVE_TYPE_NUMERIC,
+		constants.FIELD_ATTR_NATIVE + constants.NATIVE_TYPE_TIMESTAMP,
+		constants.FIELD_ATTR_NATIVE + constants.NATIVE_TYPE_TIMESTAMPTZ,
+		constants.FIELD_ATTR_NATIVE + constants.NATIVE_TYPE_TIME,
+	}
+}
+
+func characterLength(nt *NativeType) int {
+	if nt == nil || len(nt.Args) == 0 {
+		return 0
+	}
+	return nt.Args[0]
+}
+
+func numericBounds(nt *NativeType) (int, int) {
+	if len(nt.Args) == 1 {
+		return nt.Args[0], 0
+	}
+	return nt.Args[0], nt.Args[1]
+}
+
+func timestampPrecision(nt *NativeType) int {
+	if nt == nil {
+		return 3
+	}
+	return nt.Args[0]
+}
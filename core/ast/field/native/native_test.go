@@ -0,0 +1,89 @@
+package native
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseNativeType(t *testing.T) {
+	tests := []struct {
+		name      string
+		attr      string
+		value     interface{}
+		fieldType string
+		sql       string
+		err       string
+	}{
+		{name: "varchar", attr: "db.VarChar", value: "16", fieldType: "String", sql: "VARCHAR(16)"},
+		{name: "char", attr: "db.Char", value: "8", fieldType: "String", sql: "CHAR(8)"},
+		{name: "text", attr: "db.Text", fieldType: "String", sql: "TEXT"},
+		{name: "numeric precision and scale", attr: "db.Numeric", value: "12, 2", fieldType: "Numeric", sql: "NUMERIC(12,2)"},
+		{name: "numeric on decimal", attr: "db.Numeric", value: "12", fieldType: "Decimal", sql: "NUMERIC(12)"},
+		{name: "timestamp precision", attr: "db.Timestamp", value: "3", fieldType: "Timestamp", sql: "TIMESTAMP(3)"},
+		{name: "unknown native type", attr: "db.Blob", value: "1", err: "unknown native type"},
+		{name: "missing length", attr: "db.VarChar", err: "expects 1 argument(s), got 0"},
+		{name: "too many arguments", attr: "db.Numeric", value: "1,2,3", err: "expects between 1 and 2 arguments, got 3"},
+		{name: "non integer argument", attr: "db.VarChar", value: "n", err: "is not an integer"},
+		{name: "zero length", attr: "db.VarChar", value: "0", err: "length must be between 1"},
+		{name: "scale above precision", attr: "db.Numeric", value: "4,6", err: "scale must be between 0 and the precision 4"},
+		{name: "timestamp precision above six", attr: "db.Timestamp", value: "7", err: "precision must be between 0 and 6"},
+		{name: "incompatible scalar", attr: "db.VarChar", value: "16", fieldType: "Int", err: "cannot be used with field type 'Int'"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			nativeType, err := ParseNativeType(tt.attr, tt.value)
+			if err == nil && tt.fieldType != "" {
+				err = nativeType.ValidateForType(tt.fieldType)
+			}
+			if tt.err != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.err) {
+					t.Fatalf("error = %v, want %q", err, tt.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got := nativeType.SQL(); got != tt.sql {
+				t.Errorf("SQL() = %s, want %s", got, tt.sql)
+			}
+		})
+	}
+}
+
+func TestIsNarrowing(t *testing.T) {
+	varchar := func(n int) *NativeType { return &NativeType{Name: "VarChar", Args: []int{n}} }
+	numeric := func(args ...int) *NativeType { return &NativeType{Name: "Numeric", Args: args} }
+	timestamp := func(p int) *NativeType { return &NativeType{Name: "Timestamp", Args: []int{p}} }
+
+	tests := []struct {
+		name      string
+		fieldType string
+		from      *NativeType
+		to        *NativeType
+		want      bool
+	}{
+		{name: "longer varchar", fieldType: "String", from: varchar(16), to: varchar(32), want: false},
+		{name: "shorter varchar", fieldType: "String", from: varchar(32), to: varchar(16), want: true},
+		{name: "text to varchar", fieldType: "String", from: nil, to: varchar(16), want: true},
+		{name: "varchar to text", fieldType: "String", from: varchar(16), to: nil, want: false},
+		{name: "wider numeric", fieldType: "Numeric", from: numeric(10, 2), to: numeric(12, 2), want: false},
+		{name: "fewer integer digits", fieldType: "Numeric", from: numeric(12, 2), to: numeric(10, 2), want: true},
+		{name: "fewer fraction digits", fieldType: "Numeric", from: numeric(12, 4), to: numeric(12, 2), want: true},
+		{name: "unbounded to bounded numeric", fieldType: "Decimal", from: nil, to: numeric(12, 2), want: true},
+		{name: "bounded to unbounded numeric", fieldType: "Decimal", from: numeric(12, 2), to: nil, want: false},
+		{name: "finer timestamp", fieldType: "Timestamp", from: timestamp(3), to: timestamp(6), want: false},
+		{name: "coarser timestamp", fieldType: "Timestamp", from: timestamp(6), to: timestamp(0), want: true},
+		{name: "default timestamp precision", fieldType: "Timestamp", from: nil, to: timestamp(3), want: false},
+		{name: "unrelated scalar", fieldType: "Int", from: nil, to: nil, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsNarrowing(tt.fieldType, tt.from, tt.to); got != tt.want {
+				t.Errorf("IsNarrowing() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -9,13 +9,14 @@ import (
 )
 
 type Relation struct {
-	From      []string
-	FromClass string
-	To        []string
-	ToClass   string
-	OnDelete  string
-	OnUpdate  string
-	Name      string
+	From       []string
+	FromClass  string
+	To         []string
+	ToClass    string
+	OnDelete   string
+	OnUpdate   string
+	Name       string
+	Deferrable bool
 }
 
 type RelationValidator struct {
@@ -220,6 +221,15 @@ func (rv *RelationValidator) parseRelationOptions(definition string, relation *R
 			relation.OnUpdate = paramValue
 		case "name":
 			relation.Name = paramValue
+		case "deferrable":
+			switch paramValue {
+			case "true":
+				relation.Deferrable = true
+			case "false":
+				relation.Deferrable = false
+			default:
+				return fmt.Errorf("invalid deferrable value '%s'. Valid values: true, false", paramValue)
+			}
 		default:
 			return fmt.Errorf("unknown relation parameter: %s", paramName)
 		}
@@ -303,6 +313,10 @@ func (r *Relation) String() string {
 		parts = append(parts, fmt.Sprintf("name: \"%s\"", r.Name))
 	}
 
+	if r.Deferrable {
+		parts = append(parts, "deferrable: true")
+	}
+
 	return strings.Join(parts, ", ")
 }
 
@@ -0,0 +1,152 @@
+package sequence
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/rit3sh-x/blaze/core/constants"
+)
+
+const (
+	OPTION_START     = "start"
+	OPTION_INCREMENT = "increment"
+	OPTION_CACHE     = "cache"
+)
+
+type Sequence struct {
+	Name      string
+	Start     int64
+	Increment int64
+	Cache     int64
+	Position  int
+}
+
+type SequenceValidator struct {
+	sequenceRegex     *regexp.Regexp
+	identifierPattern *regexp.Regexp
+	reservedKeywords  map[string]bool
+}
+
+func NewSequenceValidator() *SequenceValidator {
+	reserved := map[string]bool{
+		constants.KEYWORD_ENUM:     true,
+		constants.KEYWORD_CLASS:    true,
+		constants.KEYWORD_SEQUENCE: true,
+	}
+
+	for _, scalarType := range constants.ScalarTypes {
+		reserved[strings.ToLower(string(scalarType))] = true
+	}
+
+	return &SequenceValidator{
+		sequenceRegex:     regexp.MustCompile(`^` + constants.KEYWORD_SEQUENCE + `\s+([A-Za-z_][A-Za-z0-9_]*)\s*\{([^}]*)\}\s*$`),
+		identifierPattern: regexp.MustCompile(`^[a-zA-Z][a-zA-Z0-9_]{0,62}$`),
+		reservedKeywords:  reserved,
+	}
+}
+
+func NewSequence(name string) *Sequence {
+	return &Sequence{
+		Name:      name,
+		Start:     1,
+		Increment: 1,
+		Cache:     1,
+	}
+}
+
+func (v *SequenceValidator) ValidateSequenceName(name string) error {
+	if name == "" {
+		return fmt.Errorf("sequence name cannot be empty")
+	}
+
+	if !v.identifierPattern.MatchString(name) {
+		return fmt.Errorf("invalid sequence name '%s': must start with a letter, contain only alphanumeric characters/underscores, and be at most 63 characters", name)
+	}
+
+	if v.reservedKeywords[strings.ToLower(name)] {
+		return fmt.Errorf("sequence name '%s' is a reserved keyword", name)
+	}
+
+	return nil
+}
+
+func (v *SequenceValidator) ValidateSequence(seq *Sequence) error {
+	if err := v.ValidateSequenceName(seq.Name); err != nil {
+		return err
+	}
+
+	if seq.Increment == 0 {
+		return fmt.Errorf("sequence '%s' increment cannot be zero", seq.Name)
+	}
+
+	if seq.Cache < 1 {
+		return fmt.Errorf("sequence '%s' cache must be at least 1, got %d", seq.Name, seq.Cache)
+	}
+
+	return nil
+}
+
+func (v *SequenceValidator) ParseSequence(definition string, position int) (*Sequence, error) {
+	definition = strings.TrimSpace(definition)
+
+	matches := v.sequenceRegex.FindStringSubmatch(definition)
+	if matches == nil {
+		return nil, fmt.Errorf("invalid sequence definition: must match pattern 'sequence name { start N increment N cache N }'")
+	}
+
+	seq := NewSequence(matches[1])
+	seq.Position = position
+
+	tokens := strings.Fields(matches[2])
+	if len(tokens)%2 != 0 {
+		return nil, fmt.Errorf("sequence '%s' has an option without a value", seq.Name)
+	}
+
+	seen := make(map[string]bool)
+	for i := 0; i < len(tokens); i += 2 {
+		option := tokens[i]
+		if seen[option] {
+			return nil, fmt.Errorf("duplicate option '%s' in sequence '%s'", option, seq.Name)
+		}
+		seen[option] = true
+
+		value, err := strconv.ParseInt(tokens[i+1], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid value '%s' for option '%s' in sequence '%s'", tokens[i+1], option, seq.Name)
+		}
+
+		switch option {
+		case OPTION_START:
+			seq.Start = value
+		case OPTION_INCREMENT:
+			seq.Increment = value
+		case OPTION_CACHE:
+			seq.Cache = value
+		default:
+			return nil, fmt.Errorf("unknown option '%s' in sequence '%s'. Valid options: %s, %s, %s",
+				option, seq.Name, OPTION_START, OPTION_INCREMENT, OPTION_CACHE)
+		}
+	}
+
+	if err := v.ValidateSequence(seq); err != nil {
+		return nil, err
+	}
+
+	return seq, nil
+}
+
+func (s *Sequence) Equals(other *Sequence) bool {
+	return s.Start == other.Start && s.Increment == other.Increment && s.Cache == other.Cache
+}
+
+func (s *Sequence) String() string {
+	var builder strings.Builder
+	builder.WriteString(fmt.Sprintf("%s %s {\n", constants.KEYWORD_SEQUENCE, s.Name))
+	builder.WriteString(fmt.Sprintf("  %s %d\n", OPTION_START, s.Start))
+	builder.WriteString(fmt.Sprintf("  %s %d\n", OPTION_INCREMENT, s.Increment))
+	builder.WriteString(fmt.Sprintf("  %s %d\n", OPTION_CACHE, s.Cache))
+	builder.WriteString("}")
+	return builder.String()
+}
@@ -0,0 +1,333 @@
+package view
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/rit3sh-x/blaze/core/ast/class/directives"
+	"github.com/rit3sh-x/blaze/core/constants"
+)
+
+type ViewField struct {
+	Name       string
+	Type       string
+	IsArray    bool
+	IsOptional bool
+	Position   int
+}
+
+type ViewIndex struct {
+	Unique bool
+	Index  *directives.Index
+}
+
+type View struct {
+	Name         string
+	Materialized bool
+	Fields       []*ViewField
+	SQL          string
+	Indexes      []*ViewIndex
+	Position     int
+}
+
+type ViewValidator struct {
+	viewRegex         *regexp.Regexp
+	fieldRegex        *regexp.Regexp
+	directiveRegex    *regexp.Regexp
+	identifierPattern *regexp.Regexp
+	reservedKeywords  map[string]bool
+}
+
+func NewViewValidator() *ViewValidator {
+	reserved := map[string]bool{
+		constants.KEYWORD_ENUM:     true,
+		constants.KEYWORD_CLASS:    true,
+		constants.KEYWORD_SEQUENCE: true,
+		constants.KEYWORD_TYPE:     true,
+		constants.KEYWORD_DOMAIN:   true,
+		constants.KEYWORD_VIEW:     true,
+		strings.ToLower(constants.KEYWORD_MATERIALIZED_VIEW): true,
+	}
+
+	for _, scalarType := range constants.ScalarTypes {
+		reserved[strings.ToLower(string(scalarType))] = true
+	}
+
+	return &ViewValidator{
+		viewRegex:         regexp.MustCompile(`(?s)^(` + constants.KEYWORD_VIEW + `|` + constants.KEYWORD_MATERIALIZED_VIEW + `)\s+([A-Za-z_][A-Za-z0-9_]*)\s*\{(.*)\}\s*(.*)$`),
+		fieldRegex:        regexp.MustCompile(`^([a-zA-Z_][a-zA-Z0-9_]{0,62})\s+([A-Za-z_][A-Za-z0-9_]*)(\[\])?(\?)?$`),
+		directiveRegex:    regexp.MustCompile(`^@@([a-zA-Z_][a-zA-Z0-9_]*)`),
+		identifierPattern: regexp.MustCompile(`^[A-Z][a-zA-Z0-9_]{0,62}$`),
+		reservedKeywords:  reserved,
+	}
+}
+
+func (v *ViewValidator) ValidateViewName(name string) error {
+	if name == "" {
+		return fmt.Errorf("view name cannot be empty")
+	}
+
+	if !v.identifierPattern.MatchString(name) {
+		return fmt.Errorf("invalid view name '%s': must start with uppercase letter, contain only alphanumeric characters/underscores, and be at most 63 characters", name)
+	}
+
+	if v.reservedKeywords[strings.ToLower(name)] {
+		return fmt.Errorf("view name '%s' is a reserved keyword", name)
+	}
+
+	return nil
+}
+
+func (v *ViewValidator) ParseView(definition string, position int) (*View, error) {
+	definition = strings.TrimSpace(definition)
+
+	matches := v.viewRegex.FindStringSubmatch(definition)
+	if matches == nil {
+		return nil, fmt.Errorf("invalid view definition: must match pattern 'view Name { field Type ... } @@sql(\"SELECT ...\")'")
+	}
+
+	parsedView := &View{
+		Name:         matches[2],
+		Materialized: matches[1] == constants.KEYWORD_MATERIALIZED_VIEW,
+		Position:     position,
+	}
+
+	if err := v.ValidateViewName(parsedView.Name); err != nil {
+		return nil, err
+	}
+
+	if err := v.parseBody(matches[3]+"\n"+matches[4], parsedView); err != nil {
+		return nil, fmt.Errorf("view '%s': %v", parsedView.Name, err)
+	}
+
+	if len(parsedView.Fields) == 0 {
+		return nil, fmt.Errorf("view '%s' must have at least one field", parsedView.Name)
+	}
+
+	if parsedView.SQL == "" {
+		return nil, fmt.Errorf("view '%s' requires a @@%s(\"SELECT ...\") directive", parsedView.Name, constants.VIEW_ATTR_SQL)
+	}
+
+	for _, viewIndex := range parsedView.Indexes {
+		for _, fieldName := range viewIndex.Index.GetFields() {
+			if parsedView.GetFieldByName(fieldName) == nil {
+				return nil, fmt.Errorf("view '%s': index references unknown field '%s'", parsedView.Name, fieldName)
+			}
+		}
+	}
+
+	return parsedView, nil
+}
+
+func (v *ViewValidator) parseBody(body string, parsedView *View) error {
+	remaining := strings.TrimSpace(body)
+	seen := make(map[string]bool)
+
+	for remaining != "" {
+		if !strings.HasPrefix(remaining, "@@") {
+			line := remaining
+			if newline := strings.Index(remaining, "\n"); newline >= 0 {
+				line, remaining = remaining[:newline], remaining[newline+1:]
+			} else {
+				remaining = ""
+			}
+			remaining = strings.TrimSpace(remaining)
+
+			if err := v.parseField(strings.TrimSpace(line), parsedView, seen); err != nil {
+				return err
+			}
+			continue
+		}
+
+		nameMatch := v.directiveRegex.FindStringSubmatch(remaining)
+		if nameMatch == nil {
+			return fmt.Errorf("invalid directive '%s'", remaining)
+		}
+		name := nameMatch[1]
+		remaining = remaining[len(nameMatch[0]):]
+
+		if !strings.HasPrefix(remaining, "(") {
+			return fmt.Errorf("@@%s directive requires a value", name)
+		}
+		end := closingParen(remaining)
+		if end < 0 {
+			return fmt.Errorf("unbalanced parentheses in @@%s", name)
+		}
+		value := strings.TrimSpace(remaining[1:end])
+		remaining = strings.TrimSpace(remaining[end+1:])
+
+		if err := v.applyDirective(name, value, parsedView); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (v *ViewValidator) parseField(line string, parsedView *View, seen map[string]bool) error {
+	if strings.Contains(line, "@") {
+		return fmt.Errorf("attributes are not supported on view fields: %s", line)
+	}
+
+	fieldMatches := v.fieldRegex.FindStringSubmatch(line)
+	if fieldMatches == nil {
+		return fmt.Errorf("invalid field '%s': must match pattern 'name Type'", line)
+	}
+
+	if seen[fieldMatches[1]] {
+		return fmt.Errorf("duplicate field '%s'", fieldMatches[1])
+	}
+	seen[fieldMatches[1]] = true
+
+	parsedView.Fields = append(parsedView.Fields, &ViewField{
+		Name:       fieldMatches[1],
+		Type:       fieldMatches[2],
+		IsArray:    fieldMatches[3] != "",
+		IsOptional: fieldMatches[4] != "",
+		Position:   len(parsedView.Fields),
+	})
+	return nil
+}
+
+func (v *ViewValidator) applyDirective(name string, value string, parsedView *View) error {
+	switch name {
+	case constants.VIEW_ATTR_SQL:
+		if parsedView.SQL != "" {
+			return fmt.Errorf("duplicate @@%s directive", name)
+		}
+		sql, err := unquote(value)
+		if err != nil || strings.TrimSpace(sql) == "" {
+			return fmt.Errorf("@@%s requires a quoted SELECT statement", name)
+		}
+		parsedView.SQL = strings.TrimSuffix(strings.Join(strings.Fields(sql), " "), ";")
+	case constants.VIEW_ATTR_UNIQUE, constants.VIEW_ATTR_INDEX:
+		if !parsedView.Materialized {
+			return fmt.Errorf("@@%s is only supported on %s blocks", name, constants.KEYWORD_MATERIALIZED_VIEW)
+		}
+		index, err := directives.ParseIndex(value)
+		if err != nil {
+			return fmt.Errorf("invalid @@%s directive: %v", name, err)
+		}
+		if err := index.Validate(false); err != nil {
+			return fmt.Errorf("invalid @@%s directive: %v", name, err)
+		}
+		parsedView.Indexes = append(parsedView.Indexes, &ViewIndex{
+			Unique: name == constants.VIEW_ATTR_UNIQUE,
+			Index:  index,
+		})
+	default:
+		return fmt.Errorf("unknown view directive '@@%s'. Valid directives: @@%s, @@%s, @@%s", name, constants.VIEW_ATTR_SQL, constants.VIEW_ATTR_UNIQUE, constants.VIEW_ATTR_INDEX)
+	}
+
+	return nil
+}
+
+func closingParen(s string) int {
+	depth := 0
+	inString := false
+	escaped := false
+
+	for i, char := range s {
+		if inString {
+			if escaped {
+				escaped = false
+			} else if char == '\\' {
+				escaped = true
+			} else if char == '"' {
+				inString = false
+			}
+			continue
+		}
+
+		switch char {
+		case '"':
+			inString = true
+		case '(':
+			depth++
+		case ')':
+			depth--
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+
+	return -1
+}
+
+func unquote(value string) (string, error) {
+	if len(value) < 2 || value[0] != '"' || value[len(value)-1] != '"' {
+		return "", fmt.Errorf("value must be a double-quoted string")
+	}
+	return strings.ReplaceAll(value[1:len(value)-1], `\"`, `"`), nil
+}
+
+func (v *View) Keyword() string {
+	if v.Materialized {
+		return constants.KEYWORD_MATERIALIZED_VIEW
+	}
+	return constants.KEYWORD_VIEW
+}
+
+func (v *View) GetFieldByName(name string) *ViewField {
+	for _, field := range v.Fields {
+		if field.Name == name {
+			return field
+		}
+	}
+	return nil
+}
+
+func (v *View) References(name string) bool {
+	return strings.Contains(v.SQL, `"`+name+`"`)
+}
+
+func (v *View) HasUniqueIndex() bool {
+	for _, viewIndex := range v.Indexes {
+		if viewIndex.Unique && viewIndex.Index.Where == "" {
+			return true
+		}
+	}
+	return false
+}
+
+func (v *View) IndexName(viewIndex *ViewIndex) string {
+	if viewIndex.Index.Name != "" || !viewIndex.Unique {
+		return viewIndex.Index.GetName(v.Name, false)
+	}
+	return strings.ToLower(fmt.Sprintf("idx_%s_%s_unique", v.Name, strings.Join(viewIndex.Index.GetFields(), "_")))
+}
+
+func (v *View) Equals(other *View) bool {
+	return v.Materialized == other.Materialized && v.SQL == other.SQL
+}
+
+func (v *View) String() string {
+	var builder strings.Builder
+	builder.WriteString(fmt.Sprintf("%s %s {\n", v.Keyword(), v.Name))
+	for _, field := range v.Fields {
+		builder.WriteString(fmt.Sprintf("  %s %s\n", field.Name, field.TypeString()))
+	}
+	for _, viewIndex := range v.Indexes {
+		name := constants.VIEW_ATTR_INDEX
+		if viewIndex.Unique {
+			name = constants.VIEW_ATTR_UNIQUE
+		}
+		builder.WriteString(fmt.Sprintf("  @@%s(%s)\n", name, viewIndex.Index.String()))
+	}
+	builder.WriteString(fmt.Sprintf("  @@%s(\"%s\")\n", constants.VIEW_ATTR_SQL, strings.ReplaceAll(v.SQL, `"`, `\"`)))
+	builder.WriteString("}")
+	return builder.String()
+}
+
+func (f *ViewField) TypeString() string {
+	typeName := f.Type
+	if f.IsArray {
+		typeName += "[]"
+	}
+	if f.IsOptional {
+		typeName += "?"
+	}
+	return typeName
+}
@@ -14,6 +14,29 @@ const (
 	DB_MAX_CONNS_ENV     = "DB_MAX_CONNS"
 	DB_MIN_CONNS_ENV     = "DB_MIN_CONNS"
 	DATABASE_URI_ENV     = "DATABASE_URI"
+	AUTOINCREMENT_ENV    = "BLAZE_AUTOINCREMENT"
+)
+
+const (
+	AUTOINCREMENT_IDENTITY   = "identity"
+	AUTOINCREMENT_SEQUENCE   = "sequence"
+	OWNED_SEQUENCE_NAME      = "%s_%s_seq"
+	DEFAULT_NEXTVAL_FUNCTION = "nextval"
+)
+
+const (
+	NO_TRANSACTION_MARKER     = "-- blaze:no-transaction"
+	DEFAULT_LOCK_TIMEOUT      = "5s"
+	DEFAULT_STATEMENT_TIMEOUT = "0"
+)
+
+const (
+	UPDATED_AT_FUNCTION_NAME = "blaze_set_updated_at"
+	UPDATED_AT_TRIGGER_NAME  = "trg_%s_%s_updated_at"
+)
+
+const (
+	PG_EXCLUSION_VIOLATION = "23P01"
 )
 
 const (
@@ -28,24 +51,35 @@ const (
 type ScalarType string
 
 const (
-	INT       ScalarType = "Int"
-	BIGINT    ScalarType = "BigInt"
-	SMALLINT  ScalarType = "SmallInt"
-	FLOAT     ScalarType = "Float"
-	NUMERIC   ScalarType = "Numeric"
-	STRING    ScalarType = "String"
-	BOOLEAN   ScalarType = "Boolean"
-	DATE      ScalarType = "Date"
-	TIMESTAMP ScalarType = "Timestamp"
-	JSON      ScalarType = "Json"
-	BYTES     ScalarType = "Bytes"
-	CHAR      ScalarType = "Char"
+	INT         ScalarType = "Int"
+	BIGINT      ScalarType = "BigInt"
+	SMALLINT    ScalarType = "SmallInt"
+	FLOAT       ScalarType = "Float"
+	NUMERIC     ScalarType = "Numeric"
+	STRING      ScalarType = "String"
+	BOOLEAN     ScalarType = "Boolean"
+	DATE        ScalarType = "Date"
+	TIMESTAMP   ScalarType = "Timestamp"
+	TIMESTAMPTZ ScalarType = "TimestampTz"
+	TIME        ScalarType = "Time"
+	INTERVAL    ScalarType = "Interval"
+	UUID        ScalarType = "Uuid"
+	DECIMAL     ScalarType = "Decimal"
+	INET        ScalarType = "Inet"
+	CIDR        ScalarType = "Cidr"
+	INT4RANGE   ScalarType = "Int4Range"
+	TSTZRANGE   ScalarType = "TsTzRange"
+	JSON        ScalarType = "Json"
+	BYTES       ScalarType = "Bytes"
+	CHAR        ScalarType = "Char"
 )
 
 var ScalarTypes = []ScalarType{
 	INT, BIGINT, SMALLINT, FLOAT, NUMERIC,
-	STRING, BOOLEAN, DATE, TIMESTAMP, JSON,
-	BYTES, CHAR,
+	STRING, BOOLEAN, DATE, TIMESTAMP, TIMESTAMPTZ,
+	TIME, INTERVAL, JSON, BYTES, CHAR,
+	UUID, DECIMAL, INET, CIDR, INT4RANGE,
+	TSTZRANGE,
 }
 
 func (st ScalarType) String() string {
@@ -62,8 +96,14 @@ func (st ScalarType) IsValid() bool {
 }
 
 const (
-	KEYWORD_ENUM  = "enum"
-	KEYWORD_CLASS = "class"
+	KEYWORD_ENUM     = "enum"
+	KEYWORD_CLASS    = "class"
+	KEYWORD_SEQUENCE = "sequence"
+	KEYWORD_TYPE     = "type"
+	KEYWORD_DOMAIN   = "domain"
+
+	KEYWORD_VIEW              = "view"
+	KEYWORD_MATERIALIZED_VIEW = "materializedView"
 )
 
 const (
@@ -71,20 +111,66 @@ const (
 	FIELD_ATTR_UNIQUE      = "unique"
 	FIELD_ATTR_DEFAULT     = "default"
 	FIELD_ATTR_RELATION    = "relation"
+	FIELD_ATTR_UPDATED_AT  = "updatedAt"
+	FIELD_ATTR_COMPUTED    = "computed"
+	FIELD_ATTR_NATIVE      = "db."
+)
+
+const (
+	NATIVE_TYPE_VARCHAR     = "VarChar"
+	NATIVE_TYPE_CHAR        = "Char"
+	NATIVE_TYPE_TEXT        = "Text"
+	NATIVE_TYPE_NUMERIC     = "Numeric"
+	NATIVE_TYPE_TIMESTAMP   = "Timestamp"
+	NATIVE_TYPE_TIMESTAMPTZ = "Timestamptz"
+	NATIVE_TYPE_TIME        = "Time"
+)
+
+const (
+	CLASS_ATTR_PRIMARY_KEY  = "primaryKey"
+	CLASS_ATTR_UNIQUE       = "unique"
+	CLASS_ATTR_INDEX        = "index"
+	CLASS_ATTR_TEXT_INDEX   = "textIndex"
+	CLASS_ATTR_CHECK        = "check"
+	CLASS_ATTR_RLS          = "rls"
+	CLASS_ATTR_POLICY       = "policy"
+	CLASS_ATTR_PARTITION_BY = "partitionBy"
+	CLASS_ATTR_PARTITION    = "partition"
+	CLASS_ATTR_EXCLUDE      = "exclude"
 )
 
 const (
-	CLASS_ATTR_PRIMARY_KEY = "primaryKey"
-	CLASS_ATTR_UNIQUE      = "unique"
-	CLASS_ATTR_INDEX       = "index"
-	CLASS_ATTR_TEXT_INDEX  = "textIndex"
-	CLASS_ATTR_CHECK       = "check"
+	PARTITION_STRATEGY_RANGE   = "range"
+	PARTITION_STRATEGY_LIST    = "list"
+	PARTITION_STRATEGY_HASH    = "hash"
+	PARTITION_INTERVAL_MONTHLY = "monthly"
 )
 
 const (
-	FIELD_KIND_SCALAR = "scalar"
-	FIELD_KIND_ENUM   = "enum"
-	FIELD_KIND_OBJECT = "object"
+	POLICY_FOR_SELECT = "Select"
+	POLICY_FOR_INSERT = "Insert"
+	POLICY_FOR_UPDATE = "Update"
+	POLICY_FOR_DELETE = "Delete"
+	POLICY_FOR_ALL    = "All"
+)
+
+const (
+	DOMAIN_ATTR_CHECK   = "check"
+	DOMAIN_ATTR_DEFAULT = "default"
+)
+
+const (
+	VIEW_ATTR_SQL    = "sql"
+	VIEW_ATTR_UNIQUE = "unique"
+	VIEW_ATTR_INDEX  = "index"
+)
+
+const (
+	FIELD_KIND_SCALAR    = "scalar"
+	FIELD_KIND_ENUM      = "enum"
+	FIELD_KIND_OBJECT    = "object"
+	FIELD_KIND_COMPOSITE = "composite"
+	FIELD_KIND_DOMAIN    = "domain"
 )
 
 const (
@@ -97,12 +183,14 @@ const (
 const (
 	DEFAULT_NOW_CALLBACK           = "now()"
 	DEFAULT_UUID_CALLBACK          = "uuid()"
+	DEFAULT_UUIDV7_CALLBACK        = "uuidv7()"
 	DEFAULT_AUTOINCREMENT_CALLBACK = "autoincrement()"
 )
 
 var ValidCallbacks = []string{
 	DEFAULT_NOW_CALLBACK,
 	DEFAULT_UUID_CALLBACK,
+	DEFAULT_UUIDV7_CALLBACK,
 	DEFAULT_AUTOINCREMENT_CALLBACK,
 }
 
@@ -128,36 +216,89 @@ const (
 	INDEX_TYPE_GIST  = "Gist"
 )
 
+const (
+	INDEX_SORT_ASC  = "Asc"
+	INDEX_SORT_DESC = "Desc"
+)
+
+var ValidIndexTypes = []string{
+	INDEX_TYPE_BTREE,
+	INDEX_TYPE_HASH,
+	INDEX_TYPE_GIN,
+	INDEX_TYPE_GIST,
+}
+
+var ValidExclusionIndexTypes = []string{
+	INDEX_TYPE_GIST,
+	INDEX_TYPE_BTREE,
+	INDEX_TYPE_HASH,
+}
+
+var ValidIndexSorts = []string{
+	INDEX_SORT_ASC,
+	INDEX_SORT_DESC,
+}
+
+var PGIndexMethodMapping = map[string]string{
+	INDEX_TYPE_BTREE: "btree",
+	INDEX_TYPE_HASH:  "hash",
+	INDEX_TYPE_GIN:   "gin",
+	INDEX_TYPE_GIST:  "gist",
+}
+
+var ReversePGIndexMethodMapping = map[string]string{
+	"btree": INDEX_TYPE_BTREE,
+	"hash":  INDEX_TYPE_HASH,
+	"gin":   INDEX_TYPE_GIN,
+	"gist":  INDEX_TYPE_GIST,
+}
+
 var TypeMappings = map[string]ScalarType{
-	"Int":       INT,
-	"BigInt":    BIGINT,
-	"SmallInt":  SMALLINT,
-	"Float":     FLOAT,
-	"Numeric":   NUMERIC,
-	"String":    STRING,
-	"Boolean":   BOOLEAN,
-	"Date":      DATE,
-	"Timestamp": TIMESTAMP,
-	"Json":      JSON,
-	"Bytes":     BYTES,
-	"Char":      CHAR,
+	"Int":         INT,
+	"BigInt":      BIGINT,
+	"SmallInt":    SMALLINT,
+	"Float":       FLOAT,
+	"Numeric":     NUMERIC,
+	"String":      STRING,
+	"Boolean":     BOOLEAN,
+	"Date":        DATE,
+	"Timestamp":   TIMESTAMP,
+	"TimestampTz": TIMESTAMPTZ,
+	"Time":        TIME,
+	"Interval":    INTERVAL,
+	"Json":        JSON,
+	"Bytes":       BYTES,
+	"Char":        CHAR,
+	"Uuid":        UUID,
+	"Decimal":     DECIMAL,
+	"Inet":        INET,
+	"Cidr":        CIDR,
+	"Int4Range":   INT4RANGE,
+	"TsTzRange":   TSTZRANGE,
 }
 
 var PGTypeMapping = map[string]string{
-	"int4":      INT.String(),
-	"int8":      BIGINT.String(),
-	"int2":      SMALLINT.String(),
-	"float8":    FLOAT.String(),
-	"numeric":   NUMERIC.String(),
-	"text":      STRING.String(),
-	"varchar":   STRING.String(),
-	"bpchar":    CHAR.String(),
-	"bool":      BOOLEAN.String(),
-	"date":      DATE.String(),
-	"timestamp": TIMESTAMP.String(),
-	"jsonb":     JSON.String(),
-	"bytea":     BYTES.String(),
-	"uuid":      STRING.String(),
+	"int4":        INT.String(),
+	"int8":        BIGINT.String(),
+	"int2":        SMALLINT.String(),
+	"float8":      FLOAT.String(),
+	"numeric":     NUMERIC.String(),
+	"text":        STRING.String(),
+	"varchar":     STRING.String(),
+	"bpchar":      CHAR.String(),
+	"bool":        BOOLEAN.String(),
+	"date":        DATE.String(),
+	"timestamp":   TIMESTAMP.String(),
+	"timestamptz": TIMESTAMPTZ.String(),
+	"time":        TIME.String(),
+	"interval":    INTERVAL.String(),
+	"jsonb":       JSON.String(),
+	"bytea":       BYTES.String(),
+	"uuid":        UUID.String(),
+	"inet":        INET.String(),
+	"cidr":        CIDR.String(),
+	"int4range":   INT4RANGE.String(),
+	"tstzrange":   TSTZRANGE.String(),
 }
 
 var PGConstraintActionMapping = map[string]string{
@@ -177,6 +318,14 @@ var ReverseDMMFIndexTypeMappings = map[string]string{
 	INDEX_TYPE_TEXT:  CLASS_ATTR_TEXT_INDEX,
 }
 
+var PolicyCommandMapping = map[string]string{
+	POLICY_FOR_SELECT: "SELECT",
+	POLICY_FOR_INSERT: "INSERT",
+	POLICY_FOR_UPDATE: "UPDATE",
+	POLICY_FOR_DELETE: "DELETE",
+	POLICY_FOR_ALL:    "ALL",
+}
+
 var RelationCardinality = map[string]string{
 	"OneToOne":   RELATION_ONE_TO_ONE,
 	"OneToMany":  RELATION_ONE_TO_MANY,
@@ -245,12 +394,57 @@ func IsValidOnUpdateAction(action string) bool {
 	return false
 }
 
+func IsValidExclusionIndexType(indexType string) bool {
+	for _, validType := range ValidExclusionIndexTypes {
+		if indexType == validType {
+			return true
+		}
+	}
+	return false
+}
+
+func IsValidIndexType(indexType string) bool {
+	for _, validType := range ValidIndexTypes {
+		if indexType == validType {
+			return true
+		}
+	}
+	return false
+}
+
+func IsValidIndexSort(sort string) bool {
+	for _, validSort := range ValidIndexSorts {
+		if sort == validSort {
+			return true
+		}
+	}
+	return false
+}
+
+func IsTemporalType(scalarType ScalarType) bool {
+	switch scalarType {
+	case DATE, TIMESTAMP, TIMESTAMPTZ, TIME, INTERVAL:
+		return true
+	}
+	return false
+}
+
+func IsRangeType(scalarType ScalarType) bool {
+	return scalarType == INT4RANGE || scalarType == TSTZRANGE
+}
+
+func IsNetworkType(scalarType ScalarType) bool {
+	return scalarType == INET || scalarType == CIDR
+}
+
 func GetCallbackCompatibleTypes(callback string) []ScalarType {
 	switch callback {
 	case DEFAULT_NOW_CALLBACK:
-		return []ScalarType{TIMESTAMP, DATE}
+		return []ScalarType{TIMESTAMP, TIMESTAMPTZ, DATE, TIME}
 	case DEFAULT_UUID_CALLBACK:
-		return []ScalarType{STRING}
+		return []ScalarType{UUID, STRING}
+	case DEFAULT_UUIDV7_CALLBACK:
+		return []ScalarType{UUID}
 	case DEFAULT_AUTOINCREMENT_CALLBACK:
 		return []ScalarType{INT, BIGINT, SMALLINT}
 	default:
@@ -10,4 +10,7 @@ var EnvContent = fmt.Sprintf(`# Your Database URL
 # Connection configs
 # %s=0
 # %s=25
-`, DATABASE_URI_ENV, DB_MIN_CONNS_ENV, DB_MAX_CONNS_ENV)
\ No newline at end of file
+
+# Column strategy for autoincrement(): identity or sequence
+# %s=identity
+`, DATABASE_URI_ENV, DB_MIN_CONNS_ENV, DB_MAX_CONNS_ENV, AUTOINCREMENT_ENV)
\ No newline at end of file
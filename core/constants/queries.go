@@ -1,7 +1,5 @@
 package constants
 
-import "fmt"
-
 const TEST_QUERY = "SELECT 1"
 
 const DROP_PUBLIC_SCHEMA = `
@@ -24,8 +22,12 @@ id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
 checksum VARCHAR(64) UNIQUE NOT NULL,
 applied_at TIMESTAMP(3) UNIQUE NOT NULL DEFAULT now(),
 migration_name TEXT UNIQUE NOT NULL,
-step_count INT NOT NULL DEFAULT 1
+step_count INT NOT NULL DEFAULT 1,
+applied_steps INT
 );
+
+ALTER TABLE "_blaze_migrations" ADD COLUMN IF NOT EXISTS step_count INT NOT NULL DEFAULT 1;
+ALTER TABLE "_blaze_migrations" ADD COLUMN IF NOT EXISTS applied_steps INT;
 `
 
 const FETCH_ALL_MIGRATIONS = `
@@ -34,11 +36,18 @@ id,
 checksum,
 applied_at,
 migration_name,
-step_count
+step_count,
+COALESCE(applied_steps, step_count)
 FROM "_blaze_migrations"
 ORDER BY applied_at DESC;
 `
 
+const INSERT_MIGRATION = `
+INSERT INTO "_blaze_migrations" (checksum, applied_at, migration_name, step_count, applied_steps)
+VALUES ($1, clock_timestamp(), $2, $3, $4)
+ON CONFLICT (migration_name) DO UPDATE SET applied_steps = EXCLUDED.applied_steps;
+`
+
 const ALL_ENUMS_QUERY = `
 SELECT 
 t.typname AS enum_name,
@@ -51,86 +60,257 @@ WHERE n.nspname = 'public'
 ORDER BY enum_name, e.enumsortorder;
 `
 
+const ALL_SEQUENCES_QUERY = `
+SELECT
+s.sequencename AS sequence_name,
+s.start_value,
+s.increment_by,
+s.cache_size
+FROM pg_sequences s
+JOIN pg_class c ON c.relname = s.sequencename
+JOIN pg_namespace n ON n.oid = c.relnamespace AND n.nspname = s.schemaname
+WHERE s.schemaname = 'public'
+AND NOT EXISTS (
+    SELECT 1 FROM pg_depend d
+    WHERE d.objid = c.oid
+    AND d.classid = 'pg_class'::regclass
+    AND d.deptype IN ('a', 'i')
+)
+ORDER BY sequence_name;
+`
+
+const ALL_COMPOSITE_TYPES_QUERY = `
+SELECT
+t.typname AS type_name,
+a.attname AS attribute_name,
+at.typname AS attribute_type,
+a.attnum::int AS attribute_position
+FROM pg_type t
+JOIN pg_namespace n ON n.oid = t.typnamespace
+JOIN pg_class c ON c.oid = t.typrelid AND c.relkind = 'c'
+JOIN pg_attribute a ON a.attrelid = c.oid AND a.attnum > 0 AND NOT a.attisdropped
+JOIN pg_type at ON at.oid = a.atttypid
+WHERE n.nspname = 'public'
+AND t.typtype = 'c'
+ORDER BY type_name, a.attnum;
+`
+
+const ALL_DOMAINS_QUERY = `
+SELECT
+t.typname AS domain_name,
+bt.typname AS base_type,
+t.typdefault AS domain_default,
+information_schema._pg_char_max_length(t.typbasetype, t.typtypmod)::int AS character_maximum_length,
+information_schema._pg_numeric_precision(t.typbasetype, t.typtypmod)::int AS numeric_precision,
+information_schema._pg_numeric_scale(t.typbasetype, t.typtypmod)::int AS numeric_scale,
+information_schema._pg_datetime_precision(t.typbasetype, t.typtypmod)::int AS datetime_precision,
+(
+    SELECT pg_get_constraintdef(con.oid)
+    FROM pg_constraint con
+    WHERE con.contypid = t.oid AND con.contype = 'c'
+    ORDER BY con.conname
+    LIMIT 1
+) AS check_definition
+FROM pg_type t
+JOIN pg_namespace n ON n.oid = t.typnamespace
+JOIN pg_type bt ON bt.oid = t.typbasetype
+WHERE n.nspname = 'public'
+AND t.typtype = 'd'
+ORDER BY domain_name;
+`
+
 const FETCH_AVAILABLE_TABLES = `
-SELECT table_name
-FROM information_schema.tables
-WHERE table_schema = 'public'
-ORDER BY table_schema, table_name;
-`
-
-func TableTypes(input string) string {
-	return fmt.Sprintf(`
-    SELECT 
-    c.column_name,
-    c.udt_name AS base_type,
-    c.is_nullable,
-    c.column_default,
-    c.ordinal_position
-    FROM information_schema.columns c
-    LEFT JOIN pg_type t
-    ON c.udt_name = t.typname
-    LEFT JOIN pg_enum e
-    ON t.oid = e.enumtypid
-    WHERE c.table_name = "%s"
-    AND c.table_schema NOT IN ('pg_catalog', 'information_schema')
-    GROUP BY c.column_name, c.data_type, c.is_nullable, c.column_default, t.typname, c.ordinal_position, c.udt_name
-    ORDER BY c.ordinal_position;
-    `, input)
-}
-
-func TableConstraints(input string) string {
-	return fmt.Sprintf(`
-    SELECT 
-    tc.constraint_type,
-    kcu.column_name,
-    tc.constraint_name
-    FROM information_schema.table_constraints tc
-    JOIN information_schema.key_column_usage kcu
-    ON tc.constraint_name = kcu.constraint_name
-    AND tc.table_schema = kcu.table_schema
-    WHERE tc.table_name = "%s"
-    AND tc.constraint_type IN ('PRIMARY KEY', 'UNIQUE')
-    ORDER BY tc.constraint_type, kcu.column_name;
-    `, input)
-}
-
-func TableRelations(input string) string {
-	return fmt.Sprintf(`
-    SELECT
-    kcu.column_name AS fk_column,
-    ccu.table_name AS referenced_table,
-    ccu.column_name AS referenced_column,
-    rc.update_rule,
-    rc.delete_rule,
-    tc.constraint_name
-    FROM information_schema.table_constraints AS tc
-    JOIN information_schema.key_column_usage AS kcu
-    ON tc.constraint_name = kcu.constraint_name
-    AND tc.table_schema = kcu.table_schema
-    JOIN information_schema.referential_constraints AS rc
-    ON tc.constraint_name = rc.constraint_name
-    AND tc.table_schema = rc.constraint_schema
-    JOIN information_schema.constraint_column_usage AS ccu
-    ON ccu.constraint_name = tc.constraint_name
-    WHERE tc.constraint_type = 'FOREIGN KEY'
-    AND tc.table_name = "%s";
-    `, input)
-}
-
-func TableIndexes(input string) string {
-	return fmt.Sprintf(`
-    SELECT 
-    i.relname AS index_name,
-    idx.indisunique AS is_unique,
-    idx.indisprimary AS is_primary,
-    array_to_string(array_agg(a.attname), ', ') AS columns
-    FROM pg_class t
-    JOIN pg_index idx ON t.oid = idx.indrelid
-    JOIN pg_class i ON i.oid = idx.indexrelid
-    JOIN pg_attribute a ON a.attrelid = t.oid AND a.attnum = ANY(idx.indkey)
-    WHERE t.relkind = 'r' 
-    AND t.relname = "%s"
-    GROUP BY i.relname, idx.indisunique, idx.indisprimary
-    ORDER BY i.relname;
-    `, input)
-}
\ No newline at end of file
+SELECT t.table_name
+FROM information_schema.tables t
+JOIN pg_namespace n ON n.nspname = t.table_schema
+JOIN pg_class c ON c.relnamespace = n.oid AND c.relname = t.table_name
+WHERE t.table_schema = 'public'
+AND t.table_type = 'BASE TABLE'
+AND NOT c.relispartition
+ORDER BY t.table_schema, t.table_name;
+`
+
+const ALL_VIEWS_QUERY = `
+SELECT viewname AS view_name, false AS materialized, definition
+FROM pg_views
+WHERE schemaname = 'public'
+UNION ALL
+SELECT matviewname AS view_name, true AS materialized, definition
+FROM pg_matviews
+WHERE schemaname = 'public'
+ORDER BY view_name;
+`
+
+const ALL_VIEW_COLUMNS_QUERY = `
+SELECT
+c.relname AS view_name,
+a.attname AS column_name,
+t.typname AS column_type,
+a.attnum::int AS column_position
+FROM pg_class c
+JOIN pg_namespace n ON n.oid = c.relnamespace
+JOIN pg_attribute a ON a.attrelid = c.oid AND a.attnum > 0 AND NOT a.attisdropped
+JOIN pg_type t ON t.oid = a.atttypid
+WHERE n.nspname = 'public'
+AND c.relkind IN ('v', 'm')
+ORDER BY view_name, a.attnum;
+`
+
+const ALL_MATERIALIZED_VIEW_INDEXES_QUERY = `
+SELECT i.tablename AS view_name, i.indexname AS index_name, i.indexdef AS definition
+FROM pg_indexes i
+JOIN pg_matviews m ON m.schemaname = i.schemaname AND m.matviewname = i.tablename
+WHERE i.schemaname = 'public'
+ORDER BY view_name, index_name;
+`
+
+const TABLE_TYPES_QUERY = `
+SELECT 
+c.column_name,
+c.udt_name AS base_type,
+c.is_nullable,
+c.column_default,
+c.ordinal_position,
+CASE WHEN c.is_generated = 'ALWAYS' THEN c.generation_expression END AS generation_expression,
+c.is_identity = 'YES' AS is_identity,
+pg_get_serial_sequence(quote_ident(c.table_schema) || '.' || quote_ident(c.table_name), c.column_name) AS owned_sequence,
+c.character_maximum_length::int AS character_maximum_length,
+c.numeric_precision::int AS numeric_precision,
+c.numeric_scale::int AS numeric_scale,
+c.datetime_precision::int AS datetime_precision,
+c.domain_name
+FROM information_schema.columns c
+LEFT JOIN pg_type t
+ON c.udt_name = t.typname
+LEFT JOIN pg_enum e
+ON t.oid = e.enumtypid
+WHERE c.table_name = $1
+AND c.table_schema NOT IN ('pg_catalog', 'information_schema')
+GROUP BY c.column_name, c.data_type, c.is_nullable, c.column_default, t.typname, c.ordinal_position, c.udt_name, c.is_generated, c.generation_expression, c.is_identity, c.table_schema, c.table_name, c.character_maximum_length, c.numeric_precision, c.numeric_scale, c.datetime_precision, c.domain_name
+ORDER BY c.ordinal_position;
+`
+
+const TABLE_CONSTRAINTS_QUERY = `
+SELECT 
+tc.constraint_type,
+kcu.column_name,
+tc.constraint_name
+FROM information_schema.table_constraints tc
+JOIN information_schema.key_column_usage kcu
+ON tc.constraint_name = kcu.constraint_name
+AND tc.table_schema = kcu.table_schema
+WHERE tc.table_name = $1
+AND tc.constraint_type IN ('PRIMARY KEY', 'UNIQUE')
+ORDER BY tc.constraint_type, kcu.column_name;
+`
+
+const TABLE_RELATIONS_QUERY = `
+SELECT
+kcu.column_name AS fk_column,
+ccu.table_name AS referenced_table,
+ccu.column_name AS referenced_column,
+rc.update_rule,
+rc.delete_rule,
+tc.constraint_name
+FROM information_schema.table_constraints AS tc
+JOIN information_schema.key_column_usage AS kcu
+ON tc.constraint_name = kcu.constraint_name
+AND tc.table_schema = kcu.table_schema
+JOIN information_schema.referential_constraints AS rc
+ON tc.constraint_name = rc.constraint_name
+AND tc.table_schema = rc.constraint_schema
+JOIN information_schema.constraint_column_usage AS ccu
+ON ccu.constraint_name = tc.constraint_name
+WHERE tc.constraint_type = 'FOREIGN KEY'
+AND tc.table_name = $1;
+`
+
+const TABLE_INDEXES_QUERY = `
+SELECT 
+i.relname AS index_name,
+idx.indisunique AS is_unique,
+idx.indisprimary AS is_primary,
+COALESCE(array_to_string(array_agg(a.attname) FILTER (WHERE a.attname IS NOT NULL), ', '), '') AS columns,
+pg_get_indexdef(idx.indexrelid) AS definition
+FROM pg_class t
+JOIN pg_index idx ON t.oid = idx.indrelid
+JOIN pg_class i ON i.oid = idx.indexrelid
+LEFT JOIN pg_attribute a ON a.attrelid = t.oid AND a.attnum = ANY(idx.indkey)
+WHERE t.relkind IN ('r', 'p') 
+AND t.relname = $1
+AND NOT EXISTS (
+    SELECT 1 FROM pg_constraint con
+    WHERE con.conindid = idx.indexrelid
+    AND con.contype = 'x'
+)
+GROUP BY i.relname, idx.indisunique, idx.indisprimary, idx.indexrelid
+ORDER BY i.relname;
+`
+
+const TABLE_UPDATED_AT_TRIGGERS_QUERY = `
+SELECT
+tg.tgname AS trigger_name,
+pg_get_triggerdef(tg.oid) AS definition
+FROM pg_trigger tg
+JOIN pg_class t ON t.oid = tg.tgrelid
+JOIN pg_proc p ON p.oid = tg.tgfoid
+WHERE NOT tg.tgisinternal
+AND t.relname = $1
+AND p.proname = '` + UPDATED_AT_FUNCTION_NAME + `'
+ORDER BY tg.tgname;
+`
+
+const TABLE_EXCLUSIONS_QUERY = `
+SELECT
+con.conname AS constraint_name,
+pg_get_constraintdef(con.oid) AS definition
+FROM pg_constraint con
+JOIN pg_class t ON t.oid = con.conrelid
+JOIN pg_namespace n ON n.oid = t.relnamespace
+WHERE n.nspname = 'public'
+AND con.contype = 'x'
+AND t.relname = $1
+ORDER BY con.conname;
+`
+
+const TABLE_ROW_SECURITY_QUERY = `
+SELECT t.relrowsecurity
+FROM pg_class t
+JOIN pg_namespace n ON n.oid = t.relnamespace
+WHERE n.nspname = 'public'
+AND t.relname = $1;
+`
+
+const TABLE_POLICIES_QUERY = `
+SELECT
+p.policyname AS policy_name,
+p.cmd AS command,
+p.roles::text[] AS roles,
+p.qual AS using_expression,
+p.with_check AS check_expression
+FROM pg_policies p
+WHERE p.schemaname = 'public'
+AND p.tablename = $1
+ORDER BY p.policyname;
+`
+
+const TABLE_PARTITION_KEY_QUERY = `
+SELECT pg_get_partkeydef(t.oid) AS partition_key
+FROM pg_class t
+JOIN pg_namespace n ON n.oid = t.relnamespace
+WHERE n.nspname = 'public'
+AND t.relname = $1;
+`
+
+const TABLE_PARTITIONS_QUERY = `
+SELECT
+c.relname AS partition_name,
+pg_get_expr(c.relpartbound, c.oid) AS partition_bound
+FROM pg_inherits i
+JOIN pg_class c ON c.oid = i.inhrelid
+JOIN pg_class t ON t.oid = i.inhparent
+JOIN pg_namespace n ON n.oid = t.relnamespace
+WHERE n.nspname = 'public'
+AND t.relname = $1
+ORDER BY c.relname;
+`
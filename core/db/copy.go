@@ -0,0 +1,259 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+const (
+	CopyThreshold      = 100
+	maxStatementParams = 65535
+)
+
+type Row interface {
+	Scan(dest ...interface{}) error
+}
+
+type BulkInserter struct {
+	table          string
+	columns        []string
+	types          map[string]string
+	rows           []map[string]interface{}
+	returning      []string
+	skipDuplicates bool
+}
+
+type copyBatch struct {
+	columns []string
+	rows    [][]interface{}
+}
+
+type statement struct {
+	sql  string
+	args []interface{}
+}
+
+func InsertMany(table string, columns []string, types map[string]string, rows []map[string]interface{}) *BulkInserter {
+	return &BulkInserter{
+		table:   table,
+		columns: columns,
+		types:   types,
+		rows:    rows,
+	}
+}
+
+func (b *BulkInserter) Returning(columns ...string) *BulkInserter {
+	b.returning = columns
+	return b
+}
+
+func (b *BulkInserter) SkipDuplicates() *BulkInserter {
+	b.skipDuplicates = true
+	return b
+}
+
+func (b *BulkInserter) UsesCopy() bool {
+	return len(b.rows) >= CopyThreshold && len(b.returning) == 0 && !b.skipDuplicates
+}
+
+func (b *BulkInserter) Exec(ctx context.Context, pool *pgxpool.Pool) (int64, error) {
+	if len(b.rows) == 0 {
+		return 0, nil
+	}
+
+	tx, err := pool.Begin(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback(ctx)
+
+	var total int64
+	if b.UsesCopy() {
+		if _, err := b.presentColumns(); err != nil {
+			return 0, err
+		}
+		for _, batch := range b.copyBatches() {
+			count, err := tx.CopyFrom(ctx, pgx.Identifier{b.table}, batch.columns, pgx.CopyFromRows(batch.rows))
+			if err != nil {
+				return 0, err
+			}
+			total += count
+		}
+	} else {
+		statements, err := b.statements()
+		if err != nil {
+			return 0, err
+		}
+		for _, stmt := range statements {
+			tag, err := tx.Exec(ctx, stmt.sql, stmt.args...)
+			if err != nil {
+				return 0, err
+			}
+			total += tag.RowsAffected()
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return 0, err
+	}
+	return total, nil
+}
+
+func (b *BulkInserter) Query(ctx context.Context, pool *pgxpool.Pool, scan func(Row) error) error {
+	if len(b.rows) == 0 {
+		return nil
+	}
+
+	statements, err := b.statements()
+	if err != nil {
+		return err
+	}
+
+	tx, err := pool.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	for _, stmt := range statements {
+		rows, err := tx.Query(ctx, stmt.sql, stmt.args...)
+		if err != nil {
+			return err
+		}
+		for rows.Next() {
+			if err := scan(rows); err != nil {
+				rows.Close()
+				return err
+			}
+		}
+		rows.Close()
+		if err := rows.Err(); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit(ctx)
+}
+
+func (b *BulkInserter) copyBatches() []copyBatch {
+	var batches []copyBatch
+	index := make(map[string]int)
+
+	for _, row := range b.rows {
+		var columns []string
+		for _, column := range b.columns {
+			if _, ok := row[column]; ok {
+				columns = append(columns, column)
+			}
+		}
+
+		key := strings.Join(columns, ",")
+		i, ok := index[key]
+		if !ok {
+			i = len(batches)
+			index[key] = i
+			batches = append(batches, copyBatch{columns: columns})
+		}
+
+		values := make([]interface{}, len(columns))
+		for n, column := range columns {
+			values[n] = row[column]
+		}
+		batches[i].rows = append(batches[i].rows, values)
+	}
+
+	return batches
+}
+
+func (b *BulkInserter) presentColumns() ([]string, error) {
+	present := make(map[string]bool)
+	for _, row := range b.rows {
+		for column := range row {
+			if !contains(b.columns, column) {
+				return nil, fmt.Errorf("cannot insert into %s: unknown column %q", b.table, column)
+			}
+			present[column] = true
+		}
+	}
+
+	var columns []string
+	for _, column := range b.columns {
+		if present[column] {
+			columns = append(columns, column)
+		}
+	}
+	return columns, nil
+}
+
+func (b *BulkInserter) statements() ([]statement, error) {
+	columns, err := b.presentColumns()
+	if err != nil {
+		return nil, err
+	}
+
+	if len(columns) == 0 {
+		var statements []statement
+		for range b.rows {
+			statements = append(statements, statement{sql: b.insertPrefix(nil) + " DEFAULT VALUES" + b.suffix()})
+		}
+		return statements, nil
+	}
+
+	chunk := maxStatementParams / len(columns)
+	var statements []statement
+	for start := 0; start < len(b.rows); start += chunk {
+		end := start + chunk
+		if end > len(b.rows) {
+			end = len(b.rows)
+		}
+
+		var tuples []string
+		var args []interface{}
+		for _, row := range b.rows[start:end] {
+			values := make([]string, len(columns))
+			for n, column := range columns {
+				value, ok := row[column]
+				if !ok {
+					values[n] = "DEFAULT"
+					continue
+				}
+				args = append(args, value)
+				values[n] = fmt.Sprintf("$%d", len(args))
+				if cast := b.types[column]; cast != "" {
+					values[n] += "::" + cast
+				}
+			}
+			tuples = append(tuples, "("+strings.Join(values, ", ")+")")
+		}
+
+		statements = append(statements, statement{
+			sql:  b.insertPrefix(columns) + " VALUES " + strings.Join(tuples, ", ") + b.suffix(),
+			args: args,
+		})
+	}
+
+	return statements, nil
+}
+
+func (b *BulkInserter) insertPrefix(columns []string) string {
+	if len(columns) == 0 {
+		return "INSERT INTO " + QuoteIdent(b.table)
+	}
+	quoted := make([]string, len(columns))
+	for i, column := range columns {
+		quoted[i] = QuoteIdent(column)
+	}
+	return fmt.Sprintf("INSERT INTO %s (%s)", QuoteIdent(b.table), strings.Join(quoted, ", "))
+}
+
+func (b *BulkInserter) suffix() string {
+	var suffix string
+	if b.skipDuplicates {
+		suffix = " ON CONFLICT DO NOTHING"
+	}
+	return suffix + returningClause(b.returning)
+}
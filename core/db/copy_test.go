@@ -0,0 +1,142 @@
+package db
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func bulkRows(n int, row func(i int) map[string]interface{}) []map[string]interface{} {
+	rows := make([]map[string]interface{}, n)
+	for i := range rows {
+		rows[i] = row(i)
+	}
+	return rows
+}
+
+func TestBulkInserterUsesCopy(t *testing.T) {
+	small := bulkRows(CopyThreshold-1, func(i int) map[string]interface{} { return map[string]interface{}{"id": i} })
+	large := bulkRows(CopyThreshold, func(i int) map[string]interface{} { return map[string]interface{}{"id": i} })
+
+	tests := []struct {
+		name     string
+		inserter *BulkInserter
+		copy     bool
+	}{
+		{name: "below threshold", inserter: InsertMany("User", userColumns, nil, small)},
+		{name: "at threshold", inserter: InsertMany("User", userColumns, nil, large), copy: true},
+		{name: "returning rows", inserter: InsertMany("User", userColumns, nil, large).Returning("id")},
+		{name: "skipping duplicates", inserter: InsertMany("User", userColumns, nil, large).SkipDuplicates()},
+	}
+
+	for _, tt := range tests {
+		if got := tt.inserter.UsesCopy(); got != tt.copy {
+			t.Errorf("%s: UsesCopy() = %v, want %v", tt.name, got, tt.copy)
+		}
+	}
+}
+
+func TestBulkInserterCopyBatches(t *testing.T) {
+	rows := []map[string]interface{}{
+		{"id": 1, "email": "a"},
+		{"email": "b"},
+		{"email": "c", "id": 3},
+	}
+
+	batches := InsertMany("User", userColumns, nil, rows).copyBatches()
+	want := []copyBatch{
+		{columns: []string{"id", "email"}, rows: [][]interface{}{{1, "a"}, {3, "c"}}},
+		{columns: []string{"email"}, rows: [][]interface{}{{"b"}}},
+	}
+	if !reflect.DeepEqual(batches, want) {
+		t.Errorf("copyBatches() = %#v, want %#v", batches, want)
+	}
+}
+
+func TestBulkInserterStatements(t *testing.T) {
+	tests := []struct {
+		name     string
+		inserter *BulkInserter
+		want     []statement
+		err      string
+	}{
+		{
+			name: "missing values fall back to defaults",
+			inserter: InsertMany("User", userColumns, map[string]string{"teamId": "integer"}, []map[string]interface{}{
+				{"email": "a", "teamId": 1},
+				{"email": "b"},
+			}).Returning("id"),
+			want: []statement{{
+				sql:  `INSERT INTO "User" ("email", "teamId") VALUES ($1, $2::integer), ($3, DEFAULT) RETURNING "id"`,
+				args: []interface{}{"a", 1, "b"},
+			}},
+		},
+		{
+			name: "skipped duplicates",
+			inserter: InsertMany("User", userColumns, nil, []map[string]interface{}{
+				{"email": "a"},
+			}).SkipDuplicates(),
+			want: []statement{{
+				sql:  `INSERT INTO "User" ("email") VALUES ($1) ON CONFLICT DO NOTHING`,
+				args: []interface{}{"a"},
+			}},
+		},
+		{
+			name:     "rows without values",
+			inserter: InsertMany("User", userColumns, nil, []map[string]interface{}{{}, {}}).Returning("id"),
+			want: []statement{
+				{sql: `INSERT INTO "User" DEFAULT VALUES RETURNING "id"`},
+				{sql: `INSERT INTO "User" DEFAULT VALUES RETURNING "id"`},
+			},
+		},
+		{
+			name:     "unknown column",
+			inserter: InsertMany("User", userColumns, nil, []map[string]interface{}{{"email": "a"}, {"age": 3}}),
+			err:      `cannot insert into User: unknown column "age"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			statements, err := tt.inserter.statements()
+			if tt.err != "" {
+				if err == nil || err.Error() != tt.err {
+					t.Fatalf("err = %v, want %s", err, tt.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(statements, tt.want) {
+				t.Errorf("statements() = %#v, want %#v", statements, tt.want)
+			}
+		})
+	}
+}
+
+func TestBulkInserterStatementsStayUnderParameterLimit(t *testing.T) {
+	rows := bulkRows(40000, func(i int) map[string]interface{} { return map[string]interface{}{"id": i, "email": "e"} })
+
+	statements, err := InsertMany("User", userColumns, nil, rows).Returning("id").statements()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(statements) != 2 {
+		t.Fatalf("got %d statements, want 2", len(statements))
+	}
+
+	total := 0
+	for _, stmt := range statements {
+		if len(stmt.args) > maxStatementParams {
+			t.Errorf("statement binds %d parameters, more than %d", len(stmt.args), maxStatementParams)
+		}
+		if !strings.HasSuffix(stmt.sql, `RETURNING "id"`) {
+			t.Errorf("statement does not return ids: %.80s", stmt.sql)
+		}
+		total += len(stmt.args) / 2
+	}
+	if total != len(rows) {
+		t.Errorf("statements insert %d rows, want %d", total, len(rows))
+	}
+}
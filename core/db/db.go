@@ -5,20 +5,27 @@ import (
 	"fmt"
 	"log"
 	"os"
+	"regexp"
 	"strconv"
+	"sync"
 
 	"github.com/rit3sh-x/blaze/core/constants"
 
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/joho/godotenv"
 )
 
+type sessionSettingsKey struct{}
+
+var sessionSettingPattern = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]*\.[a-zA-Z_][a-zA-Z0-9_.]*$`)
+
 type BlazeDB struct {
 	Pool   *pgxpool.Pool
 	Ctx    context.Context
 }
 
-func DB(ctx context.Context, envFile string) (*BlazeDB, error) {
+func DB(ctx context.Context, envFile string, typeNames ...string) (*BlazeDB, error) {
 	minConns := 0
 	maxConns := 25
 
@@ -52,6 +59,34 @@ func DB(ctx context.Context, envFile string) (*BlazeDB, error) {
 		}
 	}
 
+	if len(typeNames) > 0 {
+		config.AfterConnect = func(ctx context.Context, conn *pgx.Conn) error {
+			return RegisterTypes(ctx, conn, typeNames)
+		}
+	}
+
+	var sessionConns sync.Map
+	config.BeforeAcquire = func(ctx context.Context, conn *pgx.Conn) bool {
+		settings := SessionSettings(ctx)
+		if len(settings) == 0 {
+			return true
+		}
+		for name, value := range settings {
+			if _, err := conn.Exec(ctx, "SELECT set_config($1, $2, false)", name, value); err != nil {
+				return false
+			}
+		}
+		sessionConns.Store(conn, true)
+		return true
+	}
+	config.AfterRelease = func(conn *pgx.Conn) bool {
+		if _, applied := sessionConns.LoadAndDelete(conn); !applied {
+			return true
+		}
+		_, err := conn.Exec(context.Background(), "RESET ALL")
+		return err == nil
+	}
+
 	pool, err := pgxpool.NewWithConfig(ctx, config)
 	if err != nil {
 		return nil, fmt.Errorf("%sFailed to create connection pool: %v%s", constants.RED, err, constants.RESET)
@@ -64,4 +99,46 @@ func DB(ctx context.Context, envFile string) (*BlazeDB, error) {
 
 	fmt.Printf("%s✔ Connected to database%s\n", constants.GREEN, constants.RESET)
 	return &BlazeDB{Pool: pool, Ctx: ctx}, nil
-}
\ No newline at end of file
+}
+
+func RegisterTypes(ctx context.Context, conn *pgx.Conn, typeNames []string) error {
+	types, err := conn.LoadTypes(ctx, typeNames)
+	if err != nil {
+		return fmt.Errorf("failed to load user-defined types: %v", err)
+	}
+	conn.TypeMap().RegisterTypes(types)
+	return nil
+}
+
+func (b *BlazeDB) RefreshMaterializedView(ctx context.Context, name string, concurrently bool) error {
+	sql := fmt.Sprintf(`REFRESH MATERIALIZED VIEW "%s"`, name)
+	if concurrently {
+		sql = fmt.Sprintf(`REFRESH MATERIALIZED VIEW CONCURRENTLY "%s"`, name)
+	}
+	if _, err := b.Pool.Exec(ctx, sql); err != nil {
+		return fmt.Errorf("failed to refresh materialized view %s: %v", name, err)
+	}
+	return nil
+}
+
+func WithSessionSettings(ctx context.Context, settings map[string]string) (context.Context, error) {
+	merged := make(map[string]string)
+	for name, value := range SessionSettings(ctx) {
+		merged[name] = value
+	}
+	for name, value := range settings {
+		if !sessionSettingPattern.MatchString(name) {
+			return nil, fmt.Errorf("invalid session setting %q: custom settings must be namespaced, e.g. app.tenant_id", name)
+		}
+		merged[name] = value
+	}
+	return context.WithValue(ctx, sessionSettingsKey{}, merged), nil
+}
+
+func SessionSettings(ctx context.Context) map[string]string {
+	if ctx == nil {
+		return nil
+	}
+	settings, _ := ctx.Value(sessionSettingsKey{}).(map[string]string)
+	return settings
+}
@@ -0,0 +1,33 @@
+package db
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestWithSessionSettings(t *testing.T) {
+	ctx, err := WithSessionSettings(context.Background(), map[string]string{"app.tenant_id": "1", "app.role": "reader"})
+	if err != nil {
+		t.Fatalf("WithSessionSettings() error: %v", err)
+	}
+	ctx, err = WithSessionSettings(ctx, map[string]string{"app.tenant_id": "2"})
+	if err != nil {
+		t.Fatalf("WithSessionSettings() error: %v", err)
+	}
+
+	want := map[string]string{"app.tenant_id": "2", "app.role": "reader"}
+	if got := SessionSettings(ctx); !reflect.DeepEqual(got, want) {
+		t.Errorf("SessionSettings() = %v, want %v", got, want)
+	}
+	if got := SessionSettings(context.Background()); got != nil {
+		t.Errorf("SessionSettings() without settings = %v, want nil", got)
+	}
+
+	for _, name := range []string{"search_path", "role", "app.", "1app.tenant"} {
+		if _, err := WithSessionSettings(ctx, map[string]string{name: "x"}); err == nil || !strings.Contains(err.Error(), "must be namespaced") {
+			t.Errorf("WithSessionSettings(%q) error = %v, want a namespacing error", name, err)
+		}
+	}
+}
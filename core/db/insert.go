@@ -0,0 +1,136 @@
+package db
+
+import (
+	"fmt"
+	"strings"
+)
+
+type Inserter struct {
+	table     string
+	columns   []string
+	values    map[string]interface{}
+	returning []string
+	conflict  []string
+	updates   map[string]interface{}
+}
+
+func Insert(table string, columns []string, values map[string]interface{}) *Inserter {
+	return &Inserter{
+		table:   table,
+		columns: columns,
+		values:  values,
+	}
+}
+
+func (i *Inserter) Returning(columns ...string) *Inserter {
+	i.returning = columns
+	return i
+}
+
+func (i *Inserter) OnConflict(target []string, updates map[string]interface{}) *Inserter {
+	i.conflict = target
+	i.updates = updates
+	return i
+}
+
+func (i *Inserter) SQL() (string, []interface{}, error) {
+	var columns []string
+	var placeholders []string
+	var args []interface{}
+
+	for _, column := range i.columns {
+		value, ok := i.values[column]
+		if !ok {
+			continue
+		}
+		args = append(args, value)
+		columns = append(columns, QuoteIdent(column))
+		placeholders = append(placeholders, fmt.Sprintf("$%d", len(args)))
+	}
+
+	if len(args) != len(i.values) {
+		for column := range i.values {
+			if !contains(i.columns, column) {
+				return "", nil, fmt.Errorf("cannot insert into %s: unknown column %q", i.table, column)
+			}
+		}
+	}
+
+	var builder strings.Builder
+	builder.WriteString("INSERT INTO " + QuoteIdent(i.table))
+	if len(columns) == 0 {
+		builder.WriteString(" DEFAULT VALUES")
+	} else {
+		builder.WriteString(fmt.Sprintf(" (%s) VALUES (%s)", strings.Join(columns, ", "), strings.Join(placeholders, ", ")))
+	}
+	if len(i.conflict) > 0 {
+		clause, conflictArgs, err := i.conflictClause(args)
+		if err != nil {
+			return "", nil, err
+		}
+		builder.WriteString(clause)
+		args = conflictArgs
+	}
+	builder.WriteString(returningClause(i.returning))
+
+	return builder.String(), args, nil
+}
+
+func (i *Inserter) conflictClause(args []interface{}) (string, []interface{}, error) {
+	columns := make([]string, len(i.conflict))
+	for n, column := range i.conflict {
+		columns[n] = QuoteIdent(column)
+	}
+	target := " (" + strings.Join(columns, ", ") + ")"
+
+	for column := range i.updates {
+		if !contains(i.columns, column) {
+			return "", nil, fmt.Errorf("cannot upsert into %s: unknown update column %q", i.table, column)
+		}
+	}
+
+	var assignments []string
+	for _, column := range i.columns {
+		value, ok := i.updates[column]
+		if !ok {
+			continue
+		}
+		args = append(args, value)
+		assignments = append(assignments, fmt.Sprintf("%s = $%d", QuoteIdent(column), len(args)))
+	}
+
+	if len(assignments) == 0 {
+		assignments = append(assignments, fmt.Sprintf("%s = EXCLUDED.%s", columns[0], columns[0]))
+	}
+	return " ON CONFLICT" + target + " DO UPDATE SET " + strings.Join(assignments, ", "), args, nil
+}
+
+func MissingColumns(values map[string]interface{}, required []string) []string {
+	var missing []string
+	for _, column := range required {
+		if _, ok := values[column]; !ok {
+			missing = append(missing, column)
+		}
+	}
+	return missing
+}
+
+func returningClause(columns []string) string {
+	if len(columns) == 0 {
+		return ""
+	}
+	quoted := make([]string, len(columns))
+	for i, column := range columns {
+		quoted[i] = QuoteIdent(column)
+	}
+	return " RETURNING " + strings.Join(quoted, ", ")
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,68 @@
+package db
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestInsertSQL(t *testing.T) {
+	tests := []sqlCase{
+		{
+			name:    "values follow column order",
+			builder: Insert("User", userColumns, map[string]interface{}{"email": "e", "id": 1}).Returning(userColumns...),
+			sql:     `INSERT INTO "User" ("id", "email") VALUES ($1, $2) RETURNING "id", "name", "email", "teamId"`,
+			args:    []interface{}{1, "e"},
+		},
+		{
+			name:    "explicit nil is inserted",
+			builder: Insert("User", userColumns, map[string]interface{}{"teamId": nil}),
+			sql:     `INSERT INTO "User" ("teamId") VALUES ($1)`,
+			args:    []interface{}{nil},
+		},
+		{
+			name:    "default values",
+			builder: Insert("User", userColumns, map[string]interface{}{}).Returning("id"),
+			sql:     `INSERT INTO "User" DEFAULT VALUES RETURNING "id"`,
+		},
+		{
+			name:    "unknown column",
+			builder: Insert("User", userColumns, map[string]interface{}{"age": 3}),
+			err:     `cannot insert into User: unknown column "age"`,
+		},
+		{
+			name:    "upsert on columns",
+			builder: Insert("User", userColumns, map[string]interface{}{"email": "e", "name": "n"}).OnConflict([]string{"email"}, map[string]interface{}{"name": "m"}).Returning("id"),
+			sql:     `INSERT INTO "User" ("name", "email") VALUES ($1, $2) ON CONFLICT ("email") DO UPDATE SET "name" = $3 RETURNING "id"`,
+			args:    []interface{}{"n", "e", "m"},
+		},
+		{
+			name:    "upsert on a composite key",
+			builder: Insert("User", userColumns, map[string]interface{}{"teamId": 1, "name": "n"}).OnConflict([]string{"name", "teamId"}, map[string]interface{}{"email": "e"}),
+			sql:     `INSERT INTO "User" ("name", "teamId") VALUES ($1, $2) ON CONFLICT ("name", "teamId") DO UPDATE SET "email" = $3`,
+			args:    []interface{}{"n", 1, "e"},
+		},
+		{
+			name:    "upsert without updates still returns the existing row",
+			builder: Insert("User", userColumns, map[string]interface{}{"email": "e"}).OnConflict([]string{"email"}, nil).Returning("id"),
+			sql:     `INSERT INTO "User" ("email") VALUES ($1) ON CONFLICT ("email") DO UPDATE SET "email" = EXCLUDED."email" RETURNING "id"`,
+			args:    []interface{}{"e"},
+		},
+		{
+			name:    "upsert unknown update column",
+			builder: Insert("User", userColumns, map[string]interface{}{"email": "e"}).OnConflict([]string{"email"}, map[string]interface{}{"age": 1}),
+			err:     `cannot upsert into User: unknown update column "age"`,
+		},
+	}
+
+	runSQLTests(t, tests)
+}
+
+func TestMissingColumns(t *testing.T) {
+	values := map[string]interface{}{"email": "e", "teamId": nil}
+	if got := MissingColumns(values, []string{"name", "email", "teamId", "id"}); !reflect.DeepEqual(got, []string{"name", "id"}) {
+		t.Errorf("MissingColumns() = %v, want [name id]", got)
+	}
+	if got := MissingColumns(values, []string{"email"}); got != nil {
+		t.Errorf("MissingColumns() = %v, want nil", got)
+	}
+}
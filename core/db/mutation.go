@@ -0,0 +1,108 @@
+package db
+
+import (
+	"fmt"
+	"strings"
+)
+
+type Updater struct {
+	table     string
+	columns   []string
+	values    map[string]interface{}
+	where     []Expr
+	returning []string
+}
+
+type Deleter struct {
+	table     string
+	where     []Expr
+	returning []string
+}
+
+func Update(table string, columns []string, values map[string]interface{}) *Updater {
+	return &Updater{
+		table:   table,
+		columns: columns,
+		values:  values,
+	}
+}
+
+func (u *Updater) Where(expr Expr) *Updater {
+	if expr != nil {
+		u.where = append(u.where, expr)
+	}
+	return u
+}
+
+func (u *Updater) Returning(columns ...string) *Updater {
+	u.returning = columns
+	return u
+}
+
+func (u *Updater) SQL() (string, []interface{}, error) {
+	if len(u.values) == 0 {
+		return "", nil, fmt.Errorf("cannot update %s: no fields set", u.table)
+	}
+
+	var assignments []string
+	var args []interface{}
+
+	for _, column := range u.columns {
+		value, ok := u.values[column]
+		if !ok {
+			continue
+		}
+		args = append(args, value)
+		assignments = append(assignments, fmt.Sprintf("%s = $%d", QuoteIdent(column), len(args)))
+	}
+
+	if len(args) != len(u.values) {
+		for column := range u.values {
+			if !contains(u.columns, column) {
+				return "", nil, fmt.Errorf("cannot update %s: unknown column %q", u.table, column)
+			}
+		}
+	}
+
+	var builder strings.Builder
+	builder.WriteString(fmt.Sprintf("UPDATE %s SET %s", QuoteIdent(u.table), strings.Join(assignments, ", ")))
+	if len(u.where) > 0 {
+		condition, whereArgs := Compile(And(u.where...), args)
+		builder.WriteString(" WHERE " + condition)
+		args = whereArgs
+	}
+	builder.WriteString(returningClause(u.returning))
+
+	return builder.String(), args, nil
+}
+
+func Delete(table string) *Deleter {
+	return &Deleter{table: table}
+}
+
+func (d *Deleter) Where(expr Expr) *Deleter {
+	if expr != nil {
+		d.where = append(d.where, expr)
+	}
+	return d
+}
+
+func (d *Deleter) Returning(columns ...string) *Deleter {
+	d.returning = columns
+	return d
+}
+
+func (d *Deleter) SQL() (string, []interface{}, error) {
+	var args []interface{}
+
+	var builder strings.Builder
+	builder.WriteString("DELETE FROM " + QuoteIdent(d.table))
+	if len(d.where) > 0 {
+		var condition string
+		condition, args = Compile(And(d.where...), nil)
+		builder.WriteString(" WHERE " + condition)
+	}
+	builder.WriteString(returningClause(d.returning))
+
+	return builder.String(), args, nil
+}
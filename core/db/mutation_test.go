@@ -0,0 +1,49 @@
+package db
+
+import "testing"
+
+func TestMutationSQL(t *testing.T) {
+	tests := []sqlCase{
+		{
+			name:    "update follows column order",
+			builder: Update("User", userColumns, map[string]interface{}{"email": "e", "name": "n"}).Where(EQ(Column("User", "id"), 7)).Returning("id"),
+			sql:     `UPDATE "User" SET "name" = $1, "email" = $2 WHERE "User"."id" = $3 RETURNING "id"`,
+			args:    []interface{}{"n", "e", 7},
+		},
+		{
+			name:    "update without where",
+			builder: Update("User", userColumns, map[string]interface{}{"teamId": nil}),
+			sql:     `UPDATE "User" SET "teamId" = $1`,
+			args:    []interface{}{nil},
+		},
+		{
+			name:    "update with nothing set",
+			builder: Update("User", userColumns, map[string]interface{}{}),
+			err:     "cannot update User: no fields set",
+		},
+		{
+			name:    "update unknown column",
+			builder: Update("User", userColumns, map[string]interface{}{"age": 3}),
+			err:     `cannot update User: unknown column "age"`,
+		},
+		{
+			name:    "delete",
+			builder: Delete("User").Where(In(Column("User", "id"), []int{1, 2})).Returning("id", "email"),
+			sql:     `DELETE FROM "User" WHERE "User"."id" IN ($1, $2) RETURNING "id", "email"`,
+			args:    []interface{}{1, 2},
+		},
+		{
+			name:    "delete everything",
+			builder: Delete("User"),
+			sql:     `DELETE FROM "User"`,
+		},
+		{
+			name:    "where clauses are combined with and",
+			builder: Delete("User").Where(EQ(Column("User", "teamId"), 3)).Where(nil).Where(EQ(Column("User", "name"), "n")),
+			sql:     `DELETE FROM "User" WHERE "User"."teamId" = $1 AND "User"."name" = $2`,
+			args:    []interface{}{3, "n"},
+		},
+	}
+
+	runSQLTests(t, tests)
+}
@@ -0,0 +1,480 @@
+package db
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+const (
+	precedenceOr = iota + 1
+	precedenceAnd
+	precedenceNot
+	precedenceIs
+	precedenceComparison
+	precedenceMatch
+	precedenceOperator
+	precedenceAtom
+)
+
+type Expr interface {
+	precedence() int
+	render(r *renderer)
+}
+
+type ColumnExpr struct {
+	Table string
+	Name  string
+}
+
+type ValueExpr struct {
+	Value interface{}
+}
+
+type LiteralExpr struct {
+	SQL string
+}
+
+type CastExpr struct {
+	Operand Expr
+	Type    string
+}
+
+type FuncExpr struct {
+	Name string
+	Args []Expr
+}
+
+type BinaryExpr struct {
+	Op    string
+	Left  Expr
+	Right Expr
+}
+
+type InExpr struct {
+	Operand Expr
+	Values  []Expr
+	Negated bool
+}
+
+type UnnestInExpr struct {
+	Operands []Expr
+	Arrays   []Expr
+}
+
+type NullExpr struct {
+	Operand Expr
+	Negated bool
+}
+
+type DistinctExpr struct {
+	Left    Expr
+	Right   Expr
+	Negated bool
+}
+
+type LogicalExpr struct {
+	Op       string
+	Operands []Expr
+}
+
+type NotExpr struct {
+	Operand Expr
+}
+
+type ExistsExpr struct {
+	Table      string
+	Columns    []string
+	References []Expr
+	Where      Expr
+}
+
+type renderer struct {
+	builder strings.Builder
+	args    []interface{}
+	scopes  []map[string]string
+	aliases int
+}
+
+func Compile(expr Expr, args []interface{}) (string, []interface{}) {
+	r := &renderer{args: args}
+	expr.render(r)
+	return r.builder.String(), r.args
+}
+
+func (r *renderer) write(s string) {
+	r.builder.WriteString(s)
+}
+
+func (r *renderer) operand(expr Expr, parent int, strict bool) {
+	if expr.precedence() < parent || (strict && expr.precedence() == parent) {
+		r.write("(")
+		expr.render(r)
+		r.write(")")
+		return
+	}
+	expr.render(r)
+}
+
+func (r *renderer) table(name string) string {
+	for i := len(r.scopes) - 1; i >= 0; i-- {
+		if alias, ok := r.scopes[i][name]; ok {
+			return alias
+		}
+	}
+	return QuoteIdent(name)
+}
+
+func Column(table string, name string) *ColumnExpr {
+	return &ColumnExpr{Table: table, Name: name}
+}
+
+func (c *ColumnExpr) precedence() int { return precedenceAtom }
+
+func (c *ColumnExpr) render(r *renderer) {
+	if c.Table != "" {
+		r.write(r.table(c.Table) + ".")
+	}
+	r.write(QuoteIdent(c.Name))
+}
+
+func Value(value interface{}) *ValueExpr {
+	return &ValueExpr{Value: value}
+}
+
+func (v *ValueExpr) precedence() int { return precedenceAtom }
+
+func (v *ValueExpr) render(r *renderer) {
+	r.args = append(r.args, v.Value)
+	r.write(fmt.Sprintf("$%d", len(r.args)))
+}
+
+func Literal(sql string) *LiteralExpr {
+	return &LiteralExpr{SQL: sql}
+}
+
+func (l *LiteralExpr) precedence() int { return precedenceAtom }
+
+func (l *LiteralExpr) render(r *renderer) {
+	r.write(l.SQL)
+}
+
+func Cast(operand Expr, typeName string) *CastExpr {
+	return &CastExpr{Operand: operand, Type: typeName}
+}
+
+func (c *CastExpr) precedence() int { return precedenceAtom }
+
+func (c *CastExpr) render(r *renderer) {
+	r.operand(c.Operand, precedenceAtom, false)
+	r.write("::" + c.Type)
+}
+
+func Func(name string, args ...Expr) *FuncExpr {
+	return &FuncExpr{Name: name, Args: args}
+}
+
+func (f *FuncExpr) precedence() int { return precedenceAtom }
+
+func (f *FuncExpr) render(r *renderer) {
+	r.write(f.Name + "(")
+	for i, arg := range f.Args {
+		if i > 0 {
+			r.write(", ")
+		}
+		arg.render(r)
+	}
+	r.write(")")
+}
+
+func (b *BinaryExpr) precedence() int {
+	switch b.Op {
+	case "=", "<>", "<", "<=", ">", ">=":
+		return precedenceComparison
+	case "LIKE", "NOT LIKE", "ILIKE", "NOT ILIKE":
+		return precedenceMatch
+	}
+	return precedenceOperator
+}
+
+func (b *BinaryExpr) render(r *renderer) {
+	r.operand(b.Left, b.precedence(), true)
+	r.write(" " + b.Op + " ")
+	r.operand(b.Right, b.precedence(), true)
+}
+
+func Op(left Expr, op string, value interface{}) Expr {
+	return &BinaryExpr{Op: op, Left: left, Right: toExpr(value)}
+}
+
+func EQ(left Expr, value interface{}) Expr {
+	if isNil(value) {
+		return IsNull(left)
+	}
+	return Op(left, "=", value)
+}
+
+func NEQ(left Expr, value interface{}) Expr {
+	if isNil(value) {
+		return IsNotNull(left)
+	}
+	return Op(left, "<>", value)
+}
+
+func GT(left Expr, value interface{}) Expr {
+	return Op(left, ">", value)
+}
+
+func GTE(left Expr, value interface{}) Expr {
+	return Op(left, ">=", value)
+}
+
+func LT(left Expr, value interface{}) Expr {
+	return Op(left, "<", value)
+}
+
+func LTE(left Expr, value interface{}) Expr {
+	return Op(left, "<=", value)
+}
+
+func Contains(left Expr, value string) Expr {
+	return Op(left, "LIKE", "%"+escapeLike(value)+"%")
+}
+
+func HasPrefix(left Expr, value string) Expr {
+	return Op(left, "LIKE", escapeLike(value)+"%")
+}
+
+func HasSuffix(left Expr, value string) Expr {
+	return Op(left, "LIKE", "%"+escapeLike(value))
+}
+
+func In[T any](left Expr, values []T) Expr {
+	exprs := make([]Expr, len(values))
+	for i, value := range values {
+		exprs[i] = Value(value)
+	}
+	return &InExpr{Operand: left, Values: exprs}
+}
+
+func NotIn[T any](left Expr, values []T) Expr {
+	exprs := make([]Expr, len(values))
+	for i, value := range values {
+		exprs[i] = Value(value)
+	}
+	return &InExpr{Operand: left, Values: exprs, Negated: true}
+}
+
+func (in *InExpr) precedence() int {
+	if len(in.Values) == 0 {
+		return precedenceAtom
+	}
+	return precedenceMatch
+}
+
+func (in *InExpr) render(r *renderer) {
+	if len(in.Values) == 0 {
+		if in.Negated {
+			r.write("TRUE")
+		} else {
+			r.write("FALSE")
+		}
+		return
+	}
+
+	r.operand(in.Operand, precedenceMatch, true)
+	if in.Negated {
+		r.write(" NOT IN (")
+	} else {
+		r.write(" IN (")
+	}
+	for i, value := range in.Values {
+		if i > 0 {
+			r.write(", ")
+		}
+		value.render(r)
+	}
+	r.write(")")
+}
+
+func InUnnest(operands []Expr, arrays ...Expr) Expr {
+	return &UnnestInExpr{Operands: operands, Arrays: arrays}
+}
+
+func (u *UnnestInExpr) precedence() int { return precedenceMatch }
+
+func (u *UnnestInExpr) render(r *renderer) {
+	r.write("(")
+	for i, operand := range u.Operands {
+		if i > 0 {
+			r.write(", ")
+		}
+		operand.render(r)
+	}
+	r.write(") IN (SELECT * FROM ")
+	Func("unnest", u.Arrays...).render(r)
+	r.write(")")
+}
+
+func IsNull(operand Expr) Expr {
+	return &NullExpr{Operand: operand}
+}
+
+func IsNotNull(operand Expr) Expr {
+	return &NullExpr{Operand: operand, Negated: true}
+}
+
+func (n *NullExpr) precedence() int { return precedenceIs }
+
+func (n *NullExpr) render(r *renderer) {
+	r.operand(n.Operand, precedenceIs, true)
+	if n.Negated {
+		r.write(" IS NOT NULL")
+	} else {
+		r.write(" IS NULL")
+	}
+}
+
+func IsDistinctFrom(left Expr, value interface{}) Expr {
+	if isNil(value) {
+		return IsNotNull(left)
+	}
+	return &DistinctExpr{Left: left, Right: toExpr(value)}
+}
+
+func (d *DistinctExpr) precedence() int { return precedenceIs }
+
+func (d *DistinctExpr) render(r *renderer) {
+	r.operand(d.Left, precedenceIs, true)
+	if d.Negated {
+		r.write(" IS NOT DISTINCT FROM ")
+	} else {
+		r.write(" IS DISTINCT FROM ")
+	}
+	r.operand(d.Right, precedenceIs, true)
+}
+
+func And(operands ...Expr) Expr {
+	return logical("AND", operands)
+}
+
+func Or(operands ...Expr) Expr {
+	return logical("OR", operands)
+}
+
+func logical(op string, operands []Expr) Expr {
+	var filtered []Expr
+	for _, operand := range operands {
+		if operand != nil {
+			filtered = append(filtered, operand)
+		}
+	}
+	if len(filtered) == 1 {
+		return filtered[0]
+	}
+	return &LogicalExpr{Op: op, Operands: filtered}
+}
+
+func (l *LogicalExpr) precedence() int {
+	if len(l.Operands) == 0 {
+		return precedenceAtom
+	}
+	if l.Op == "OR" {
+		return precedenceOr
+	}
+	return precedenceAnd
+}
+
+func (l *LogicalExpr) render(r *renderer) {
+	if len(l.Operands) == 0 {
+		if l.Op == "OR" {
+			r.write("FALSE")
+		} else {
+			r.write("TRUE")
+		}
+		return
+	}
+
+	for i, operand := range l.Operands {
+		if i > 0 {
+			r.write(" " + l.Op + " ")
+		}
+		r.operand(operand, l.precedence(), false)
+	}
+}
+
+func Not(operand Expr) Expr {
+	return &NotExpr{Operand: operand}
+}
+
+func (n *NotExpr) precedence() int { return precedenceNot }
+
+func (n *NotExpr) render(r *renderer) {
+	r.write("NOT ")
+	r.operand(n.Operand, precedenceNot, false)
+}
+
+func Exists(table string, columns []string, references []Expr, where Expr) Expr {
+	return &ExistsExpr{Table: table, Columns: columns, References: references, Where: where}
+}
+
+func (e *ExistsExpr) precedence() int { return precedenceAtom }
+
+func (e *ExistsExpr) render(r *renderer) {
+	references := make([]string, len(e.References))
+	for i, reference := range e.References {
+		var sub renderer
+		sub.args = r.args
+		sub.scopes = r.scopes
+		sub.aliases = r.aliases
+		reference.render(&sub)
+		references[i] = sub.builder.String()
+		r.args = sub.args
+	}
+
+	r.aliases++
+	alias := QuoteIdent(fmt.Sprintf("t%d", r.aliases))
+	r.scopes = append(r.scopes, map[string]string{e.Table: alias})
+
+	r.write(fmt.Sprintf("EXISTS (SELECT 1 FROM %s AS %s WHERE ", QuoteIdent(e.Table), alias))
+	for i, column := range e.Columns {
+		if i > 0 {
+			r.write(" AND ")
+		}
+		r.write(fmt.Sprintf("%s.%s = %s", alias, QuoteIdent(column), references[i]))
+	}
+	if e.Where != nil {
+		if len(e.Columns) > 0 {
+			r.write(" AND ")
+		}
+		r.operand(e.Where, precedenceAnd, false)
+	} else if len(e.Columns) == 0 {
+		r.write("TRUE")
+	}
+	r.write(")")
+
+	r.scopes = r.scopes[:len(r.scopes)-1]
+}
+
+func toExpr(value interface{}) Expr {
+	if expr, ok := value.(Expr); ok {
+		return expr
+	}
+	return Value(value)
+}
+
+func isNil(value interface{}) bool {
+	if value == nil {
+		return true
+	}
+	v := reflect.ValueOf(value)
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Slice, reflect.Map, reflect.Interface:
+		return v.IsNil()
+	}
+	return false
+}
+
+func escapeLike(value string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(value)
+}
@@ -0,0 +1,327 @@
+package db
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCompile(t *testing.T) {
+	name := Column("User", "name")
+	age := Column("User", "age")
+	id := Column("User", "id")
+
+	tests := []struct {
+		name string
+		expr Expr
+		sql  string
+		args []interface{}
+	}{
+		{
+			name: "equality",
+			expr: EQ(name, "alice"),
+			sql:  `"User"."name" = $1`,
+			args: []interface{}{"alice"},
+		},
+		{
+			name: "unqualified column",
+			expr: EQ(Column("", "name"), "alice"),
+			sql:  `"name" = $1`,
+			args: []interface{}{"alice"},
+		},
+		{
+			name: "quoted identifier",
+			expr: EQ(Column("User", `we"ird`), 1),
+			sql:  `"User"."we""ird" = $1`,
+			args: []interface{}{1},
+		},
+		{
+			name: "nil equality becomes IS NULL",
+			expr: EQ(name, nil),
+			sql:  `"User"."name" IS NULL`,
+		},
+		{
+			name: "typed nil inequality becomes IS NOT NULL",
+			expr: NEQ(name, (*string)(nil)),
+			sql:  `"User"."name" IS NOT NULL`,
+		},
+		{
+			name: "comparison operators",
+			expr: And(GT(age, 1), GTE(age, 2), LT(age, 3), LTE(age, 4)),
+			sql:  `"User"."age" > $1 AND "User"."age" >= $2 AND "User"."age" < $3 AND "User"."age" <= $4`,
+			args: []interface{}{1, 2, 3, 4},
+		},
+		{
+			name: "in list",
+			expr: In(id, []int{1, 2, 3}),
+			sql:  `"User"."id" IN ($1, $2, $3)`,
+			args: []interface{}{1, 2, 3},
+		},
+		{
+			name: "not in list",
+			expr: NotIn(id, []int{7}),
+			sql:  `"User"."id" NOT IN ($1)`,
+			args: []interface{}{7},
+		},
+		{
+			name: "empty in is false",
+			expr: In(id, []int{}),
+			sql:  `FALSE`,
+		},
+		{
+			name: "empty not in is true",
+			expr: NotIn(id, []int{}),
+			sql:  `TRUE`,
+		},
+		{
+			name: "empty in under not",
+			expr: Not(In(id, []int{})),
+			sql:  `NOT FALSE`,
+		},
+		{
+			name: "empty and is true",
+			expr: And(),
+			sql:  `TRUE`,
+		},
+		{
+			name: "empty or is false",
+			expr: Or(),
+			sql:  `FALSE`,
+		},
+		{
+			name: "nil operands are dropped",
+			expr: And(nil, EQ(id, 1), nil),
+			sql:  `"User"."id" = $1`,
+			args: []interface{}{1},
+		},
+		{
+			name: "or inside and is parenthesized",
+			expr: And(Or(EQ(id, 1), EQ(id, 2)), EQ(name, "a")),
+			sql:  `("User"."id" = $1 OR "User"."id" = $2) AND "User"."name" = $3`,
+			args: []interface{}{1, 2, "a"},
+		},
+		{
+			name: "and inside or is not parenthesized",
+			expr: Or(And(EQ(id, 1), EQ(name, "a")), EQ(id, 2)),
+			sql:  `"User"."id" = $1 AND "User"."name" = $2 OR "User"."id" = $3`,
+			args: []interface{}{1, "a", 2},
+		},
+		{
+			name: "nested and is not parenthesized",
+			expr: And(And(EQ(id, 1), EQ(id, 2)), EQ(id, 3)),
+			sql:  `"User"."id" = $1 AND "User"."id" = $2 AND "User"."id" = $3`,
+			args: []interface{}{1, 2, 3},
+		},
+		{
+			name: "not over and is parenthesized",
+			expr: Not(And(EQ(id, 1), EQ(name, "a"))),
+			sql:  `NOT ("User"."id" = $1 AND "User"."name" = $2)`,
+			args: []interface{}{1, "a"},
+		},
+		{
+			name: "not over comparison",
+			expr: Not(EQ(id, 1)),
+			sql:  `NOT "User"."id" = $1`,
+			args: []interface{}{1},
+		},
+		{
+			name: "comparison of comparisons is parenthesized",
+			expr: EQ(EQ(id, 1), true),
+			sql:  `("User"."id" = $1) = $2`,
+			args: []interface{}{1, true},
+		},
+		{
+			name: "is null over comparison needs no parens",
+			expr: IsNull(EQ(id, 1)),
+			sql:  `"User"."id" = $1 IS NULL`,
+			args: []interface{}{1},
+		},
+		{
+			name: "comparison over is null is parenthesized",
+			expr: EQ(IsNull(id), true),
+			sql:  `("User"."id" IS NULL) = $1`,
+			args: []interface{}{true},
+		},
+		{
+			name: "arithmetic operand is not parenthesized",
+			expr: GT(&BinaryExpr{Op: "+", Left: age, Right: Value(1)}, 2),
+			sql:  `"User"."age" + $1 > $2`,
+			args: []interface{}{1, 2},
+		},
+		{
+			name: "same operator on the right is parenthesized",
+			expr: &BinaryExpr{Op: "-", Left: age, Right: &BinaryExpr{Op: "-", Left: Value(1), Right: Value(2)}},
+			sql:  `"User"."age" - ($1 - $2)`,
+			args: []interface{}{1, 2},
+		},
+		{
+			name: "cast of operator is parenthesized",
+			expr: Cast(&BinaryExpr{Op: "||", Left: name, Right: Value("x")}, "text"),
+			sql:  `("User"."name" || $1)::text`,
+			args: []interface{}{"x"},
+		},
+		{
+			name: "function arguments",
+			expr: EQ(Func("lower", name), Func("lower", Value("A"))),
+			sql:  `lower("User"."name") = lower($1)`,
+			args: []interface{}{"A"},
+		},
+		{
+			name: "is distinct from",
+			expr: IsDistinctFrom(name, "a"),
+			sql:  `"User"."name" IS DISTINCT FROM $1`,
+			args: []interface{}{"a"},
+		},
+		{
+			name: "is distinct from nil",
+			expr: IsDistinctFrom(name, nil),
+			sql:  `"User"."name" IS NOT NULL`,
+		},
+		{
+			name: "contains escapes wildcards",
+			expr: Contains(name, `50%_off\`),
+			sql:  `"User"."name" LIKE $1`,
+			args: []interface{}{`%50\%\_off\\%`},
+		},
+		{
+			name: "prefix",
+			expr: HasPrefix(name, "a_"),
+			sql:  `"User"."name" LIKE $1`,
+			args: []interface{}{`a\_%`},
+		},
+		{
+			name: "suffix",
+			expr: HasSuffix(name, "%z"),
+			sql:  `"User"."name" LIKE $1`,
+			args: []interface{}{`%\%z`},
+		},
+		{
+			name: "expression operand is not bound",
+			expr: EQ(Column("Post", "authorId"), Column("User", "id")),
+			sql:  `"Post"."authorId" = "User"."id"`,
+		},
+		{
+			name: "row comparison against unnested arrays",
+			expr: InUnnest([]Expr{Column("Badge", "orgId"), Column("Badge", "handle")}, Cast(Value([]int32{1, 2}), "int4[]"), Cast(Value([]string{"a", "b"}), "text[]")),
+			sql:  `("Badge"."orgId", "Badge"."handle") IN (SELECT * FROM unnest($1::int4[], $2::text[]))`,
+			args: []interface{}{[]int32{1, 2}, []string{"a", "b"}},
+		},
+		{
+			name: "unnest comparison inside a disjunction",
+			expr: Or(EQ(id, 1), InUnnest([]Expr{name, age}, Value([]string{"a"}), Value([]int{3}))),
+			sql:  `"User"."id" = $1 OR ("User"."name", "User"."age") IN (SELECT * FROM unnest($2, $3))`,
+			args: []interface{}{1, []string{"a"}, []int{3}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sql, args := Compile(tt.expr, nil)
+			if sql != tt.sql {
+				t.Errorf("sql = %s, want %s", sql, tt.sql)
+			}
+			if !reflect.DeepEqual(args, tt.args) {
+				t.Errorf("args = %#v, want %#v", args, tt.args)
+			}
+		})
+	}
+}
+
+func TestCompileExists(t *testing.T) {
+	tests := []struct {
+		name string
+		expr Expr
+		sql  string
+		args []interface{}
+	}{
+		{
+			name: "correlated subquery",
+			expr: Exists("Post", []string{"authorId"}, []Expr{Column("User", "id")}, EQ(Column("Post", "published"), true)),
+			sql:  `EXISTS (SELECT 1 FROM "Post" AS "t1" WHERE "t1"."authorId" = "User"."id" AND "t1"."published" = $1)`,
+			args: []interface{}{true},
+		},
+		{
+			name: "no columns and no filter",
+			expr: Exists("Post", nil, nil, nil),
+			sql:  `EXISTS (SELECT 1 FROM "Post" AS "t1" WHERE TRUE)`,
+		},
+		{
+			name: "or filter is parenthesized",
+			expr: Exists("Post", []string{"authorId"}, []Expr{Column("User", "id")}, Or(EQ(Column("Post", "id"), 1), EQ(Column("Post", "id"), 2))),
+			sql:  `EXISTS (SELECT 1 FROM "Post" AS "t1" WHERE "t1"."authorId" = "User"."id" AND ("t1"."id" = $1 OR "t1"."id" = $2))`,
+			args: []interface{}{1, 2},
+		},
+		{
+			name: "self relation resolves to the inner alias",
+			expr: Exists("User", []string{"managerId"}, []Expr{Column("User", "id")}, EQ(Column("User", "name"), "bob")),
+			sql:  `EXISTS (SELECT 1 FROM "User" AS "t1" WHERE "t1"."managerId" = "User"."id" AND "t1"."name" = $1)`,
+			args: []interface{}{"bob"},
+		},
+		{
+			name: "nested self relation uses distinct aliases",
+			expr: Exists("User", []string{"managerId"}, []Expr{Column("User", "id")},
+				Exists("User", []string{"managerId"}, []Expr{Column("User", "id")}, EQ(Column("User", "name"), "eve"))),
+			sql:  `EXISTS (SELECT 1 FROM "User" AS "t1" WHERE "t1"."managerId" = "User"."id" AND EXISTS (SELECT 1 FROM "User" AS "t2" WHERE "t2"."managerId" = "t1"."id" AND "t2"."name" = $1))`,
+			args: []interface{}{"eve"},
+		},
+		{
+			name: "sibling subqueries get fresh aliases",
+			expr: And(
+				Exists("Post", []string{"authorId"}, []Expr{Column("User", "id")}, EQ(Column("Post", "id"), 1)),
+				Not(Exists("Post", []string{"authorId"}, []Expr{Column("User", "id")}, EQ(Column("Post", "id"), 2))),
+			),
+			sql:  `EXISTS (SELECT 1 FROM "Post" AS "t1" WHERE "t1"."authorId" = "User"."id" AND "t1"."id" = $1) AND NOT EXISTS (SELECT 1 FROM "Post" AS "t2" WHERE "t2"."authorId" = "User"."id" AND "t2"."id" = $2)`,
+			args: []interface{}{1, 2},
+		},
+		{
+			name: "outer table outside the subquery is not aliased",
+			expr: And(
+				Exists("Post", []string{"authorId"}, []Expr{Column("User", "id")}, nil),
+				EQ(Column("Post", "id"), 3),
+			),
+			sql:  `EXISTS (SELECT 1 FROM "Post" AS "t1" WHERE "t1"."authorId" = "User"."id") AND "Post"."id" = $1`,
+			args: []interface{}{3},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sql, args := Compile(tt.expr, nil)
+			if sql != tt.sql {
+				t.Errorf("sql = %s, want %s", sql, tt.sql)
+			}
+			if !reflect.DeepEqual(args, tt.args) {
+				t.Errorf("args = %#v, want %#v", args, tt.args)
+			}
+		})
+	}
+}
+
+func TestCompileContinuesPlaceholders(t *testing.T) {
+	sql, args := Compile(EQ(Column("User", "id"), 9), []interface{}{"a", "b"})
+	if sql != `"User"."id" = $3` {
+		t.Errorf("sql = %s", sql)
+	}
+	if !reflect.DeepEqual(args, []interface{}{"a", "b", 9}) {
+		t.Errorf("args = %#v", args)
+	}
+}
+
+func TestEscapeLike(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{in: "", want: ""},
+		{in: "plain", want: "plain"},
+		{in: "100%", want: `100\%`},
+		{in: "a_b", want: `a\_b`},
+		{in: `back\slash`, want: `back\\slash`},
+		{in: `\%_`, want: `\\\%\_`},
+	}
+
+	for _, tt := range tests {
+		if got := escapeLike(tt.in); got != tt.want {
+			t.Errorf("escapeLike(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,189 @@
+package db
+
+import (
+	"fmt"
+	"strings"
+)
+
+type Selector struct {
+	table     string
+	columns   []string
+	projected []string
+	where     []Expr
+	orders    []string
+	limit     *int
+	offset    *int
+	partition []string
+	err       error
+}
+
+func Select(table string, columns ...string) *Selector {
+	return &Selector{
+		table:   table,
+		columns: columns,
+	}
+}
+
+func QuoteIdent(name string) string {
+	return `"` + strings.ReplaceAll(name, `"`, `""`) + `"`
+}
+
+func (s *Selector) Columns(columns ...string) *Selector {
+	if len(columns) == 0 {
+		s.err = fmt.Errorf("cannot select zero columns from %s", s.table)
+		return s
+	}
+	for _, column := range columns {
+		if !contains(s.columns, column) {
+			s.err = fmt.Errorf("cannot select unknown column %q from %s", column, s.table)
+			return s
+		}
+	}
+	s.projected = columns
+	return s
+}
+
+func (s *Selector) Where(expr Expr) *Selector {
+	if expr != nil {
+		s.where = append(s.where, expr)
+	}
+	return s
+}
+
+func (s *Selector) OrderBy(column string, desc bool) *Selector {
+	if !contains(s.columns, column) {
+		s.err = fmt.Errorf("cannot order %s by unknown column %q", s.table, column)
+		return s
+	}
+
+	direction := "ASC"
+	if desc {
+		direction = "DESC"
+	}
+	s.orders = append(s.orders, fmt.Sprintf("%s %s", QuoteIdent(column), direction))
+	return s
+}
+
+func (s *Selector) Limit(limit int) *Selector {
+	s.limit = &limit
+	return s
+}
+
+func (s *Selector) Offset(offset int) *Selector {
+	s.offset = &offset
+	return s
+}
+
+func (s *Selector) PartitionBy(columns ...string) *Selector {
+	s.partition = columns
+	return s
+}
+
+func (s *Selector) fromClause() (string, []interface{}) {
+	if len(s.where) == 0 {
+		return " FROM " + QuoteIdent(s.table), nil
+	}
+	condition, args := Compile(And(s.where...), nil)
+	return " FROM " + QuoteIdent(s.table) + " WHERE " + condition, args
+}
+
+func (s *Selector) pageClause() (string, error) {
+	var builder strings.Builder
+	if s.limit != nil {
+		if *s.limit < 0 {
+			return "", fmt.Errorf("limit must not be negative, got %d", *s.limit)
+		}
+		builder.WriteString(fmt.Sprintf(" LIMIT %d", *s.limit))
+	}
+	if s.offset != nil {
+		if *s.offset < 0 {
+			return "", fmt.Errorf("offset must not be negative, got %d", *s.offset)
+		}
+		builder.WriteString(fmt.Sprintf(" OFFSET %d", *s.offset))
+	}
+	return builder.String(), nil
+}
+
+func (s *Selector) SQL() (string, []interface{}, error) {
+	if s.err != nil {
+		return "", nil, s.err
+	}
+
+	selected := s.columns
+	if len(s.projected) > 0 {
+		selected = s.projected
+	}
+	columns := make([]string, len(selected))
+	for i, column := range selected {
+		columns[i] = QuoteIdent(column)
+	}
+
+	page, err := s.pageClause()
+	if err != nil {
+		return "", nil, err
+	}
+
+	from, args := s.fromClause()
+
+	if len(s.partition) > 0 && page != "" {
+		return s.partitionedSQL(columns, from), args, nil
+	}
+
+	var builder strings.Builder
+	builder.WriteString("SELECT " + strings.Join(columns, ", "))
+	builder.WriteString(from)
+	if len(s.orders) > 0 {
+		builder.WriteString(" ORDER BY " + strings.Join(s.orders, ", "))
+	}
+	builder.WriteString(page)
+
+	return builder.String(), args, nil
+}
+
+func (s *Selector) partitionedSQL(columns []string, from string) string {
+	partition := make([]string, len(s.partition))
+	for i, column := range s.partition {
+		partition[i] = QuoteIdent(column)
+	}
+
+	window := "PARTITION BY " + strings.Join(partition, ", ")
+	if len(s.orders) > 0 {
+		window += " ORDER BY " + strings.Join(s.orders, ", ")
+	}
+
+	var bounds []string
+	offset := 0
+	if s.offset != nil {
+		offset = *s.offset
+		bounds = append(bounds, fmt.Sprintf(`"__rank" > %d`, offset))
+	}
+	if s.limit != nil {
+		bounds = append(bounds, fmt.Sprintf(`"__rank" <= %d`, offset+*s.limit))
+	}
+
+	var builder strings.Builder
+	builder.WriteString("SELECT " + strings.Join(columns, ", "))
+	builder.WriteString(fmt.Sprintf(` FROM (SELECT %s, ROW_NUMBER() OVER (%s) AS "__rank"%s) AS "ranked"`, strings.Join(columns, ", "), window, from))
+	builder.WriteString(" WHERE " + strings.Join(bounds, " AND "))
+	builder.WriteString(" ORDER BY " + strings.Join(append(partition, `"__rank"`), ", "))
+
+	return builder.String()
+}
+
+func (s *Selector) CountSQL() (string, []interface{}, error) {
+	if s.err != nil {
+		return "", nil, s.err
+	}
+
+	page, err := s.pageClause()
+	if err != nil {
+		return "", nil, err
+	}
+
+	from, args := s.fromClause()
+	if page == "" {
+		return "SELECT COUNT(*)" + from, args, nil
+	}
+
+	return fmt.Sprintf("SELECT COUNT(*) FROM (SELECT 1%s%s) AS counted", from, page), args, nil
+}
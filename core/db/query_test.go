@@ -0,0 +1,144 @@
+package db
+
+import (
+	"reflect"
+	"testing"
+)
+
+var userColumns = []string{"id", "name", "email", "teamId"}
+
+type sqlBuilder interface {
+	SQL() (string, []interface{}, error)
+}
+
+type sqlCase struct {
+	name    string
+	builder sqlBuilder
+	sql     string
+	args    []interface{}
+	err     string
+}
+
+func TestSelectorSQL(t *testing.T) {
+	tests := []sqlCase{
+		{
+			name:    "all columns",
+			builder: Select("User", userColumns...),
+			sql:     `SELECT "id", "name", "email", "teamId" FROM "User"`,
+		},
+		{
+			name:    "projection",
+			builder: Select("User", userColumns...).Columns("id", "email"),
+			sql:     `SELECT "id", "email" FROM "User"`,
+		},
+		{
+			name:    "where clauses are combined with and",
+			builder: Select("User", userColumns...).Where(EQ(Column("User", "id"), 1)).Where(nil).Where(Or(EQ(Column("User", "name"), "a"), EQ(Column("User", "name"), "b"))),
+			sql:     `SELECT "id", "name", "email", "teamId" FROM "User" WHERE "User"."id" = $1 AND ("User"."name" = $2 OR "User"."name" = $3)`,
+			args:    []interface{}{1, "a", "b"},
+		},
+		{
+			name:    "order limit offset",
+			builder: Select("User", userColumns...).OrderBy("name", false).OrderBy("id", true).Limit(10).Offset(20),
+			sql:     `SELECT "id", "name", "email", "teamId" FROM "User" ORDER BY "name" ASC, "id" DESC LIMIT 10 OFFSET 20`,
+		},
+		{
+			name:    "partitioned page",
+			builder: Select("User", userColumns...).Columns("id", "teamId").Where(In(Column("User", "teamId"), []int{1, 2})).PartitionBy("teamId").OrderBy("id", false).Limit(2).Offset(1),
+			sql:     `SELECT "id", "teamId" FROM (SELECT "id", "teamId", ROW_NUMBER() OVER (PARTITION BY "teamId" ORDER BY "id" ASC) AS "__rank" FROM "User" WHERE "User"."teamId" IN ($1, $2)) AS "ranked" WHERE "__rank" > 1 AND "__rank" <= 3 ORDER BY "teamId", "__rank"`,
+			args:    []interface{}{1, 2},
+		},
+		{
+			name:    "partition without page is a plain select",
+			builder: Select("User", userColumns...).PartitionBy("teamId"),
+			sql:     `SELECT "id", "name", "email", "teamId" FROM "User"`,
+		},
+		{
+			name:    "unknown projected column",
+			builder: Select("User", userColumns...).Columns("password"),
+			err:     `cannot select unknown column "password" from User`,
+		},
+		{
+			name:    "empty projection",
+			builder: Select("User", userColumns...).Columns(),
+			err:     "cannot select zero columns from User",
+		},
+		{
+			name:    "unknown order column",
+			builder: Select("User", userColumns...).OrderBy("age", false),
+			err:     `cannot order User by unknown column "age"`,
+		},
+		{
+			name:    "negative limit",
+			builder: Select("User", userColumns...).Limit(-1),
+			err:     "limit must not be negative, got -1",
+		},
+		{
+			name:    "negative offset",
+			builder: Select("User", userColumns...).Offset(-5),
+			err:     "offset must not be negative, got -5",
+		},
+	}
+
+	runSQLTests(t, tests)
+}
+
+func TestSelectorCountSQL(t *testing.T) {
+	tests := []struct {
+		name     string
+		selector *Selector
+		sql      string
+		args     []interface{}
+	}{
+		{
+			name:     "plain count",
+			selector: Select("User", userColumns...).Where(EQ(Column("User", "teamId"), 3)),
+			sql:      `SELECT COUNT(*) FROM "User" WHERE "User"."teamId" = $1`,
+			args:     []interface{}{3},
+		},
+		{
+			name:     "paged count",
+			selector: Select("User", userColumns...).OrderBy("id", false).Limit(5).Offset(10),
+			sql:      `SELECT COUNT(*) FROM (SELECT 1 FROM "User" LIMIT 5 OFFSET 10) AS counted`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sql, args, err := tt.selector.CountSQL()
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if sql != tt.sql {
+				t.Errorf("sql = %s, want %s", sql, tt.sql)
+			}
+			if !reflect.DeepEqual(args, tt.args) {
+				t.Errorf("args = %#v, want %#v", args, tt.args)
+			}
+		})
+	}
+}
+
+func runSQLTests(t *testing.T, tests []sqlCase) {
+	t.Helper()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sql, args, err := tt.builder.SQL()
+			if tt.err != "" {
+				if err == nil || err.Error() != tt.err {
+					t.Fatalf("err = %v, want %s", err, tt.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if sql != tt.sql {
+				t.Errorf("sql = %s, want %s", sql, tt.sql)
+			}
+			if !reflect.DeepEqual(args, tt.args) {
+				t.Errorf("args = %#v, want %#v", args, tt.args)
+			}
+		})
+	}
+}
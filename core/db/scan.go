@@ -0,0 +1,92 @@
+package db
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+)
+
+func ScanRows(rows pgx.Rows, into interface{}) error {
+	target := reflect.ValueOf(into)
+	if target.Kind() != reflect.Ptr || target.IsNil() {
+		return fmt.Errorf("scan destination must be a non-nil pointer, got %T", into)
+	}
+	target = target.Elem()
+
+	var elemType reflect.Type
+	many := target.Kind() == reflect.Slice
+	if many {
+		elemType = target.Type().Elem()
+	} else {
+		elemType = target.Type()
+	}
+	pointer := elemType.Kind() == reflect.Ptr
+	if pointer {
+		elemType = elemType.Elem()
+	}
+	if elemType.Kind() != reflect.Struct {
+		return fmt.Errorf("scan destination must point to a struct or a slice of structs, got %T", into)
+	}
+
+	fields := rows.FieldDescriptions()
+	indexes := make([][]int, len(fields))
+	for i, fd := range fields {
+		index, ok := structFieldIndex(elemType, fd.Name)
+		if !ok {
+			return fmt.Errorf("cannot scan column %q into %s: no matching field", fd.Name, elemType)
+		}
+		indexes[i] = index
+	}
+
+	found := false
+	for rows.Next() {
+		elem := reflect.New(elemType).Elem()
+		targets := make([]interface{}, len(indexes))
+		for i, index := range indexes {
+			targets[i] = elem.FieldByIndex(index).Addr().Interface()
+		}
+		if err := rows.Scan(targets...); err != nil {
+			return fmt.Errorf("failed to scan into %s: %w", elemType, err)
+		}
+
+		value := elem
+		if pointer {
+			value = elem.Addr()
+		}
+		if !many {
+			target.Set(value)
+			found = true
+			break
+		}
+		target.Set(reflect.Append(target, value))
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+	if !many && !found {
+		return pgx.ErrNoRows
+	}
+	return nil
+}
+
+func structFieldIndex(structType reflect.Type, column string) ([]int, bool) {
+	normalized := strings.ReplaceAll(column, "_", "")
+	for i := 0; i < structType.NumField(); i++ {
+		f := structType.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+		if tag, ok := f.Tag.Lookup("db"); ok {
+			if tag == column {
+				return f.Index, true
+			}
+			continue
+		}
+		if strings.EqualFold(f.Name, column) || strings.EqualFold(f.Name, normalized) {
+			return f.Index, true
+		}
+	}
+	return nil, false
+}
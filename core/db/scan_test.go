@@ -0,0 +1,61 @@
+package db
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestStructFieldIndex(t *testing.T) {
+	type row struct {
+		ID        int
+		TeamID    int
+		CreatedAt string `db:"created"`
+		Ignored   string `db:"-"`
+		secret    string
+	}
+
+	tests := []struct {
+		column string
+		index  []int
+		ok     bool
+	}{
+		{column: "id", index: []int{0}, ok: true},
+		{column: "teamId", index: []int{1}, ok: true},
+		{column: "team_id", index: []int{1}, ok: true},
+		{column: "created", index: []int{2}, ok: true},
+		{column: "createdAt"},
+		{column: "ignored"},
+		{column: "secret"},
+	}
+
+	for _, tt := range tests {
+		index, ok := structFieldIndex(reflect.TypeOf(row{}), tt.column)
+		if ok != tt.ok || !reflect.DeepEqual(index, tt.index) {
+			t.Errorf("structFieldIndex(%q) = %v, %v, want %v, %v", tt.column, index, ok, tt.index, tt.ok)
+		}
+	}
+}
+
+func TestScanRowsRejectsDestination(t *testing.T) {
+	var count int
+	var names []string
+	tests := []struct {
+		name string
+		into interface{}
+		err  string
+	}{
+		{name: "not a pointer", into: struct{}{}, err: "scan destination must be a non-nil pointer, got struct {}"},
+		{name: "nil pointer", into: (*struct{})(nil), err: "scan destination must be a non-nil pointer, got *struct {}"},
+		{name: "scalar", into: &count, err: "scan destination must point to a struct or a slice of structs, got *int"},
+		{name: "slice of scalars", into: &names, err: "scan destination must point to a struct or a slice of structs, got *[]string"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ScanRows(nil, tt.into)
+			if err == nil || err.Error() != tt.err {
+				t.Fatalf("ScanRows() error = %v, want %q", err, tt.err)
+			}
+		})
+	}
+}
@@ -5,13 +5,14 @@ import (
 	"strings"
 )
 
-func GenerateDBUtils() string {
+func GenerateDBUtils(typeNames []string) string {
 	var content strings.Builder
 
 	content.WriteString(`package client
 
 import (
     "context"
+    "errors"
     "fmt"
 
     "github.com/jackc/pgx/v5/pgconn"
@@ -24,8 +25,55 @@ type BlazeDatabaseClient struct {
     ctx context.Context
 }
 
+type ExclusionViolationError struct {
+    Table      string
+    Constraint string
+    Detail     string
+    Err        error
+}
+
+func (e *ExclusionViolationError) Error() string {
+    return fmt.Sprintf("exclusion constraint %s on %s violated: %s", e.Constraint, e.Table, e.Detail)
+}
+
+func (e *ExclusionViolationError) Unwrap() error {
+    return e.Err
+}
+
+type NotFoundError struct {
+    Table string
+}
+
+func (e *NotFoundError) Error() string {
+    return fmt.Sprintf("%s record not found", e.Table)
+}
+
+func mapDatabaseError(err error) error {
+    var pgErr *pgconn.PgError
+    if errors.As(err, &pgErr) && pgErr.Code == constants.PG_EXCLUSION_VIOLATION {
+        return &ExclusionViolationError{
+            Table:      pgErr.TableName,
+            Constraint: pgErr.ConstraintName,
+            Detail:     pgErr.Detail,
+            Err:        err,
+        }
+    }
+    return err
+}
+`)
+
+	content.WriteString("\nvar userTypes = []string{")
+	for i, typeName := range typeNames {
+		if i > 0 {
+			content.WriteString(", ")
+		}
+		content.WriteString(fmt.Sprintf("%q", typeName))
+	}
+	content.WriteString("}\n")
+
+	content.WriteString(`
 func DB(ctx context.Context, envFile string) (*BlazeDatabaseClient, error) {
-    blazeDB, err := db.DB(ctx, envFile)
+    blazeDB, err := db.DB(ctx, envFile, userTypes...)
     if err != nil {
         return nil, err
     }
@@ -49,6 +97,14 @@ func (c *BlazeDatabaseClient) Context() context.Context {
     return c.ctx
 }
 
+func (c *BlazeDatabaseClient) WithSession(settings map[string]string) (*BlazeDatabaseClient, error) {
+    ctx, err := db.WithSessionSettings(c.Context(), settings)
+    if err != nil {
+        return nil, err
+    }
+    return c.WithContext(ctx), nil
+}
+
 func (c *BlazeDatabaseClient) Connect() bool {
     if c.Pool == nil {
         return false
@@ -75,7 +131,7 @@ func (c *BlazeDatabaseClient) ExecuteRaw(query string, args ...interface{}) (int
 
     result, err := c.Pool.Exec(c.Context(), query, args...)
     if err != nil {
-        return 0, err
+        return 0, mapDatabaseError(err)
     }
 
     return result.RowsAffected(), nil
@@ -88,7 +144,7 @@ func (c *BlazeDatabaseClient) QueryRaw(query string, args ...interface{}) ([]map
 
     rows, err := c.Pool.Query(c.Context(), query, args...)
     if err != nil {
-        return nil, err
+        return nil, mapDatabaseError(err)
     }
     defer rows.Close()
 
@@ -109,7 +165,7 @@ func (c *BlazeDatabaseClient) QueryRaw(query string, args ...interface{}) ([]map
     }
 
     if err := rows.Err(); err != nil {
-        return nil, err
+        return nil, mapDatabaseError(err)
     }
 
     return results, nil
@@ -145,7 +201,7 @@ func (c *BlazeDatabaseClient) Transaction(queries []string, args [][]interface{}
     for i, query := range queries {
         result, execErr := tx.Exec(c.Context(), query, args[i]...)
         if execErr != nil {
-            err = fmt.Errorf("failed to execute query %d: %w", i, execErr)
+            err = fmt.Errorf("failed to execute query %d: %w", i, mapDatabaseError(execErr))
             return nil, err
         }
         results = append(results, result)
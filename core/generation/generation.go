@@ -11,6 +11,7 @@ import (
 	"github.com/rit3sh-x/blaze/core/generation/db"
 	"github.com/rit3sh-x/blaze/core/generation/hooks"
 	"github.com/rit3sh-x/blaze/core/generation/types"
+	"github.com/rit3sh-x/blaze/core/utils"
 )
 
 func Generate(schemaAST *ast.SchemaAST) error {
@@ -39,18 +40,8 @@ func GenerateTypes(schemaAST *ast.SchemaAST) error {
 
 	content.WriteString("package client\n\n")
 
-	for _, cls := range schemaAST.Classes {
-		for _, field := range cls.Attributes.Fields {
-			baseType := field.GetBaseType()
-			for _, scalarType := range constants.ScalarTypes {
-				if string(scalarType) == baseType {
-					if scalarType == constants.DATE || scalarType == constants.TIMESTAMP {
-						globalImports["time"] = true
-					}
-					break
-				}
-			}
-		}
+	for _, imp := range utils.SchemaGoImports(schemaAST) {
+		globalImports[imp] = true
 	}
 
 	if len(globalImports) > 0 {
@@ -80,6 +71,14 @@ func GenerateTypes(schemaAST *ast.SchemaAST) error {
 		}
 	}
 
+	if len(schemaAST.Composites) > 0 {
+		content.WriteString("// ==================== COMPOSITE TYPES ====================\n\n")
+		for _, compositeType := range schemaAST.GetCompositeOrder() {
+			compositeGen := types.NewCompositeTypeGenerator(compositeType, schemaAST)
+			content.WriteString(compositeGen.Generate())
+		}
+	}
+
 	if len(schemaAST.Classes) > 0 {
 		content.WriteString("// ==================== TYPES ====================\n\n")
 		for _, cls := range schemaAST.Classes {
@@ -90,6 +89,14 @@ func GenerateTypes(schemaAST *ast.SchemaAST) error {
 		}
 	}
 
+	if len(schemaAST.Views) > 0 {
+		content.WriteString("// ==================== VIEWS ====================\n\n")
+		for _, v := range schemaAST.GetViewOrder() {
+			viewGen := types.NewViewGenerator(v, schemaAST)
+			content.WriteString(viewGen.Generate())
+		}
+	}
+
 	content.WriteString("// ==================== COMPOSITE UNIQUE CONSTRAINT TYPES ====================\n\n")
 	for _, cls := range schemaAST.Classes {
 		classGen := types.NewClassGenerator(cls, schemaAST)
@@ -131,8 +138,17 @@ func GenerateHooks(schemaAST *ast.SchemaAST) error {
 
 	content.WriteString("package client\n\n")
 	content.WriteString("import (\n")
-	content.WriteString("\t\"time\"\n")
+	content.WriteString("\t\"context\"\n")
+	content.WriteString("\t\"errors\"\n")
 	content.WriteString("\t\"fmt\"\n")
+	content.WriteString("\t\"slices\"\n")
+	content.WriteString("\t\"github.com/jackc/pgx/v5\"\n")
+	content.WriteString("\t\"github.com/rit3sh-x/blaze/core/db\"\n")
+	for _, imp := range utils.HookGoImports(schemaAST) {
+		if imp != "context" && imp != "slices" {
+			content.WriteString(fmt.Sprintf("\t\"%s\"\n", imp))
+		}
+	}
 	content.WriteString(")\n\n")
 
 	content.WriteString(hooks.GenerateCoreTypes())
@@ -142,6 +158,9 @@ func GenerateHooks(schemaAST *ast.SchemaAST) error {
 	for i, cls := range schemaAST.Classes {
 		classNames[i] = cls.Name
 	}
+	for _, v := range schemaAST.GetViewOrder() {
+		classNames = append(classNames, v.Name)
+	}
 
 	content.WriteString("// ==================== PREDICATES ====================\n\n")
 	content.WriteString(hooks.GeneratePredicateVars(classNames))
@@ -161,6 +180,12 @@ func GenerateHooks(schemaAST *ast.SchemaAST) error {
 		}
 	}
 
+	for _, v := range schemaAST.GetViewOrder() {
+		content.WriteString(fmt.Sprintf("// ==================== %s PREDICATES ====================\n\n", strings.ToUpper(v.Name)))
+		content.WriteString(hooks.GenerateClassPredicates(utils.ViewAsClass(v, schemaAST), schemaAST))
+		content.WriteString("\n")
+	}
+
 	content.WriteString("// ==================== LOGICAL OPERATORS ====================\n\n")
 	content.WriteString(hooks.GenerateLogicalOperators())
 	content.WriteString("\n\n")
@@ -182,6 +207,10 @@ func GenerateHooks(schemaAST *ast.SchemaAST) error {
 		content.WriteString(hooks.GenerateCreateBuilder(cls, schemaAST))
 		content.WriteString("\n")
 
+		content.WriteString(fmt.Sprintf("// ==================== %s CREATE MANY ====================\n\n", strings.ToUpper(cls.Name)))
+		content.WriteString(hooks.GenerateCreateManyBuilder(cls, schemaAST))
+		content.WriteString("\n")
+
 		content.WriteString(fmt.Sprintf("// ==================== %s UPDATE ====================\n\n", strings.ToUpper(cls.Name)))
 		content.WriteString(hooks.GenerateUpdateBuilder(cls, schemaAST))
 		content.WriteString("\n")
@@ -190,6 +219,10 @@ func GenerateHooks(schemaAST *ast.SchemaAST) error {
 		content.WriteString(hooks.GenerateUpdateOneBuilder(cls, schemaAST))
 		content.WriteString("\n")
 
+		content.WriteString(fmt.Sprintf("// ==================== %s UPSERT ====================\n\n", strings.ToUpper(cls.Name)))
+		content.WriteString(hooks.GenerateUpsertBuilder(cls, schemaAST))
+		content.WriteString("\n")
+
 		content.WriteString(fmt.Sprintf("// ==================== %s DELETE ====================\n\n", strings.ToUpper(cls.Name)))
 		content.WriteString(hooks.GenerateDeleteBuilder(cls, schemaAST))
 		content.WriteString("\n")
@@ -199,6 +232,16 @@ func GenerateHooks(schemaAST *ast.SchemaAST) error {
 		content.WriteString("\n")
 	}
 
+	for _, v := range schemaAST.GetViewOrder() {
+		content.WriteString(fmt.Sprintf("// ==================== %s QUERY ====================\n\n", strings.ToUpper(v.Name)))
+		content.WriteString(hooks.GenerateQueryBuilder(utils.ViewAsClass(v, schemaAST), schemaAST))
+		content.WriteString("\n")
+
+		content.WriteString(fmt.Sprintf("// ==================== %s CLIENT ====================\n\n", strings.ToUpper(v.Name)))
+		content.WriteString(hooks.GenerateViewClient(v))
+		content.WriteString("\n")
+	}
+
 	if err := os.MkdirAll(constants.CLIENT_DIR, 0755); err != nil {
 		return fmt.Errorf("failed to create directory %s: %v", constants.CLIENT_DIR, err)
 	}
@@ -211,12 +254,36 @@ func GenerateHooks(schemaAST *ast.SchemaAST) error {
 }
 
 func GenerateDBUtils(schemaAST *ast.SchemaAST) error {
-	content := db.GenerateDBUtils()
+	var typeNames []string
+	enumNames := make([]string, 0, len(schemaAST.Enums))
+	for name := range schemaAST.Enums {
+		enumNames = append(enumNames, name)
+	}
+	sort.Strings(enumNames)
+	for _, name := range enumNames {
+		typeNames = append(typeNames, name, "_"+name)
+	}
+	domainNames := make([]string, 0, len(schemaAST.Domains))
+	for name := range schemaAST.Domains {
+		domainNames = append(domainNames, name)
+	}
+	sort.Strings(domainNames)
+	for _, name := range domainNames {
+		typeNames = append(typeNames, name, "_"+name)
+	}
+	for _, compositeType := range schemaAST.GetCompositeOrder() {
+		typeNames = append(typeNames, compositeType.Name, "_"+compositeType.Name)
+	}
+
+	content := db.GenerateDBUtils(typeNames)
 
 	classNames := []string{}
 	for _, cls := range schemaAST.Classes {
 		classNames = append(classNames, cls.Name)
 	}
+	for _, v := range schemaAST.GetViewOrder() {
+		classNames = append(classNames, v.Name)
+	}
 	content += db.GenerateClientAccessors(classNames)
 
 	if err := os.MkdirAll(constants.CLIENT_DIR, 0755); err != nil {
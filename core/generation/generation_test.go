@@ -0,0 +1,478 @@
+package generation
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/rit3sh-x/blaze/core/ast"
+	"github.com/rit3sh-x/blaze/core/constants"
+	"github.com/rit3sh-x/blaze/core/utils"
+)
+
+func TestGenerate(t *testing.T) {
+	tests := []struct {
+		name   string
+		schema string
+		file   string
+		want   []string
+		absent []string
+	}{
+		{
+			name: "updatedAt is left to the database trigger",
+			schema: `class Event {
+  id Int @primaryKey
+  name String
+  changedAt Timestamp @updatedAt
+  touchedAt TimestampTz @updatedAt
+  tagId Int?
+  tag Tag? @relation([tagId], [id])
+}
+class Tag {
+  id Int @primaryKey
+  label String
+  updatedAt Timestamp @updatedAt
+  events Event[]
+}`,
+			want: []string{
+				"type EventCreateInput struct",
+				"ChangedAt *time.Time",
+			},
+			absent: []string{"time.Now()"},
+		},
+		{
+			name: "computed fields are read only",
+			schema: `class Line {
+  id Int @primaryKey
+  qty Int
+  price Int
+  total Int @computed("qty * price")
+}`,
+			want: []string{
+				"func (c *LineCreate) SetQty(value int32) *LineCreate",
+				"func (line) TotalEQ(v int32) Predicate",
+				"&item.Total",
+			},
+			absent: []string{"SetTotal", "Total *int32"},
+		},
+		{
+			name: "temporal scalars map to time types",
+			schema: `class Shift {
+  id Int @primaryKey
+  startsAt TimestampTz
+  opensAt Time?
+  length Interval
+  breaks Interval[]
+}`,
+			want: []string{
+				"func (shift) StartsAtGT(v time.Time) Predicate",
+				"func (shift) OpensAtIsNull() Predicate",
+				"func (shift) LengthLTE(v time.Duration) Predicate",
+				"func (c *ShiftCreate) SetBreaks(value []time.Duration) *ShiftCreate",
+			},
+		},
+		{
+			name: "uuid scalar gets byte array predicates",
+			schema: `class Account {
+  id Uuid @primaryKey @default(uuidv7())
+  ownerId Uuid?
+  legacyId String @default(uuid())
+}`,
+			want: []string{
+				"func (account) IdEQ(v [16]byte) Predicate",
+				"func (account) OwnerIdIsNull() Predicate",
+				"func (account) LegacyIdContains(v string) Predicate",
+			},
+			absent: []string{"IdContains(v [16]byte)"},
+		},
+		{
+			name: "network, range and decimal predicates",
+			schema: `class Booking {
+  id Int @primaryKey
+  ip Inet
+  amount Decimal @db.Numeric(12,2)
+  seats Int4Range
+  during TsTzRange?
+}`,
+			want: []string{
+				`func (booking) IpContainedBy(v netip.Prefix) Predicate { return db.Op(db.Column("Booking", "ip"), "<<", v) }`,
+				"func (booking) AmountGT(v pgtype.Numeric) Predicate",
+				`func (booking) SeatsOverlaps(v pgtype.Range[pgtype.Int4]) Predicate { return db.Op(db.Column("Booking", "seats"), "&&", v) }`,
+				`func (booking) SeatsContains(v int32) Predicate { return db.Op(db.Column("Booking", "seats"), "@>", db.Cast(db.Value(v), "integer")) }`,
+				`func (booking) DuringContains(v time.Time) Predicate { return db.Op(db.Column("Booking", "during"), "@>", db.Cast(db.Value(v), "timestamptz")) }`,
+				"func (booking) DuringIsNull() Predicate",
+			},
+			absent: []string{"AmountGT(v float64)"},
+		},
+		{
+			name: "composite types and domains",
+			schema: `type Geo {
+  lat Float
+  lng Float
+}
+type Address {
+  street String
+  city String
+  geo Geo?
+}
+domain Email = String @check("VALUE ~ '@'")
+class Customer {
+  id Int @primaryKey
+  email Email
+  home Address
+  previous Address[]
+}`,
+			want: []string{
+				"func (customer) EmailContains(v string) Predicate",
+				"func (customer) HomeEQ(v Address) Predicate",
+				"func (customer) PreviousHas(v Address) Predicate",
+			},
+		},
+		{
+			name: "views get query-only clients",
+			schema: `class User {
+  id Int @primaryKey
+  email String
+  active Boolean
+}
+class Post {
+  id Int @primaryKey
+  authorId Int
+  title String
+}
+view ActiveUser {
+  id Int
+  email String
+} @@sql("SELECT \"id\", \"email\" FROM \"User\" WHERE \"active\"")
+view RecentUser {
+  id Int
+  email String
+  @@sql("SELECT \"id\", \"email\" FROM \"ActiveUser\"
+         WHERE \"id\" > 100")
+}
+materializedView UserStats {
+  userId Int
+  posts BigInt
+  @@unique([userId])
+  @@index([posts])
+  @@sql("SELECT \"authorId\" AS \"userId\", count(*) AS \"posts\" FROM \"Post\" GROUP BY \"authorId\"")
+}`,
+			want: []string{
+				"func (c *ActiveUserClient) Query() *ActiveUserQuery",
+				"func (activeuser) EmailContains(v string) Predicate",
+				`return c.db.RefreshMaterializedView(c.db.Context(), "UserStats", true)`,
+			},
+			absent: []string{"ActiveUserCreate", "ActiveUserUpdate", "UserStatsDelete", "func (c *ActiveUserClient) Refresh"},
+		},
+		{
+			name: "exclusion violations surface as a typed error",
+			schema: `class Booking {
+  id Int @primaryKey
+  roomId Int
+  during TsTzRange
+  @@exclude([roomId WITH =, during WITH &&])
+}`,
+			file: constants.UTIL_FILE,
+			want: []string{
+				"type ExclusionViolationError struct",
+				"pgErr.Code == constants.PG_EXCLUSION_VIOLATION",
+				"return &ExclusionViolationError{",
+			},
+		},
+		{
+			name: "queries compile to selectors and scan their rows",
+			schema: `class User {
+  id Int @primaryKey
+  email String
+}`,
+			want: []string{
+				`sel := db.Select("User", userColumns...).Columns(q.columns()...)`,
+				"func (q *UserQuery) Find() ([]*User, error)",
+				"results, err := q.find(q.selector().Limit(1))",
+				`return nil, &NotFoundError{Table: "User"}`,
+				"query, args, err := q.selector().CountSQL()",
+			},
+		},
+		{
+			name: "create only requires fields without defaults",
+			schema: `domain Role = String @default("member")
+class User {
+  id Int @primaryKey @default(autoincrement())
+  email String
+  nickname String?
+  role Role
+  joinedAt Timestamp @default(now())
+  updatedAt Timestamp @updatedAt
+}`,
+			want: []string{
+				`if missing := db.MissingColumns(c.data, []string{"email"}); len(missing) > 0 {`,
+				`return nil, fmt.Errorf("cannot create User: missing required fields %v", missing)`,
+				`query, args, err := db.Insert("User", userColumns, c.data).Returning(userColumns...).SQL()`,
+				"item, err := scanUser(q.QueryRow(ctx, query, args...))",
+				"func (c *UserCreate) SetNickname(value *string) *UserCreate",
+			},
+		},
+		{
+			name: "updates and deletes are guarded and keyed by unique inputs",
+			schema: `class Member {
+  id Int @primaryKey
+  email String @unique
+  orgId Int
+  handle String
+  @@unique([orgId, handle])
+}`,
+			want: []string{
+				`return 0, fmt.Errorf("refusing to update every Member row without a predicate, call All() to confirm")`,
+				`return 0, fmt.Errorf("refusing to delete every Member row without a predicate, call All() to confirm")`,
+				`predicates = append(predicates, db.EQ(db.Column("Member", "email"), *w.Email))`,
+				`predicates = append(predicates, db.And(db.EQ(db.Column("Member", "handle"), w.HandleOrgId.Handle), db.EQ(db.Column("Member", "orgId"), w.HandleOrgId.OrgId)))`,
+				`query, args, err = db.Update("Member", memberColumns, u.data).Where(where).Returning(memberColumns...).SQL()`,
+				"if d.where != nil && tag.RowsAffected() == 0 {",
+			},
+			absent: []string{"func (u *MemberUpdate) SetId("},
+		},
+		{
+			name: "upserts target unique keys by typed methods",
+			schema: `class Member {
+  id Int @primaryKey
+  email String @unique
+  orgId Int
+  handle String
+  @@unique([orgId, handle])
+}`,
+			want: []string{
+				"func (u *MemberUpsert) OnConflictId() *MemberUpsert",
+				"func (u *MemberUpsert) OnConflictEmail() *MemberUpsert",
+				"func (u *MemberUpsert) OnConflictHandleOrgId() *MemberUpsert {\n\tu.target = []string{\"handle\", \"orgId\"}",
+				`query, args, err := db.Insert("Member", memberColumns, values).Returning(memberColumns...).OnConflict(target, u.update).SQL()`,
+			},
+			absent: []string{"OnConstraint", "len(u.update) == 0"},
+		},
+		{
+			name: "create many casts user types and json for the insert fallback",
+			schema: `enum Level {
+  Low
+  High
+}
+class Reading {
+  id Int @primaryKey @default(autoincrement())
+  level Level
+  history Level[]
+  payload Json?
+  note String
+}`,
+			want: []string{
+				"type ReadingCreateInput struct {\n\tId *int32\n\tLevel Level\n\tHistory []Level\n\tPayload *",
+				`var readingColumnTypes = map[string]string{"level": "\"Level\"", "history": "\"Level\"[]", "payload": "jsonb"}`,
+				`bulk := db.InsertMany("Reading", readingColumns, readingColumnTypes, rows)`,
+				"count, err := m.bulk().Exec(m.client.db.Context(), m.client.db.Pool)",
+				"err := m.bulk().Returning(readingColumns...).Query(m.client.db.Context(), m.client.db.Pool, func(row db.Row) error {",
+			},
+		},
+		{
+			name: "composite key includes compare rows against unnested key arrays",
+			schema: `class Tenant {
+  id String @primaryKey @default(uuid())
+  members Member[]
+}
+class Member {
+  id Int @primaryKey
+  tenantId String
+  handle String @db.VarChar(32)
+  tenant Tenant @relation([tenantId], [id])
+  badges Badge[]
+  @@unique([tenantId, handle])
+}
+class Badge {
+  id Int @primaryKey
+  memberTenantId String?
+  memberHandle String? @db.VarChar(32)
+  member Member? @relation([memberTenantId, memberHandle], [tenantId, handle])
+}`,
+			want: []string{
+				"tenantIdKeys := make([]string, 0, len(items))",
+				"memberTenantIdKeys = append(memberTenantIdKeys, *item.MemberTenantId)",
+				`filter := db.InUnnest([]Predicate{db.Column("Badge", "memberTenantId"), db.Column("Badge", "memberHandle")}, db.Cast(db.Value(tenantIdKeys), "text[]"), db.Cast(db.Value(handleKeys), "VARCHAR(32)[]"))`,
+				`filter := db.InUnnest([]Predicate{db.Column("Member", "tenantId"), db.Column("Member", "handle")}, db.Cast(db.Value(memberTenantIdKeys), "uuid[]"), db.Cast(db.Value(memberHandleKeys), "VARCHAR(32)[]"))`,
+			},
+			absent: []string{"db.Or(conditions...)"},
+		},
+		{
+			name: "relations are tracked on one loaded-state struct",
+			schema: `class Author {
+  id Int @primaryKey
+  profile Profile?
+  posts Post[]
+  comments Comment[]
+}
+class Profile {
+  id Int @primaryKey
+  authorId Int @unique
+  author Author @relation([authorId], [id])
+}
+class Post {
+  id Int @primaryKey
+  authorId Int
+  author Author @relation([authorId], [id])
+}
+class Comment {
+  id Int @primaryKey
+  authorId Int
+  author Author @relation([authorId], [id])
+}`,
+			file: constants.TYPES_FILE,
+			want: []string{
+				"type Author struct {\n\tId int32\n\tRelations AuthorRelations\n}",
+				"type AuthorRelations struct {\n\tProfile *Profile\n\tPosts []*Post\n\tComments []*Comment\n\tloaded map[string]bool\n}",
+				"func (r AuthorRelations) ProfileLoaded() bool {\n\treturn r.loaded[\"profile\"]\n}",
+				"func (r AuthorRelations) PostsLoaded() bool",
+				"func (r AuthorRelations) CommentsLoaded() bool",
+			},
+			absent: []string{"AuthorWith", "PostWith"},
+		},
+		{
+			name: "includes mark relations as loaded",
+			schema: `class Author {
+  id Int @primaryKey
+  posts Post[]
+}
+class Post {
+  id Int @primaryKey
+  authorId Int
+  author Author @relation([authorId], [id])
+}`,
+			want: []string{
+				"item.Relations.markLoaded(\"posts\")\n\t\t\t\titem.Relations.Posts = grouped[item.Id]",
+				"item.Relations.markLoaded(\"author\")",
+			},
+		},
+		{
+			name: "nested creates and connects run in one transaction",
+			schema: `class Author {
+  id Int @primaryKey @default(autoincrement())
+  email String @unique
+  posts Post[]
+}
+class Post {
+  id Int @primaryKey @default(autoincrement())
+  title String
+  authorId Int
+  author Author @relation([authorId], [id])
+}`,
+			want: []string{
+				"func (c *AuthorCreate) WithPosts(creates ...*PostCreate) *AuthorCreate {",
+				"\t\t\tcreate.data[\"authorId\"] = item.Id\n\t\t\tif _, err := create.save(ctx, q); err != nil {",
+				"func (c *AuthorCreate) ConnectPosts(wheres ...PostWhereUniqueInput) *AuthorCreate {",
+				"data := map[string]interface{}{\"authorId\": item.Id}",
+				"func (c *PostCreate) WithAuthor(create *AuthorCreate) *PostCreate {",
+				"func (c *PostCreate) ConnectAuthor(where AuthorWhereUniqueInput) *PostCreate {",
+				"c.data[\"authorId\"] = related.Id",
+				"err := db.Transaction(ctx, c.client.db.Pool, func(q db.Querier) error {",
+				"\tfor _, parent := range c.parents {\n\t\tif err := parent(ctx, q); err != nil {\n\t\t\treturn nil, err\n\t\t}\n\t}\n\tif missing := db.MissingColumns(c.data, []string{\"title\", \"authorId\"}); len(missing) > 0 {",
+			},
+		},
+		{
+			name: "queries project selected columns and keep include keys",
+			schema: `class Author {
+  id Int @primaryKey
+  email String
+  bio Json?
+  posts Post[]
+}
+class Post {
+  id Int @primaryKey
+  body Bytes
+  authorId Int
+  author Author @relation([authorId], [id])
+}`,
+			want: []string{
+				"func (AuthorFieldsType) Email() string {",
+				"func (q *AuthorQuery) Select(fields ...string) *AuthorQuery {",
+				"func (q *AuthorQuery) Omit(fields ...string) *AuthorQuery {",
+				"\tif len(q.fields) == 0 && len(q.omitted) == 0 {\n\t\treturn authorColumns\n\t}",
+				"for _, include := range q.include {\n\t\trequired = append(required, include.columns...)\n\t}",
+				`sel := db.Select("Author", authorColumns...).Columns(q.columns()...)`,
+				"item, err := scanAuthorColumns(rows, columns)",
+				"\t\tcase \"bio\":\n\t\t\ttargets[i] = &item.Bio",
+				"\t\tcolumns: []string{\"id\"},",
+				"query.required = append(query.required, \"authorId\")",
+				"func (q *PostQuery) Scan(into interface{}) error {",
+				"if err := db.ScanRows(rows, into); err != nil {",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			file := tt.file
+			if file == "" {
+				file = constants.HOOKS_FILE
+			}
+			generated := generateClient(t, tt.schema, file)
+			for _, fragment := range tt.want {
+				if !strings.Contains(generated, fragment) {
+					t.Errorf("generated %s is missing %q", file, fragment)
+				}
+			}
+			for _, fragment := range tt.absent {
+				if strings.Contains(generated, fragment) {
+					t.Errorf("generated %s contains %q", file, fragment)
+				}
+			}
+		})
+	}
+}
+
+func generateClient(t *testing.T, source string, file string) string {
+	t.Helper()
+
+	schemaPath := filepath.Join(t.TempDir(), "schema.blaze")
+	if err := os.WriteFile(schemaPath, []byte(source), 0644); err != nil {
+		t.Fatalf("writing schema: %v", err)
+	}
+	content, err := utils.ReadAndSeparateSchema(schemaPath)
+	if err != nil {
+		t.Fatalf("ReadAndSeparateSchema() error: %v", err)
+	}
+	schema, err := ast.BuildSchemaAST(content)
+	if err != nil {
+		t.Fatalf("BuildSchemaAST() error: %v", err)
+	}
+
+	packageDir, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.MkdirAll("testdata", 0755); err != nil {
+		t.Fatal(err)
+	}
+	outputDir, err := os.MkdirTemp("testdata", "client")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		os.RemoveAll(filepath.Join(packageDir, outputDir))
+		os.Remove(filepath.Join(packageDir, "testdata"))
+	})
+
+	t.Chdir(outputDir)
+	if err := Generate(schema); err != nil {
+		t.Fatalf("Generate() error: %v", err)
+	}
+	generated, err := os.ReadFile(file)
+	if err != nil {
+		t.Fatalf("reading generated %s: %v", file, err)
+	}
+
+	if !testing.Short() {
+		vet := exec.Command("go", "vet", "./"+filepath.ToSlash(filepath.Join(outputDir, constants.CLIENT_DIR)))
+		vet.Dir = packageDir
+		if output, err := vet.CombinedOutput(); err != nil {
+			t.Fatalf("generated client does not compile: %v\n%s", err, output)
+		}
+	}
+
+	return string(generated)
+}
@@ -203,7 +203,7 @@ func (cg *ClientGenerator) generateClassCreate(cls *class.Class) {
 	cg.builder.WriteString("}\n\n")
 
 	for _, fld := range cls.Attributes.Fields {
-		if fld.IsObject() {
+		if fld.IsObject() || fld.IsComputed() {
 			continue
 		}
 
@@ -289,7 +289,7 @@ func (cg *ClientGenerator) generateClassUpdate(cls *class.Class) {
 	cg.builder.WriteString("}\n\n")
 
 	for _, fld := range cls.Attributes.Fields {
-		if fld.IsObject() || fld.IsPrimaryKey() {
+		if fld.IsObject() || fld.IsPrimaryKey() || fld.IsComputed() {
 			continue
 		}
 
@@ -303,8 +303,8 @@ func (cg *ClientGenerator) generateClassUpdate(cls *class.Class) {
 		cg.builder.WriteString("\treturn u\n")
 		cg.builder.WriteString("}\n\n")
 
-		if fld.IsScalar() {
-			scalarType := fld.GetBaseType()
+		if fld.IsScalar() || fld.IsDomain() {
+			scalarType := utils.ResolveScalarType(fld.GetBaseType(), cg.ast)
 			switch constants.ScalarType(scalarType) {
 			case constants.INT, constants.BIGINT, constants.SMALLINT, constants.FLOAT, constants.NUMERIC:
 				cg.builder.WriteString(fmt.Sprintf("func (u *%sUpdate) Add%s(v %s) *%sUpdate {\n",
@@ -359,7 +359,7 @@ func (cg *ClientGenerator) generateClassUpdateOne(cls *class.Class) {
 	cg.builder.WriteString("}\n\n")
 
 	for _, fld := range cls.Attributes.Fields {
-		if fld.IsObject() || fld.IsPrimaryKey() {
+		if fld.IsObject() || fld.IsPrimaryKey() || fld.IsComputed() {
 			continue
 		}
 
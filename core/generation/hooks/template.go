@@ -8,6 +8,7 @@ import (
 	"github.com/rit3sh-x/blaze/core/ast"
 	"github.com/rit3sh-x/blaze/core/ast/class"
 	"github.com/rit3sh-x/blaze/core/ast/field"
+	"github.com/rit3sh-x/blaze/core/ast/view"
 	"github.com/rit3sh-x/blaze/core/constants"
 	"github.com/rit3sh-x/blaze/core/utils"
 	"golang.org/x/text/cases"
@@ -25,7 +26,7 @@ func GenerateModelPredicates(cls *class.Class, ast *ast.SchemaAST) string {
 func GenerateCoreTypes() string {
 	return `// ==================== CORE TYPES ====================
 
-type Predicate func(interface{})
+type Predicate = db.Expr
 
 type Order struct {
     field string
@@ -68,208 +69,309 @@ func GenerateClassPredicates(cls *class.Class, ast *ast.SchemaAST) string {
 	lowerClass := cases.Lower(language.English).String(cls.Name)
 
 	for _, fld := range cls.Attributes.Fields {
-		if fld.IsScalar() {
-			scalarType := fld.GetBaseType()
+		column := fmt.Sprintf("db.Column(%q, %q)", cls.Name, fld.GetName())
+
+		if fld.IsArray() && !fld.IsObject() {
+			res.WriteString(generateArrayPredicates(lowerClass, column, fld, ast))
+			continue
+		}
+
+		if fld.IsScalar() || fld.IsDomain() {
+			scalarType := utils.ResolveScalarType(fld.GetBaseType(), ast)
 			switch constants.ScalarType(scalarType) {
-			case constants.STRING:
-				if fld.AttributeDefinition.DefaultValue != nil && fld.AttributeDefinition.DefaultValue.Value == constants.DEFAULT_UUID_CALLBACK {
-					res.WriteString(generateUUIDPredicates(lowerClass, fld, ast))
-				} else {
-					res.WriteString(generateStringPredicates(lowerClass, fld, ast))
-				}
-			case constants.CHAR:
-				res.WriteString(generateStringPredicates(lowerClass, fld, ast))
+			case constants.STRING, constants.CHAR:
+				res.WriteString(generateStringPredicates(lowerClass, column, fld, ast))
+			case constants.UUID:
+				res.WriteString(generateUUIDPredicates(lowerClass, column, fld, ast))
 			case constants.INT, constants.BIGINT, constants.SMALLINT:
-				res.WriteString(generateIntegerPredicates(lowerClass, fld, ast))
-			case constants.FLOAT, constants.NUMERIC:
-				res.WriteString(generateFloatPredicates(lowerClass, fld, ast))
+				res.WriteString(generateIntegerPredicates(lowerClass, column, fld, ast))
+			case constants.FLOAT, constants.NUMERIC, constants.DECIMAL:
+				res.WriteString(generateFloatPredicates(lowerClass, column, fld, ast))
+			case constants.INET, constants.CIDR:
+				res.WriteString(generateNetworkPredicates(lowerClass, column, fld, ast))
+			case constants.INT4RANGE, constants.TSTZRANGE:
+				res.WriteString(generateRangePredicates(lowerClass, column, fld, ast, scalarType))
 			case constants.BOOLEAN:
-				res.WriteString(generateBooleanPredicates(lowerClass, fld, ast))
-			case constants.DATE, constants.TIMESTAMP:
-				res.WriteString(generateDateTimePredicates(lowerClass, fld, ast))
+				res.WriteString(generateBooleanPredicates(lowerClass, column, fld, ast))
+			case constants.DATE, constants.TIMESTAMP, constants.TIMESTAMPTZ, constants.TIME, constants.INTERVAL:
+				res.WriteString(generateDateTimePredicates(lowerClass, column, fld, ast))
 			case constants.JSON:
-				res.WriteString(generateJsonPredicates(lowerClass, fld))
+				res.WriteString(generateJsonPredicates(lowerClass, column, fld))
 			case constants.BYTES:
-				res.WriteString(generateBytesPredicates(lowerClass, fld, ast))
+				res.WriteString(generateBytesPredicates(lowerClass, column, fld, ast))
 			}
 		} else if fld.IsEnum() {
-			res.WriteString(generateEnumPredicates(lowerClass, fld, ast))
+			res.WriteString(generateEnumPredicates(lowerClass, column, fld, ast))
+		} else if fld.IsComposite() {
+			res.WriteString(generateCompositePredicates(lowerClass, column, fld, ast))
 		} else if fld.IsObject() {
-			baseType := fld.GetBaseType()
-			if utils.GetScalarType(baseType) == "" && ast.GetEnumByName(baseType) == nil {
-				res.WriteString(generateRelationPredicates(lowerClass, fld))
-			}
+			res.WriteString(generateRelationPredicates(lowerClass, cls, fld, ast))
 		}
+	}
 
-		if fld.IsArray() {
-			res.WriteString(generateArrayPredicates(lowerClass, fld))
-		}
+	return res.String()
+}
+
+func generateEqualityPredicates(lowerClass string, column string, fld *field.Field, goType string) string {
+	var res strings.Builder
+	fieldName := utils.ToExportedName(fld.GetName())
+
+	res.WriteString(fmt.Sprintf("func (%s) %sEQ(v %s) Predicate { return db.EQ(%s, v) }\n", lowerClass, fieldName, goType, column))
+	if fld.IsOptional() {
+		res.WriteString(fmt.Sprintf("func (%s) %sNEQ(v %s) Predicate { return db.IsDistinctFrom(%s, v) }\n", lowerClass, fieldName, goType, column))
+	} else {
+		res.WriteString(fmt.Sprintf("func (%s) %sNEQ(v %s) Predicate { return db.NEQ(%s, v) }\n", lowerClass, fieldName, goType, column))
 	}
 
 	return res.String()
 }
 
-func generateUUIDPredicates(lowerClass string, fld *field.Field, ast *ast.SchemaAST) string {
+func generateMembershipPredicates(lowerClass string, column string, fld *field.Field, goType string) string {
 	var res strings.Builder
 	fieldName := utils.ToExportedName(fld.GetName())
-	goType := strings.TrimPrefix(utils.GetGoType(fld, ast), "*")
 
-	res.WriteString(fmt.Sprintf("func (%s) %sEQ(v %s) Predicate { return func(interface{}) {} }\n", lowerClass, fieldName, goType))
-	res.WriteString(fmt.Sprintf("func (%s) %sNEQ(v %s) Predicate { return func(interface{}) {} }\n", lowerClass, fieldName, goType))
-	res.WriteString(fmt.Sprintf("func (%s) %sIn(vs ...%s) Predicate { return func(interface{}) {} }\n", lowerClass, fieldName, goType))
-	res.WriteString(fmt.Sprintf("func (%s) %sNotIn(vs ...%s) Predicate { return func(interface{}) {} }\n", lowerClass, fieldName, goType))
+	res.WriteString(fmt.Sprintf("func (%s) %sIn(vs ...%s) Predicate { return db.In(%s, vs) }\n", lowerClass, fieldName, goType, column))
+	res.WriteString(fmt.Sprintf("func (%s) %sNotIn(vs ...%s) Predicate { return db.NotIn(%s, vs) }\n", lowerClass, fieldName, goType, column))
+
+	return res.String()
+}
+
+func generateNullPredicates(lowerClass string, column string, fld *field.Field) string {
+	var res strings.Builder
+	fieldName := utils.ToExportedName(fld.GetName())
 
 	if fld.IsOptional() {
-		res.WriteString(fmt.Sprintf("func (%s) %sIsNull() Predicate { return func(interface{}) {} }\n", lowerClass, fieldName))
-		res.WriteString(fmt.Sprintf("func (%s) %sIsNotNull() Predicate { return func(interface{}) {} }\n", lowerClass, fieldName))
+		res.WriteString(fmt.Sprintf("func (%s) %sIsNull() Predicate { return db.IsNull(%s) }\n", lowerClass, fieldName, column))
+		res.WriteString(fmt.Sprintf("func (%s) %sIsNotNull() Predicate { return db.IsNotNull(%s) }\n", lowerClass, fieldName, column))
 	}
 
 	return res.String()
 }
 
-func generateStringPredicates(lowerClass string, fld *field.Field, ast *ast.SchemaAST) string {
+func generateUUIDPredicates(lowerClass string, column string, fld *field.Field, ast *ast.SchemaAST) string {
 	var res strings.Builder
-	fieldName := utils.ToExportedName(fld.GetName())
 	goType := strings.TrimPrefix(utils.GetGoType(fld, ast), "*")
 
-	res.WriteString(fmt.Sprintf("func (%s) %sEQ(v %s) Predicate { return func(interface{}) {} }\n", lowerClass, fieldName, goType))
-	res.WriteString(fmt.Sprintf("func (%s) %sNEQ(v %s) Predicate { return func(interface{}) {} }\n", lowerClass, fieldName, goType))
-	res.WriteString(fmt.Sprintf("func (%s) %sContains(v %s) Predicate { return func(interface{}) {} }\n", lowerClass, fieldName, goType))
-	res.WriteString(fmt.Sprintf("func (%s) %sHasPrefix(v %s) Predicate { return func(interface{}) {} }\n", lowerClass, fieldName, goType))
-	res.WriteString(fmt.Sprintf("func (%s) %sHasSuffix(v %s) Predicate { return func(interface{}) {} }\n", lowerClass, fieldName, goType))
-	res.WriteString(fmt.Sprintf("func (%s) %sIn(vs ...%s) Predicate { return func(interface{}) {} }\n", lowerClass, fieldName, goType))
-	res.WriteString(fmt.Sprintf("func (%s) %sNotIn(vs ...%s) Predicate { return func(interface{}) {} }\n", lowerClass, fieldName, goType))
+	res.WriteString(generateEqualityPredicates(lowerClass, column, fld, goType))
+	res.WriteString(generateMembershipPredicates(lowerClass, column, fld, goType))
+	res.WriteString(generateNullPredicates(lowerClass, column, fld))
 
-	if fld.IsOptional() {
-		res.WriteString(fmt.Sprintf("func (%s) %sIsNull() Predicate { return func(interface{}) {} }\n", lowerClass, fieldName))
-		res.WriteString(fmt.Sprintf("func (%s) %sIsNotNull() Predicate { return func(interface{}) {} }\n", lowerClass, fieldName))
+	return res.String()
+}
+
+func generateStringPredicates(lowerClass string, column string, fld *field.Field, ast *ast.SchemaAST) string {
+	var res strings.Builder
+	fieldName := utils.ToExportedName(fld.GetName())
+	goType := strings.TrimPrefix(utils.GetGoType(fld, ast), "*")
+
+	value := "v"
+	if goType != "string" {
+		value = "string(v)"
 	}
 
+	res.WriteString(generateEqualityPredicates(lowerClass, column, fld, goType))
+	res.WriteString(fmt.Sprintf("func (%s) %sContains(v %s) Predicate { return db.Contains(%s, %s) }\n", lowerClass, fieldName, goType, column, value))
+	res.WriteString(fmt.Sprintf("func (%s) %sHasPrefix(v %s) Predicate { return db.HasPrefix(%s, %s) }\n", lowerClass, fieldName, goType, column, value))
+	res.WriteString(fmt.Sprintf("func (%s) %sHasSuffix(v %s) Predicate { return db.HasSuffix(%s, %s) }\n", lowerClass, fieldName, goType, column, value))
+	res.WriteString(generateMembershipPredicates(lowerClass, column, fld, goType))
+	res.WriteString(generateNullPredicates(lowerClass, column, fld))
+
 	return res.String()
 }
 
-func generateIntegerPredicates(lowerClass string, fld *field.Field, ast *ast.SchemaAST) string {
+func generateIntegerPredicates(lowerClass string, column string, fld *field.Field, ast *ast.SchemaAST) string {
 	var res strings.Builder
 	fieldName := utils.ToExportedName(fld.GetName())
 	goType := strings.TrimPrefix(utils.GetGoType(fld, ast), "*")
 
-	res.WriteString(fmt.Sprintf("func (%s) %sEQ(v %s) Predicate { return func(interface{}) {} }\n", lowerClass, fieldName, goType))
-	res.WriteString(fmt.Sprintf("func (%s) %sNEQ(v %s) Predicate { return func(interface{}) {} }\n", lowerClass, fieldName, goType))
-	res.WriteString(fmt.Sprintf("func (%s) %sGT(v %s) Predicate { return func(interface{}) {} }\n", lowerClass, fieldName, goType))
-	res.WriteString(fmt.Sprintf("func (%s) %sGTE(v %s) Predicate { return func(interface{}) {} }\n", lowerClass, fieldName, goType))
-	res.WriteString(fmt.Sprintf("func (%s) %sLT(v %s) Predicate { return func(interface{}) {} }\n", lowerClass, fieldName, goType))
-	res.WriteString(fmt.Sprintf("func (%s) %sLTE(v %s) Predicate { return func(interface{}) {} }\n", lowerClass, fieldName, goType))
-	res.WriteString(fmt.Sprintf("func (%s) %sIn(vs ...%s) Predicate { return func(interface{}) {} }\n", lowerClass, fieldName, goType))
-	res.WriteString(fmt.Sprintf("func (%s) %sNotIn(vs ...%s) Predicate { return func(interface{}) {} }\n", lowerClass, fieldName, goType))
+	res.WriteString(generateEqualityPredicates(lowerClass, column, fld, goType))
+	res.WriteString(fmt.Sprintf("func (%s) %sGT(v %s) Predicate { return db.GT(%s, v) }\n", lowerClass, fieldName, goType, column))
+	res.WriteString(fmt.Sprintf("func (%s) %sGTE(v %s) Predicate { return db.GTE(%s, v) }\n", lowerClass, fieldName, goType, column))
+	res.WriteString(fmt.Sprintf("func (%s) %sLT(v %s) Predicate { return db.LT(%s, v) }\n", lowerClass, fieldName, goType, column))
+	res.WriteString(fmt.Sprintf("func (%s) %sLTE(v %s) Predicate { return db.LTE(%s, v) }\n", lowerClass, fieldName, goType, column))
+	res.WriteString(generateMembershipPredicates(lowerClass, column, fld, goType))
+	res.WriteString(generateNullPredicates(lowerClass, column, fld))
 
-	if fld.IsOptional() {
-		res.WriteString(fmt.Sprintf("func (%s) %sIsNull() Predicate { return func(interface{}) {} }\n", lowerClass, fieldName))
-		res.WriteString(fmt.Sprintf("func (%s) %sIsNotNull() Predicate { return func(interface{}) {} }\n", lowerClass, fieldName))
-	}
+	return res.String()
+}
+
+func generateFloatPredicates(lowerClass string, column string, fld *field.Field, ast *ast.SchemaAST) string {
+	return generateIntegerPredicates(lowerClass, column, fld, ast)
+}
+
+func generateBooleanPredicates(lowerClass string, column string, fld *field.Field, ast *ast.SchemaAST) string {
+	var res strings.Builder
+	goType := strings.TrimPrefix(utils.GetGoType(fld, ast), "*")
+
+	res.WriteString(generateEqualityPredicates(lowerClass, column, fld, goType))
+	res.WriteString(generateNullPredicates(lowerClass, column, fld))
 
 	return res.String()
 }
 
-func generateFloatPredicates(lowerClass string, fld *field.Field, ast *ast.SchemaAST) string {
-	return generateIntegerPredicates(lowerClass, fld, ast)
+func generateDateTimePredicates(lowerClass string, column string, fld *field.Field, ast *ast.SchemaAST) string {
+	return generateIntegerPredicates(lowerClass, column, fld, ast)
 }
 
-func generateBooleanPredicates(lowerClass string, fld *field.Field, ast *ast.SchemaAST) string {
+func generateNetworkPredicates(lowerClass string, column string, fld *field.Field, ast *ast.SchemaAST) string {
 	var res strings.Builder
 	fieldName := utils.ToExportedName(fld.GetName())
 	goType := strings.TrimPrefix(utils.GetGoType(fld, ast), "*")
 
-	res.WriteString(fmt.Sprintf("func (%s) %sEQ(v %s) Predicate { return func(interface{}) {} }\n", lowerClass, fieldName, goType))
-	res.WriteString(fmt.Sprintf("func (%s) %sNEQ(v %s) Predicate { return func(interface{}) {} }\n", lowerClass, fieldName, goType))
+	res.WriteString(generateEqualityPredicates(lowerClass, column, fld, goType))
+	res.WriteString(generateMembershipPredicates(lowerClass, column, fld, goType))
+	res.WriteString(fmt.Sprintf("func (%s) %sContains(v %s) Predicate { return db.Op(%s, \">>\", v) }\n", lowerClass, fieldName, goType, column))
+	res.WriteString(fmt.Sprintf("func (%s) %sContainsOrEquals(v %s) Predicate { return db.Op(%s, \">>=\", v) }\n", lowerClass, fieldName, goType, column))
+	res.WriteString(fmt.Sprintf("func (%s) %sContainedBy(v %s) Predicate { return db.Op(%s, \"<<\", v) }\n", lowerClass, fieldName, goType, column))
+	res.WriteString(fmt.Sprintf("func (%s) %sContainedByOrEquals(v %s) Predicate { return db.Op(%s, \"<<=\", v) }\n", lowerClass, fieldName, goType, column))
+	res.WriteString(fmt.Sprintf("func (%s) %sOverlaps(v %s) Predicate { return db.Op(%s, \"&&\", v) }\n", lowerClass, fieldName, goType, column))
+	res.WriteString(generateNullPredicates(lowerClass, column, fld))
 
-	if fld.IsOptional() {
-		res.WriteString(fmt.Sprintf("func (%s) %sIsNull() Predicate { return func(interface{}) {} }\n", lowerClass, fieldName))
-		res.WriteString(fmt.Sprintf("func (%s) %sIsNotNull() Predicate { return func(interface{}) {} }\n", lowerClass, fieldName))
+	return res.String()
+}
+
+func generateRangePredicates(lowerClass string, column string, fld *field.Field, ast *ast.SchemaAST, scalarType string) string {
+	var res strings.Builder
+	fieldName := utils.ToExportedName(fld.GetName())
+	goType := strings.TrimPrefix(utils.GetGoType(fld, ast), "*")
+	elementType := utils.RangeElementGoType(scalarType)
+	elementCast := "integer"
+	if constants.ScalarType(scalarType) == constants.TSTZRANGE {
+		elementCast = "timestamptz"
 	}
 
+	res.WriteString(generateEqualityPredicates(lowerClass, column, fld, goType))
+	res.WriteString(fmt.Sprintf("func (%s) %sOverlaps(v %s) Predicate { return db.Op(%s, \"&&\", v) }\n", lowerClass, fieldName, goType, column))
+	res.WriteString(fmt.Sprintf("func (%s) %sContains(v %s) Predicate { return db.Op(%s, \"@>\", db.Cast(db.Value(v), %q)) }\n", lowerClass, fieldName, elementType, column, elementCast))
+	res.WriteString(fmt.Sprintf("func (%s) %sContainsRange(v %s) Predicate { return db.Op(%s, \"@>\", v) }\n", lowerClass, fieldName, goType, column))
+	res.WriteString(fmt.Sprintf("func (%s) %sContainedBy(v %s) Predicate { return db.Op(%s, \"<@\", v) }\n", lowerClass, fieldName, goType, column))
+	res.WriteString(fmt.Sprintf("func (%s) %sIsEmpty() Predicate { return db.Func(\"isempty\", %s) }\n", lowerClass, fieldName, column))
+	res.WriteString(generateNullPredicates(lowerClass, column, fld))
+
 	return res.String()
 }
 
-func generateDateTimePredicates(lowerClass string, fld *field.Field, ast *ast.SchemaAST) string {
-	return generateIntegerPredicates(lowerClass, fld, ast)
+func generateEnumPredicates(lowerClass string, column string, fld *field.Field, ast *ast.SchemaAST) string {
+	var res strings.Builder
+	goType := strings.TrimPrefix(utils.GetGoType(fld, ast), "*")
+
+	res.WriteString(generateEqualityPredicates(lowerClass, column, fld, goType))
+	res.WriteString(generateMembershipPredicates(lowerClass, column, fld, goType))
+	res.WriteString(generateNullPredicates(lowerClass, column, fld))
+
+	return res.String()
 }
 
-func generateEnumPredicates(lowerClass string, fld *field.Field, ast *ast.SchemaAST) string {
+func generateCompositePredicates(lowerClass string, column string, fld *field.Field, ast *ast.SchemaAST) string {
 	var res strings.Builder
-	fieldName := utils.ToExportedName(fld.GetName())
 	goType := strings.TrimPrefix(utils.GetGoType(fld, ast), "*")
 
-	res.WriteString(fmt.Sprintf("func (%s) %sEQ(v %s) Predicate { return func(interface{}) {} }\n", lowerClass, fieldName, goType))
-	res.WriteString(fmt.Sprintf("func (%s) %sNEQ(v %s) Predicate { return func(interface{}) {} }\n", lowerClass, fieldName, goType))
-	res.WriteString(fmt.Sprintf("func (%s) %sIn(vs ...%s) Predicate { return func(interface{}) {} }\n", lowerClass, fieldName, goType))
-	res.WriteString(fmt.Sprintf("func (%s) %sNotIn(vs ...%s) Predicate { return func(interface{}) {} }\n", lowerClass, fieldName, goType))
-
-	if fld.IsOptional() {
-		res.WriteString(fmt.Sprintf("func (%s) %sIsNull() Predicate { return func(interface{}) {} }\n", lowerClass, fieldName))
-		res.WriteString(fmt.Sprintf("func (%s) %sIsNotNull() Predicate { return func(interface{}) {} }\n", lowerClass, fieldName))
-	}
+	res.WriteString(generateEqualityPredicates(lowerClass, column, fld, goType))
+	res.WriteString(generateNullPredicates(lowerClass, column, fld))
 
 	return res.String()
 }
 
-func generateRelationPredicates(lowerClass string, fld *field.Field) string {
+func generateRelationPredicates(lowerClass string, cls *class.Class, fld *field.Field, ast *ast.SchemaAST) string {
 	var res strings.Builder
 	fieldName := utils.ToExportedName(fld.GetName())
 
-	res.WriteString(fmt.Sprintf("func (%s) Has%s() Predicate { return func(interface{}) {} }\n", lowerClass, fieldName))
-	res.WriteString(fmt.Sprintf("func (%s) Has%sWith(preds ...Predicate) Predicate { return func(interface{}) {} }\n", lowerClass, fieldName))
+	target, columns, references := resolveRelationLink(cls, fld, ast)
+	if target == "" {
+		return ""
+	}
+
+	quoted := make([]string, len(columns))
+	for i, column := range columns {
+		quoted[i] = fmt.Sprintf("%q", column)
+	}
+	refs := make([]string, len(references))
+	for i, reference := range references {
+		refs[i] = fmt.Sprintf("db.Column(%q, %q)", cls.Name, reference)
+	}
+	exists := func(where string) string {
+		return fmt.Sprintf("db.Exists(%q, []string{%s}, []db.Expr{%s}, %s)", target, strings.Join(quoted, ", "), strings.Join(refs, ", "), where)
+	}
+
+	res.WriteString(fmt.Sprintf("func (%s) Has%s() Predicate { return %s }\n", lowerClass, fieldName, exists("nil")))
+	res.WriteString(fmt.Sprintf("func (%s) Has%sWith(preds ...Predicate) Predicate { return %s }\n", lowerClass, fieldName, exists("db.And(preds...)")))
 
 	if fld.IsArray() {
-		res.WriteString(fmt.Sprintf("func (%s) %sSome(preds ...Predicate) Predicate { return func(interface{}) {} }\n", lowerClass, fieldName))
-		res.WriteString(fmt.Sprintf("func (%s) %sEvery(preds ...Predicate) Predicate { return func(interface{}) {} }\n", lowerClass, fieldName))
-		res.WriteString(fmt.Sprintf("func (%s) %sNone(preds ...Predicate) Predicate { return func(interface{}) {} }\n", lowerClass, fieldName))
+		res.WriteString(fmt.Sprintf("func (%s) %sSome(preds ...Predicate) Predicate { return %s }\n", lowerClass, fieldName, exists("db.And(preds...)")))
+		res.WriteString(fmt.Sprintf("func (%s) %sEvery(preds ...Predicate) Predicate { return db.Not(%s) }\n", lowerClass, fieldName, exists("db.Not(db.And(preds...))")))
+		res.WriteString(fmt.Sprintf("func (%s) %sNone(preds ...Predicate) Predicate { return db.Not(%s) }\n", lowerClass, fieldName, exists("db.And(preds...)")))
 	}
 
 	return res.String()
 }
 
-func generateJsonPredicates(lowerClass string, fld *field.Field) string {
-	var res strings.Builder
-	fieldName := utils.ToExportedName(fld.GetName())
+func resolveRelationLink(cls *class.Class, fld *field.Field, ast *ast.SchemaAST) (string, []string, []string) {
+	targetClass := ast.GetClassByName(fld.GetBaseType())
+	if targetClass == nil {
+		return "", nil, nil
+	}
 
-	res.WriteString(fmt.Sprintf("func (%s) %sEQ(v interface{}) Predicate { return func(interface{}) {} }\n", lowerClass, fieldName))
-	res.WriteString(fmt.Sprintf("func (%s) %sNEQ(v interface{}) Predicate { return func(interface{}) {} }\n", lowerClass, fieldName))
-	res.WriteString(fmt.Sprintf("func (%s) %sContains(v interface{}) Predicate { return func(interface{}) {} }\n", lowerClass, fieldName))
-	res.WriteString(fmt.Sprintf("func (%s) %sHasKey(key string) Predicate { return func(interface{}) {} }\n", lowerClass, fieldName))
+	if fld.HasRelation() {
+		relation := fld.AttributeDefinition.GetRelation()
+		if relation == nil {
+			return "", nil, nil
+		}
+		return targetClass.Name, relation.To, relation.From
+	}
 
-	if fld.IsOptional() {
-		res.WriteString(fmt.Sprintf("func (%s) %sIsNull() Predicate { return func(interface{}) {} }\n", lowerClass, fieldName))
-		res.WriteString(fmt.Sprintf("func (%s) %sIsNotNull() Predicate { return func(interface{}) {} }\n", lowerClass, fieldName))
+	for _, targetField := range targetClass.Attributes.Fields {
+		if !targetField.HasRelation() || targetField.GetBaseType() != cls.Name {
+			continue
+		}
+		relation := targetField.AttributeDefinition.GetRelation()
+		if relation == nil {
+			continue
+		}
+		return targetClass.Name, relation.From, relation.To
 	}
 
-	return res.String()
+	return "", nil, nil
 }
 
-func generateBytesPredicates(lowerClass string, fld *field.Field, ast *ast.SchemaAST) string {
+func generateJsonPredicates(lowerClass string, column string, fld *field.Field) string {
 	var res strings.Builder
 	fieldName := utils.ToExportedName(fld.GetName())
-	goType := strings.TrimPrefix(utils.GetGoType(fld, ast), "*")
 
-	res.WriteString(fmt.Sprintf("func (%s) %sEQ(v %s) Predicate { return func(interface{}) {} }\n", lowerClass, fieldName, goType))
-	res.WriteString(fmt.Sprintf("func (%s) %sNEQ(v %s) Predicate { return func(interface{}) {} }\n", lowerClass, fieldName, goType))
+	res.WriteString(generateEqualityPredicates(lowerClass, column, fld, "interface{}"))
+	res.WriteString(fmt.Sprintf("func (%s) %sContains(v interface{}) Predicate { return db.Op(%s, \"@>\", v) }\n", lowerClass, fieldName, column))
+	res.WriteString(fmt.Sprintf("func (%s) %sHasKey(key string) Predicate { return db.Op(%s, \"?\", key) }\n", lowerClass, fieldName, column))
+	res.WriteString(generateNullPredicates(lowerClass, column, fld))
 
-	if fld.IsOptional() {
-		res.WriteString(fmt.Sprintf("func (%s) %sIsNull() Predicate { return func(interface{}) {} }\n", lowerClass, fieldName))
-		res.WriteString(fmt.Sprintf("func (%s) %sIsNotNull() Predicate { return func(interface{}) {} }\n", lowerClass, fieldName))
-	}
+	return res.String()
+}
+
+func generateBytesPredicates(lowerClass string, column string, fld *field.Field, ast *ast.SchemaAST) string {
+	var res strings.Builder
+	goType := strings.TrimPrefix(utils.GetGoType(fld, ast), "*")
+
+	res.WriteString(generateEqualityPredicates(lowerClass, column, fld, goType))
+	res.WriteString(generateNullPredicates(lowerClass, column, fld))
 
 	return res.String()
 }
 
-func generateArrayPredicates(lowerClass string, fld *field.Field) string {
+func generateArrayPredicates(lowerClass string, column string, fld *field.Field, ast *ast.SchemaAST) string {
 	var res strings.Builder
 	fieldName := utils.ToExportedName(fld.GetName())
-
-	res.WriteString(fmt.Sprintf("func (%s) %sIsEmpty() Predicate { return func(interface{}) {} }\n", lowerClass, fieldName))
-	res.WriteString(fmt.Sprintf("func (%s) %sIsNotEmpty() Predicate { return func(interface{}) {} }\n", lowerClass, fieldName))
-	res.WriteString(fmt.Sprintf("func (%s) %sLengthEQ(n int) Predicate { return func(interface{}) {} }\n", lowerClass, fieldName))
-	res.WriteString(fmt.Sprintf("func (%s) %sLengthGT(n int) Predicate { return func(interface{}) {} }\n", lowerClass, fieldName))
-	res.WriteString(fmt.Sprintf("func (%s) %sLengthLT(n int) Predicate { return func(interface{}) {} }\n", lowerClass, fieldName))
+	goType := strings.TrimPrefix(utils.GetGoType(fld, ast), "*")
+	elementType := strings.TrimPrefix(goType, "[]")
+	length := fmt.Sprintf("db.Func(\"cardinality\", %s)", column)
+
+	res.WriteString(generateEqualityPredicates(lowerClass, column, fld, goType))
+	res.WriteString(fmt.Sprintf("func (%s) %sHas(v %s) Predicate { return db.EQ(db.Value(v), db.Func(\"ANY\", %s)) }\n", lowerClass, fieldName, elementType, column))
+	res.WriteString(fmt.Sprintf("func (%s) %sHasEvery(vs ...%s) Predicate { return db.Op(%s, \"@>\", vs) }\n", lowerClass, fieldName, elementType, column))
+	res.WriteString(fmt.Sprintf("func (%s) %sHasSome(vs ...%s) Predicate { return db.Op(%s, \"&&\", vs) }\n", lowerClass, fieldName, elementType, column))
+	res.WriteString(fmt.Sprintf("func (%s) %sIsEmpty() Predicate { return db.EQ(db.Func(\"COALESCE\", %s, db.Literal(\"0\")), 0) }\n", lowerClass, fieldName, length))
+	res.WriteString(fmt.Sprintf("func (%s) %sIsNotEmpty() Predicate { return db.GT(%s, 0) }\n", lowerClass, fieldName, length))
+	res.WriteString(fmt.Sprintf("func (%s) %sLengthEQ(n int) Predicate { return db.EQ(%s, n) }\n", lowerClass, fieldName, length))
+	res.WriteString(fmt.Sprintf("func (%s) %sLengthGT(n int) Predicate { return db.GT(%s, n) }\n", lowerClass, fieldName, length))
+	res.WriteString(fmt.Sprintf("func (%s) %sLengthLT(n int) Predicate { return db.LT(%s, n) }\n", lowerClass, fieldName, length))
+	res.WriteString(generateNullPredicates(lowerClass, column, fld))
 
 	return res.String()
 }
@@ -320,15 +422,15 @@ func GenerateCompositePredicates(cls *class.Class, ast *ast.SchemaAST) string {
 
 func GenerateLogicalOperators() string {
 	return `func And(predicates ...Predicate) Predicate {
-    return func(interface{}) {}
+    return db.And(predicates...)
 }
 
 func Or(predicates ...Predicate) Predicate {
-    return func(interface{}) {}
+    return db.Or(predicates...)
 }
 
 func Not(p Predicate) Predicate {
-    return func(interface{}) {}
+    return db.Not(p)
 }`
 }
 
@@ -353,7 +455,12 @@ func GenerateFieldConstants(ast *ast.SchemaAST) string {
 
 	res.WriteString("// ==================== FIELD CONSTANTS ====================\n\n")
 
-	for _, cls := range ast.Classes {
+	classes := append([]*class.Class{}, ast.Classes...)
+	for _, v := range ast.GetViewOrder() {
+		classes = append(classes, utils.ViewAsClass(v, ast))
+	}
+
+	for _, cls := range classes {
 		structName := cls.Name + "FieldsType"
 		varName := cls.Name + "Fields"
 
@@ -474,6 +581,13 @@ func GenerateClassClient(cls *class.Class, ast *ast.SchemaAST) string {
 	res.WriteString("\t}\n")
 	res.WriteString("}\n\n")
 
+	res.WriteString(fmt.Sprintf("func (c *%sClient) CreateMany(rows []%sCreateInput) *%sCreateMany {\n", cls.Name, cls.Name, cls.Name))
+	res.WriteString(fmt.Sprintf("\treturn &%sCreateMany{\n", cls.Name))
+	res.WriteString("\t\tclient: c,\n")
+	res.WriteString("\t\trows: rows,\n")
+	res.WriteString("\t}\n")
+	res.WriteString("}\n\n")
+
 	res.WriteString(fmt.Sprintf("func (c *%sClient) Update() *%sUpdate {\n", cls.Name, cls.Name))
 	res.WriteString(fmt.Sprintf("\treturn &%sUpdate{\n", cls.Name))
 	res.WriteString("\t\tclient: c,\n")
@@ -488,6 +602,13 @@ func GenerateClassClient(cls *class.Class, ast *ast.SchemaAST) string {
 	res.WriteString("\t}\n")
 	res.WriteString("}\n\n")
 
+	res.WriteString(fmt.Sprintf("func (c *%sClient) Upsert(where %sWhereUniqueInput) *%sUpsert {\n", cls.Name, cls.Name, cls.Name))
+	res.WriteString(fmt.Sprintf("\treturn &%sUpsert{\n", cls.Name))
+	res.WriteString("\t\tclient: c,\n")
+	res.WriteString("\t\twhere: where,\n")
+	res.WriteString("\t}\n")
+	res.WriteString("}\n\n")
+
 	res.WriteString(fmt.Sprintf("func (c *%sClient) Delete() *%sDelete {\n", cls.Name, cls.Name))
 	res.WriteString(fmt.Sprintf("\treturn &%sDelete{\n", cls.Name))
 	res.WriteString("\t\tclient: c,\n")
@@ -505,8 +626,45 @@ func GenerateClassClient(cls *class.Class, ast *ast.SchemaAST) string {
 	return res.String()
 }
 
+func GenerateViewClient(v *view.View) string {
+	var res strings.Builder
+
+	res.WriteString(fmt.Sprintf("type %sClient struct {\n", v.Name))
+	res.WriteString("\tdb *BlazeDatabaseClient\n")
+	res.WriteString("}\n\n")
+
+	res.WriteString(fmt.Sprintf("func (c *%sClient) Query() *%sQuery {\n", v.Name, v.Name))
+	res.WriteString(fmt.Sprintf("\treturn &%sQuery{\n", v.Name))
+	res.WriteString("\t\tclient: c,\n")
+	res.WriteString("\t\tpredicates: []Predicate{},\n")
+	res.WriteString("\t}\n")
+	res.WriteString("}\n\n")
+
+	if !v.Materialized {
+		return res.String()
+	}
+
+	res.WriteString(fmt.Sprintf("func (c *%sClient) Refresh() error {\n", v.Name))
+	res.WriteString(fmt.Sprintf("\treturn c.db.RefreshMaterializedView(c.db.Context(), \"%s\", false)\n", v.Name))
+	res.WriteString("}\n\n")
+
+	if v.HasUniqueIndex() {
+		res.WriteString(fmt.Sprintf("func (c *%sClient) RefreshConcurrently() error {\n", v.Name))
+		res.WriteString(fmt.Sprintf("\treturn c.db.RefreshMaterializedView(c.db.Context(), \"%s\", true)\n", v.Name))
+		res.WriteString("}\n\n")
+	}
+
+	return res.String()
+}
+
 func GenerateQueryBuilder(cls *class.Class, ast *ast.SchemaAST) string {
 	var res strings.Builder
+	lowerClass := cases.Lower(language.English).String(cls.Name)
+	columnsVar := lowerClass + "Columns"
+	scanFunc := "scan" + cls.Name
+
+	res.WriteString(generateColumnScanner(cls, columnsVar, scanFunc))
+	res.WriteString(generateIncludeOptions(cls, ast))
 
 	res.WriteString(fmt.Sprintf("type %sQuery struct {\n", cls.Name))
 	res.WriteString(fmt.Sprintf("\tclient *%sClient\n", cls.Name))
@@ -514,7 +672,10 @@ func GenerateQueryBuilder(cls *class.Class, ast *ast.SchemaAST) string {
 	res.WriteString("\torders []*Order\n")
 	res.WriteString("\tlimitValue *int\n")
 	res.WriteString("\toffsetValue *int\n")
-	res.WriteString("\tinclude []string\n")
+	res.WriteString(fmt.Sprintf("\tinclude []%sIncludeOption\n", cls.Name))
+	res.WriteString("\tfields []string\n")
+	res.WriteString("\tomitted []string\n")
+	res.WriteString("\trequired []string\n")
 	res.WriteString("}\n\n")
 
 	res.WriteString(fmt.Sprintf("func (q *%sQuery) Where(predicates ...Predicate) *%sQuery {\n", cls.Name, cls.Name))
@@ -539,30 +700,374 @@ func GenerateQueryBuilder(cls *class.Class, ast *ast.SchemaAST) string {
 	res.WriteString("\treturn q\n")
 	res.WriteString("}\n\n")
 
-	res.WriteString(fmt.Sprintf("func (q *%sQuery) Include(relations ...string) *%sQuery {\n", cls.Name, cls.Name))
-	res.WriteString("\tq.include = append(q.include, relations...)\n")
+	res.WriteString(fmt.Sprintf("func (q *%sQuery) Include(options ...%sIncludeOption) *%sQuery {\n", cls.Name, cls.Name, cls.Name))
+	res.WriteString("\tq.include = append(q.include, options...)\n")
+	res.WriteString("\treturn q\n")
+	res.WriteString("}\n\n")
+
+	res.WriteString(fmt.Sprintf("func (q *%sQuery) Select(fields ...string) *%sQuery {\n", cls.Name, cls.Name))
+	res.WriteString("\tq.fields = append(q.fields, fields...)\n")
 	res.WriteString("\treturn q\n")
 	res.WriteString("}\n\n")
 
+	res.WriteString(fmt.Sprintf("func (q *%sQuery) Omit(fields ...string) *%sQuery {\n", cls.Name, cls.Name))
+	res.WriteString("\tq.omitted = append(q.omitted, fields...)\n")
+	res.WriteString("\treturn q\n")
+	res.WriteString("}\n\n")
+
+	res.WriteString(fmt.Sprintf("func (q *%sQuery) columns() []string {\n", cls.Name))
+	res.WriteString("\tif len(q.fields) == 0 && len(q.omitted) == 0 {\n")
+	res.WriteString(fmt.Sprintf("\t\treturn %s\n", columnsVar))
+	res.WriteString("\t}\n\n")
+	res.WriteString("\tfields := q.fields\n")
+	res.WriteString("\tif len(fields) == 0 {\n")
+	res.WriteString(fmt.Sprintf("\t\tfields = %s\n", columnsVar))
+	res.WriteString("\t}\n")
+	res.WriteString("\trequired := append([]string{}, q.required...)\n")
+	res.WriteString("\tfor _, include := range q.include {\n")
+	res.WriteString("\t\trequired = append(required, include.columns...)\n")
+	res.WriteString("\t}\n\n")
+	res.WriteString("\tvar columns []string\n")
+	res.WriteString("\tfor _, field := range fields {\n")
+	res.WriteString("\t\tif !slices.Contains(q.omitted, field) && !slices.Contains(columns, field) {\n")
+	res.WriteString("\t\t\tcolumns = append(columns, field)\n")
+	res.WriteString("\t\t}\n")
+	res.WriteString("\t}\n")
+	res.WriteString("\tfor _, field := range required {\n")
+	res.WriteString("\t\tif !slices.Contains(columns, field) {\n")
+	res.WriteString("\t\t\tcolumns = append(columns, field)\n")
+	res.WriteString("\t\t}\n")
+	res.WriteString("\t}\n")
+	res.WriteString("\treturn columns\n")
+	res.WriteString("}\n\n")
+
+	res.WriteString(fmt.Sprintf("func (q *%sQuery) selector() *db.Selector {\n", cls.Name))
+	res.WriteString(fmt.Sprintf("\tsel := db.Select(\"%s\", %s...).Columns(q.columns()...)\n", cls.Name, columnsVar))
+	res.WriteString("\tfor _, predicate := range q.predicates {\n")
+	res.WriteString("\t\tsel.Where(predicate)\n")
+	res.WriteString("\t}\n")
+	res.WriteString("\tfor _, order := range q.orders {\n")
+	res.WriteString("\t\tsel.OrderBy(order.field, order.desc)\n")
+	res.WriteString("\t}\n")
+	res.WriteString("\tif q.limitValue != nil {\n")
+	res.WriteString("\t\tsel.Limit(*q.limitValue)\n")
+	res.WriteString("\t}\n")
+	res.WriteString("\tif q.offsetValue != nil {\n")
+	res.WriteString("\t\tsel.Offset(*q.offsetValue)\n")
+	res.WriteString("\t}\n")
+	res.WriteString("\treturn sel\n")
+	res.WriteString("}\n\n")
+
 	res.WriteString(fmt.Sprintf("func (q *%sQuery) Find() ([]*%s, error) {\n", cls.Name, cls.Name))
-	res.WriteString("\tctx := q.client.db.Context()\n")
-	res.WriteString("\t_ = ctx\n")
-	res.WriteString("\t// TODO: Implement query execution\n")
-	res.WriteString("\treturn nil, fmt.Errorf(\"not implemented\")\n")
+	res.WriteString("\treturn q.find(q.selector())\n")
+	res.WriteString("}\n\n")
+
+	res.WriteString(fmt.Sprintf("func (q *%sQuery) find(sel *db.Selector) ([]*%s, error) {\n", cls.Name, cls.Name))
+	res.WriteString("\tcolumns := q.columns()\n")
+	res.WriteString("\tquery, args, err := sel.SQL()\n")
+	res.WriteString("\tif err != nil {\n")
+	res.WriteString("\t\treturn nil, err\n")
+	res.WriteString("\t}\n\n")
+	res.WriteString("\trows, err := q.client.db.Pool.Query(q.client.db.Context(), query, args...)\n")
+	res.WriteString("\tif err != nil {\n")
+	res.WriteString("\t\treturn nil, mapDatabaseError(err)\n")
+	res.WriteString("\t}\n")
+	res.WriteString("\tdefer rows.Close()\n\n")
+	res.WriteString(fmt.Sprintf("\tvar results []*%s\n", cls.Name))
+	res.WriteString("\tfor rows.Next() {\n")
+	res.WriteString(fmt.Sprintf("\t\titem, err := %sColumns(rows, columns)\n", scanFunc))
+	res.WriteString("\t\tif err != nil {\n")
+	res.WriteString("\t\t\treturn nil, err\n")
+	res.WriteString("\t\t}\n")
+	res.WriteString("\t\tresults = append(results, item)\n")
+	res.WriteString("\t}\n")
+	res.WriteString("\tif err := rows.Err(); err != nil {\n")
+	res.WriteString("\t\treturn nil, mapDatabaseError(err)\n")
+	res.WriteString("\t}\n")
+	res.WriteString("\trows.Close()\n\n")
+	res.WriteString("\tfor _, include := range q.include {\n")
+	res.WriteString("\t\tif err := include.load(q.client.db, results); err != nil {\n")
+	res.WriteString("\t\t\treturn nil, err\n")
+	res.WriteString("\t\t}\n")
+	res.WriteString("\t}\n\n")
+	res.WriteString("\treturn results, nil\n")
 	res.WriteString("}\n\n")
 
 	res.WriteString(fmt.Sprintf("func (q *%sQuery) First() (*%s, error) {\n", cls.Name, cls.Name))
-	res.WriteString("\tctx := q.client.db.Context()\n")
-	res.WriteString("\t_ = ctx\n")
-	res.WriteString("\t// TODO: Implement query execution\n")
-	res.WriteString("\treturn nil, fmt.Errorf(\"not implemented\")\n")
+	res.WriteString("\tresults, err := q.find(q.selector().Limit(1))\n")
+	res.WriteString("\tif err != nil {\n")
+	res.WriteString("\t\treturn nil, err\n")
+	res.WriteString("\t}\n")
+	res.WriteString("\tif len(results) == 0 {\n")
+	res.WriteString(fmt.Sprintf("\t\treturn nil, &NotFoundError{Table: \"%s\"}\n", cls.Name))
+	res.WriteString("\t}\n")
+	res.WriteString("\treturn results[0], nil\n")
+	res.WriteString("}\n\n")
+
+	res.WriteString(fmt.Sprintf("func (q *%sQuery) Scan(into interface{}) error {\n", cls.Name))
+	res.WriteString("\tquery, args, err := q.selector().SQL()\n")
+	res.WriteString("\tif err != nil {\n")
+	res.WriteString("\t\treturn err\n")
+	res.WriteString("\t}\n\n")
+	res.WriteString("\trows, err := q.client.db.Pool.Query(q.client.db.Context(), query, args...)\n")
+	res.WriteString("\tif err != nil {\n")
+	res.WriteString("\t\treturn mapDatabaseError(err)\n")
+	res.WriteString("\t}\n")
+	res.WriteString("\tdefer rows.Close()\n\n")
+	res.WriteString("\tif err := db.ScanRows(rows, into); err != nil {\n")
+	res.WriteString("\t\treturn mapDatabaseError(err)\n")
+	res.WriteString("\t}\n")
+	res.WriteString("\treturn nil\n")
 	res.WriteString("}\n\n")
 
 	res.WriteString(fmt.Sprintf("func (q *%sQuery) Count() (int64, error) {\n", cls.Name))
-	res.WriteString("\tctx := q.client.db.Context()\n")
-	res.WriteString("\t_ = ctx\n")
-	res.WriteString("\t// TODO: Implement count\n")
-	res.WriteString("\treturn 0, fmt.Errorf(\"not implemented\")\n")
+	res.WriteString("\tquery, args, err := q.selector().CountSQL()\n")
+	res.WriteString("\tif err != nil {\n")
+	res.WriteString("\t\treturn 0, err\n")
+	res.WriteString("\t}\n\n")
+	res.WriteString("\tvar count int64\n")
+	res.WriteString("\tif err := q.client.db.Pool.QueryRow(q.client.db.Context(), query, args...).Scan(&count); err != nil {\n")
+	res.WriteString("\t\treturn 0, mapDatabaseError(err)\n")
+	res.WriteString("\t}\n")
+	res.WriteString("\treturn count, nil\n")
+	res.WriteString("}\n\n")
+
+	return res.String()
+}
+
+func generateIncludeOptions(cls *class.Class, ast *ast.SchemaAST) string {
+	var res strings.Builder
+	lowerClass := cases.Lower(language.English).String(cls.Name)
+
+	res.WriteString(fmt.Sprintf("type %sIncludeOption struct {\n", cls.Name))
+	res.WriteString("\trelation string\n")
+	res.WriteString("\tcolumns []string\n")
+	res.WriteString(fmt.Sprintf("\tload func(client *BlazeDatabaseClient, items []*%s) error\n", cls.Name))
+	res.WriteString("}\n\n")
+
+	res.WriteString(fmt.Sprintf("type %sInclude struct{}\n\n", lowerClass))
+	res.WriteString(fmt.Sprintf("var %sInclude = %sInclude{}\n\n", cls.Name, lowerClass))
+
+	for _, fld := range cls.Attributes.Fields {
+		if !fld.IsObject() {
+			continue
+		}
+		target, columns, references := resolveRelationLink(cls, fld, ast)
+		targetClass := ast.GetClassByName(target)
+		if targetClass == nil || len(columns) == 0 || len(columns) != len(references) {
+			continue
+		}
+		res.WriteString(generateIncludeOption(cls, fld, targetClass, columns, references, ast))
+	}
+
+	return res.String()
+}
+
+func generateIncludeOption(cls *class.Class, fld *field.Field, target *class.Class, columns []string, references []string, ast *ast.SchemaAST) string {
+	var res strings.Builder
+	lowerClass := cases.Lower(language.English).String(cls.Name)
+	fieldName := utils.ToExportedName(fld.GetName())
+
+	keyTypes := make([]string, len(references))
+	for i, reference := range references {
+		keyTypes[i] = strings.TrimPrefix(utils.GetGoType(cls.Attributes.GetFieldByName(reference), ast), "*")
+		if strings.HasPrefix(keyTypes[i], "[]") {
+			keyTypes[i] = "string"
+		}
+	}
+
+	keyOf := func(owner *class.Class, variable string, names []string) (string, []string) {
+		var guards []string
+		var parts []string
+		for i, name := range names {
+			ref := fmt.Sprintf("%s.%s", variable, utils.ToExportedName(name))
+			goType := ""
+			if keyField := owner.Attributes.GetFieldByName(name); keyField != nil {
+				goType = utils.GetGoType(keyField, ast)
+			}
+			if strings.HasPrefix(goType, "*") {
+				guards = append(guards, fmt.Sprintf("%s == nil", ref))
+				ref = "*" + ref
+			}
+			if strings.HasPrefix(strings.TrimPrefix(goType, "*"), "[]") {
+				ref = fmt.Sprintf("%s(%s)", keyTypes[i], ref)
+			}
+			parts = append(parts, ref)
+		}
+		if len(parts) == 1 {
+			return parts[0], guards
+		}
+		return fmt.Sprintf("includeKey{%s}", strings.Join(parts, ", ")), guards
+	}
+
+	itemKey, itemGuards := keyOf(cls, "item", references)
+	relatedKey, relatedGuards := keyOf(target, "related", columns)
+
+	mapKey := keyTypes[0]
+	if len(keyTypes) > 1 {
+		mapKey = "includeKey"
+	}
+
+	res.WriteString(fmt.Sprintf("func (%sInclude) %s(build ...func(*%sQuery)) %sIncludeOption {\n", lowerClass, fieldName, target.Name, cls.Name))
+	res.WriteString(fmt.Sprintf("\treturn %sIncludeOption{\n", cls.Name))
+	quotedReferences := make([]string, len(references))
+	for i, reference := range references {
+		quotedReferences[i] = fmt.Sprintf("%q", reference)
+	}
+
+	res.WriteString(fmt.Sprintf("\t\trelation: %q,\n", fld.GetName()))
+	res.WriteString(fmt.Sprintf("\t\tcolumns: []string{%s},\n", strings.Join(quotedReferences, ", ")))
+	res.WriteString(fmt.Sprintf("\t\tload: func(client *BlazeDatabaseClient, items []*%s) error {\n", cls.Name))
+	res.WriteString("\t\t\tif len(items) == 0 {\n")
+	res.WriteString("\t\t\t\treturn nil\n")
+	res.WriteString("\t\t\t}\n\n")
+
+	if len(keyTypes) > 1 {
+		res.WriteString("\t\t\ttype includeKey struct {\n")
+		for i, reference := range references {
+			res.WriteString(fmt.Sprintf("\t\t\t\t%s %s\n", utils.ToExportedName(reference), keyTypes[i]))
+		}
+		res.WriteString("\t\t\t}\n\n")
+	}
+
+	if len(columns) == 1 {
+		keyField := cls.Attributes.GetFieldByName(references[0])
+		keyType := strings.TrimPrefix(utils.GetGoType(keyField, ast), "*")
+		ref := "item." + utils.ToExportedName(references[0])
+		res.WriteString(fmt.Sprintf("\t\t\tkeys := make([]%s, 0, len(items))\n", keyType))
+		res.WriteString("\t\t\tfor _, item := range items {\n")
+		if len(itemGuards) > 0 {
+			res.WriteString(fmt.Sprintf("\t\t\t\tif %s {\n", strings.Join(itemGuards, " || ")))
+			res.WriteString("\t\t\t\t\tcontinue\n")
+			res.WriteString("\t\t\t\t}\n")
+			ref = "*" + ref
+		}
+		res.WriteString(fmt.Sprintf("\t\t\t\tkeys = append(keys, %s)\n", ref))
+		res.WriteString("\t\t\t}\n")
+		res.WriteString(fmt.Sprintf("\t\t\tfilter := db.EQ(db.Column(%q, %q), db.Func(\"ANY\", db.Value(keys)))\n\n", target.Name, columns[0]))
+	} else {
+		keyVars := make([]string, len(references))
+		for i, reference := range references {
+			keyVars[i] = reference + "Keys"
+			res.WriteString(fmt.Sprintf("\t\t\t%s := make([]%s, 0, len(items))\n", keyVars[i], keyTypes[i]))
+		}
+		res.WriteString("\t\t\tfor _, item := range items {\n")
+		if len(itemGuards) > 0 {
+			res.WriteString(fmt.Sprintf("\t\t\t\tif %s {\n", strings.Join(itemGuards, " || ")))
+			res.WriteString("\t\t\t\t\tcontinue\n")
+			res.WriteString("\t\t\t\t}\n")
+		}
+		for i, reference := range references {
+			ref := "item." + utils.ToExportedName(reference)
+			goType := utils.GetGoType(cls.Attributes.GetFieldByName(reference), ast)
+			if strings.HasPrefix(goType, "*") {
+				ref = "*" + ref
+			}
+			if strings.HasPrefix(strings.TrimPrefix(goType, "*"), "[]") {
+				ref = fmt.Sprintf("%s(%s)", keyTypes[i], ref)
+			}
+			res.WriteString(fmt.Sprintf("\t\t\t\t%s = append(%s, %s)\n", keyVars[i], keyVars[i], ref))
+		}
+		res.WriteString("\t\t\t}\n")
+
+		var operands []string
+		var arrays []string
+		for i, column := range columns {
+			operands = append(operands, fmt.Sprintf("db.Column(%q, %q)", target.Name, column))
+			arrayType := includeKeyArrayType(target, target.Attributes.GetFieldByName(column), ast)
+			arrays = append(arrays, fmt.Sprintf("db.Cast(db.Value(%s), %q)", keyVars[i], arrayType))
+		}
+		res.WriteString(fmt.Sprintf("\t\t\tfilter := db.InUnnest([]Predicate{%s}, %s)\n\n", strings.Join(operands, ", "), strings.Join(arrays, ", ")))
+	}
+
+	quoted := make([]string, len(columns))
+	for i, column := range columns {
+		quoted[i] = fmt.Sprintf("%q", column)
+	}
+
+	res.WriteString(fmt.Sprintf("\t\t\tquery := (&%sClient{db: client}).Query()\n", target.Name))
+	res.WriteString("\t\t\tfor _, b := range build {\n")
+	res.WriteString("\t\t\t\tb(query)\n")
+	res.WriteString("\t\t\t}\n")
+	res.WriteString(fmt.Sprintf("\t\t\tquery.required = append(query.required, %s)\n", strings.Join(quoted, ", ")))
+	res.WriteString(fmt.Sprintf("\t\t\tloaded, err := query.find(query.selector().Where(filter).PartitionBy(%s))\n", strings.Join(quoted, ", ")))
+	res.WriteString("\t\t\tif err != nil {\n")
+	res.WriteString("\t\t\t\treturn err\n")
+	res.WriteString("\t\t\t}\n\n")
+
+	res.WriteString(fmt.Sprintf("\t\t\tgrouped := make(map[%s][]*%s)\n", mapKey, target.Name))
+	res.WriteString("\t\t\tfor _, related := range loaded {\n")
+	if len(relatedGuards) > 0 {
+		res.WriteString(fmt.Sprintf("\t\t\t\tif %s {\n", strings.Join(relatedGuards, " || ")))
+		res.WriteString("\t\t\t\t\tcontinue\n")
+		res.WriteString("\t\t\t\t}\n")
+	}
+	res.WriteString(fmt.Sprintf("\t\t\t\tkey := %s\n", relatedKey))
+	res.WriteString("\t\t\t\tgrouped[key] = append(grouped[key], related)\n")
+	res.WriteString("\t\t\t}\n")
+	res.WriteString("\t\t\tfor _, item := range items {\n")
+	res.WriteString(fmt.Sprintf("\t\t\t\titem.Relations.markLoaded(%q)\n", fld.GetName()))
+	if len(itemGuards) > 0 {
+		res.WriteString(fmt.Sprintf("\t\t\t\tif %s {\n", strings.Join(itemGuards, " || ")))
+		res.WriteString("\t\t\t\t\tcontinue\n")
+		res.WriteString("\t\t\t\t}\n")
+	}
+	if fld.IsArray() {
+		res.WriteString(fmt.Sprintf("\t\t\t\titem.Relations.%s = grouped[%s]\n", fieldName, itemKey))
+	} else {
+		res.WriteString(fmt.Sprintf("\t\t\t\tif related := grouped[%s]; len(related) > 0 {\n", itemKey))
+		res.WriteString(fmt.Sprintf("\t\t\t\t\titem.Relations.%s = related[0]\n", fieldName))
+		res.WriteString("\t\t\t\t}\n")
+	}
+	res.WriteString("\t\t\t}\n")
+	res.WriteString("\t\t\treturn nil\n")
+	res.WriteString("\t\t},\n")
+	res.WriteString("\t}\n")
+	res.WriteString("}\n\n")
+
+	return res.String()
+}
+
+func generateColumnScanner(cls *class.Class, columnsVar string, scanFunc string) string {
+	var res strings.Builder
+	var columns []string
+	var targets []string
+
+	for _, fld := range cls.Attributes.Fields {
+		if fld.IsObject() {
+			continue
+		}
+		columns = append(columns, fmt.Sprintf("%q", fld.GetName()))
+		targets = append(targets, fmt.Sprintf("&item.%s", utils.ToExportedName(fld.GetName())))
+	}
+
+	res.WriteString(fmt.Sprintf("var %s = []string{%s}\n\n", columnsVar, strings.Join(columns, ", ")))
+
+	res.WriteString(fmt.Sprintf("func %s(row interface{ Scan(...interface{}) error }) (*%s, error) {\n", scanFunc, cls.Name))
+	res.WriteString(fmt.Sprintf("\tvar item %s\n", cls.Name))
+	res.WriteString(fmt.Sprintf("\tif err := row.Scan(%s); err != nil {\n", strings.Join(targets, ", ")))
+	res.WriteString(fmt.Sprintf("\t\treturn nil, fmt.Errorf(\"failed to scan %s: %%w\", err)\n", cls.Name))
+	res.WriteString("\t}\n")
+	res.WriteString("\treturn &item, nil\n")
+	res.WriteString("}\n\n")
+
+	res.WriteString(fmt.Sprintf("func %sColumns(row interface{ Scan(...interface{}) error }, columns []string) (*%s, error) {\n", scanFunc, cls.Name))
+	res.WriteString(fmt.Sprintf("\tvar item %s\n", cls.Name))
+	res.WriteString("\ttargets := make([]interface{}, len(columns))\n")
+	res.WriteString("\tfor i, column := range columns {\n")
+	res.WriteString("\t\tswitch column {\n")
+	for i, column := range columns {
+		res.WriteString(fmt.Sprintf("\t\tcase %s:\n", column))
+		res.WriteString(fmt.Sprintf("\t\t\ttargets[i] = %s\n", targets[i]))
+	}
+	res.WriteString("\t\tdefault:\n")
+	res.WriteString(fmt.Sprintf("\t\t\treturn nil, fmt.Errorf(\"cannot scan unknown %s column %%q\", column)\n", cls.Name))
+	res.WriteString("\t\t}\n")
+	res.WriteString("\t}\n")
+	res.WriteString("\tif err := row.Scan(targets...); err != nil {\n")
+	res.WriteString(fmt.Sprintf("\t\treturn nil, fmt.Errorf(\"failed to scan %s: %%w\", err)\n", cls.Name))
+	res.WriteString("\t}\n")
+	res.WriteString("\treturn &item, nil\n")
 	res.WriteString("}\n\n")
 
 	return res.String()
@@ -574,10 +1079,12 @@ func GenerateCreateBuilder(cls *class.Class, ast *ast.SchemaAST) string {
 	res.WriteString(fmt.Sprintf("type %sCreate struct {\n", cls.Name))
 	res.WriteString(fmt.Sprintf("\tclient *%sClient\n", cls.Name))
 	res.WriteString("\tdata map[string]interface{}\n")
+	res.WriteString("\tparents []func(ctx context.Context, q db.Querier) error\n")
+	res.WriteString(fmt.Sprintf("\tchildren []func(ctx context.Context, q db.Querier, item *%s) error\n", cls.Name))
 	res.WriteString("}\n\n")
 
 	for _, fld := range cls.Attributes.Fields {
-		if fld.IsObject() {
+		if fld.IsObject() || fld.IsComputed() {
 			continue
 		}
 		fieldName := utils.ToExportedName(fld.GetName())
@@ -593,22 +1100,425 @@ func GenerateCreateBuilder(cls *class.Class, ast *ast.SchemaAST) string {
 		res.WriteString("}\n\n")
 	}
 
-	res.WriteString(fmt.Sprintf("func (c *%sCreate) Save() (*%s, error) {\n", cls.Name, cls.Name))
-	res.WriteString("\tctx := c.client.db.Context()\n")
-	res.WriteString("\t_ = ctx\n")
-	res.WriteString("\t// TODO: Implement insert\n")
-	res.WriteString("\treturn nil, fmt.Errorf(\"not implemented\")\n")
+	lowerClass := cases.Lower(language.English).String(cls.Name)
+	var required []string
+	for _, fld := range cls.Attributes.Fields {
+		if isRequiredOnCreate(fld, ast) {
+			required = append(required, fmt.Sprintf("%q", fld.GetName()))
+		}
+	}
+
+	res.WriteString(generateNestedWrites(cls, cls.Name+"Create", "c", ast))
+	res.WriteString(generateTransactionalSave(cls, cls.Name+"Create", "c"))
+
+	res.WriteString(fmt.Sprintf("func (c *%sCreate) save(ctx context.Context, q db.Querier) (*%s, error) {\n", cls.Name, cls.Name))
+	res.WriteString(generateParentWrites("c"))
+	if len(required) > 0 {
+		res.WriteString(fmt.Sprintf("\tif missing := db.MissingColumns(c.data, []string{%s}); len(missing) > 0 {\n", strings.Join(required, ", ")))
+		res.WriteString(fmt.Sprintf("\t\treturn nil, fmt.Errorf(\"cannot create %s: missing required fields %%v\", missing)\n", cls.Name))
+		res.WriteString("\t}\n")
+	}
+	res.WriteString("\n")
+	res.WriteString(fmt.Sprintf("\tquery, args, err := db.Insert(\"%s\", %sColumns, c.data).Returning(%sColumns...).SQL()\n", cls.Name, lowerClass, lowerClass))
+	res.WriteString("\tif err != nil {\n")
+	res.WriteString("\t\treturn nil, err\n")
+	res.WriteString("\t}\n\n")
+	res.WriteString(fmt.Sprintf("\titem, err := scan%s(q.QueryRow(ctx, query, args...))\n", cls.Name))
+	res.WriteString("\tif err != nil {\n")
+	res.WriteString("\t\treturn nil, mapDatabaseError(err)\n")
+	res.WriteString("\t}\n")
+	res.WriteString(generateChildWrites("c"))
+	res.WriteString("\treturn item, nil\n")
+	res.WriteString("}\n\n")
+
+	return res.String()
+}
+
+func generateTransactionalSave(cls *class.Class, builder string, receiver string) string {
+	var res strings.Builder
+
+	res.WriteString(fmt.Sprintf("func (%s *%s) Save() (*%s, error) {\n", receiver, builder, cls.Name))
+	res.WriteString(fmt.Sprintf("\tctx := %s.client.db.Context()\n", receiver))
+	res.WriteString(fmt.Sprintf("\tif len(%s.parents) == 0 && len(%s.children) == 0 {\n", receiver, receiver))
+	res.WriteString(fmt.Sprintf("\t\treturn %s.save(ctx, %s.client.db.Pool)\n", receiver, receiver))
+	res.WriteString("\t}\n\n")
+	res.WriteString(fmt.Sprintf("\tvar item *%s\n", cls.Name))
+	res.WriteString(fmt.Sprintf("\terr := db.Transaction(ctx, %s.client.db.Pool, func(q db.Querier) error {\n", receiver))
+	res.WriteString("\t\tvar err error\n")
+	res.WriteString(fmt.Sprintf("\t\titem, err = %s.save(ctx, q)\n", receiver))
+	res.WriteString("\t\treturn err\n")
+	res.WriteString("\t})\n")
+	res.WriteString("\tif err != nil {\n")
+	res.WriteString("\t\treturn nil, mapDatabaseError(err)\n")
+	res.WriteString("\t}\n")
+	res.WriteString("\treturn item, nil\n")
+	res.WriteString("}\n\n")
+
+	return res.String()
+}
+
+func generateParentWrites(receiver string) string {
+	var res strings.Builder
+
+	res.WriteString(fmt.Sprintf("\tfor _, parent := range %s.parents {\n", receiver))
+	res.WriteString("\t\tif err := parent(ctx, q); err != nil {\n")
+	res.WriteString("\t\t\treturn nil, err\n")
+	res.WriteString("\t\t}\n")
+	res.WriteString("\t}\n")
+
+	return res.String()
+}
+
+func generateChildWrites(receiver string) string {
+	var res strings.Builder
+
+	res.WriteString(fmt.Sprintf("\tfor _, child := range %s.children {\n", receiver))
+	res.WriteString("\t\tif err := child(ctx, q, item); err != nil {\n")
+	res.WriteString("\t\t\treturn nil, err\n")
+	res.WriteString("\t\t}\n")
+	res.WriteString("\t}\n")
+
+	return res.String()
+}
+
+func generateNestedWrites(cls *class.Class, builder string, receiver string, ast *ast.SchemaAST) string {
+	var res strings.Builder
+
+	for _, fld := range cls.Attributes.Fields {
+		if !fld.IsObject() {
+			continue
+		}
+		target, targetColumns, ownColumns := resolveRelationLink(cls, fld, ast)
+		targetClass := ast.GetClassByName(target)
+		if targetClass == nil || len(targetColumns) == 0 || len(targetColumns) != len(ownColumns) {
+			continue
+		}
+
+		if fld.HasRelation() {
+			res.WriteString(generateParentRelationWrites(cls, fld, targetClass, targetColumns, ownColumns, builder, receiver))
+		} else {
+			res.WriteString(generateChildRelationWrites(cls, fld, targetClass, targetColumns, ownColumns, builder, receiver))
+		}
+	}
+
+	return res.String()
+}
+
+func generateParentRelationWrites(cls *class.Class, fld *field.Field, target *class.Class, targetColumns []string, ownColumns []string, builder string, receiver string) string {
+	var res strings.Builder
+	fieldName := utils.ToExportedName(fld.GetName())
+	lowerTarget := cases.Lower(language.English).String(target.Name)
+
+	var assignments strings.Builder
+	assignments.WriteString(fmt.Sprintf("\t\tif %s.data == nil {\n", receiver))
+	assignments.WriteString(fmt.Sprintf("\t\t\t%s.data = make(map[string]interface{})\n", receiver))
+	assignments.WriteString("\t\t}\n")
+	for i, column := range ownColumns {
+		assignments.WriteString(fmt.Sprintf("\t\t%s.data[%q] = related.%s\n", receiver, column, utils.ToExportedName(targetColumns[i])))
+	}
+
+	res.WriteString(fmt.Sprintf("func (%s *%s) With%s(create *%sCreate) *%s {\n", receiver, builder, fieldName, target.Name, builder))
+	res.WriteString(fmt.Sprintf("\t%s.parents = append(%s.parents, func(ctx context.Context, q db.Querier) error {\n", receiver, receiver))
+	res.WriteString("\t\trelated, err := create.save(ctx, q)\n")
+	res.WriteString("\t\tif err != nil {\n")
+	res.WriteString("\t\t\treturn err\n")
+	res.WriteString("\t\t}\n")
+	res.WriteString(assignments.String())
+	res.WriteString("\t\treturn nil\n")
+	res.WriteString("\t})\n")
+	res.WriteString(fmt.Sprintf("\treturn %s\n", receiver))
+	res.WriteString("}\n\n")
+
+	res.WriteString(fmt.Sprintf("func (%s *%s) Connect%s(where %sWhereUniqueInput) *%s {\n", receiver, builder, fieldName, target.Name, builder))
+	res.WriteString(fmt.Sprintf("\t%s.parents = append(%s.parents, func(ctx context.Context, q db.Querier) error {\n", receiver, receiver))
+	res.WriteString("\t\tpredicate, err := where.predicate()\n")
+	res.WriteString("\t\tif err != nil {\n")
+	res.WriteString("\t\t\treturn err\n")
+	res.WriteString("\t\t}\n")
+	res.WriteString(fmt.Sprintf("\t\tquery, args, err := db.Select(%q, %sColumns...).Where(predicate).SQL()\n", target.Name, lowerTarget))
+	res.WriteString("\t\tif err != nil {\n")
+	res.WriteString("\t\t\treturn err\n")
+	res.WriteString("\t\t}\n")
+	res.WriteString(fmt.Sprintf("\t\trelated, err := scan%s(q.QueryRow(ctx, query, args...))\n", target.Name))
+	res.WriteString("\t\tif errors.Is(err, pgx.ErrNoRows) {\n")
+	res.WriteString(fmt.Sprintf("\t\t\treturn &NotFoundError{Table: %q}\n", target.Name))
+	res.WriteString("\t\t}\n")
+	res.WriteString("\t\tif err != nil {\n")
+	res.WriteString("\t\t\treturn mapDatabaseError(err)\n")
+	res.WriteString("\t\t}\n")
+	res.WriteString(assignments.String())
+	res.WriteString("\t\treturn nil\n")
+	res.WriteString("\t})\n")
+	res.WriteString(fmt.Sprintf("\treturn %s\n", receiver))
+	res.WriteString("}\n\n")
+
+	return res.String()
+}
+
+func generateChildRelationWrites(cls *class.Class, fld *field.Field, target *class.Class, targetColumns []string, ownColumns []string, builder string, receiver string) string {
+	var res strings.Builder
+	fieldName := utils.ToExportedName(fld.GetName())
+	lowerTarget := cases.Lower(language.English).String(target.Name)
+
+	createParam := fmt.Sprintf("create *%sCreate", target.Name)
+	connectParam := fmt.Sprintf("where %sWhereUniqueInput", target.Name)
+	if fld.IsArray() {
+		createParam = fmt.Sprintf("creates ...*%sCreate", target.Name)
+		connectParam = fmt.Sprintf("wheres ...%sWhereUniqueInput", target.Name)
+	}
+
+	var create strings.Builder
+	create.WriteString("\tif create.data == nil {\n")
+	create.WriteString("\t\tcreate.data = make(map[string]interface{})\n")
+	create.WriteString("\t}\n")
+	for i, column := range targetColumns {
+		create.WriteString(fmt.Sprintf("\tcreate.data[%q] = item.%s\n", column, utils.ToExportedName(ownColumns[i])))
+	}
+	create.WriteString("\tif _, err := create.save(ctx, q); err != nil {\n")
+	create.WriteString("\t\treturn err\n")
+	create.WriteString("\t}\n")
+
+	var connect strings.Builder
+	connect.WriteString("\tpredicate, err := where.predicate()\n")
+	connect.WriteString("\tif err != nil {\n")
+	connect.WriteString("\t\treturn err\n")
+	connect.WriteString("\t}\n")
+	var values []string
+	for i, column := range targetColumns {
+		values = append(values, fmt.Sprintf("%q: item.%s", column, utils.ToExportedName(ownColumns[i])))
+	}
+	connect.WriteString(fmt.Sprintf("\tdata := map[string]interface{}{%s}\n", strings.Join(values, ", ")))
+	connect.WriteString(fmt.Sprintf("\tquery, args, err := db.Update(%q, %sColumns, data).Where(predicate).SQL()\n", target.Name, lowerTarget))
+	connect.WriteString("\tif err != nil {\n")
+	connect.WriteString("\t\treturn err\n")
+	connect.WriteString("\t}\n")
+	connect.WriteString("\ttag, err := q.Exec(ctx, query, args...)\n")
+	connect.WriteString("\tif err != nil {\n")
+	connect.WriteString("\t\treturn mapDatabaseError(err)\n")
+	connect.WriteString("\t}\n")
+	connect.WriteString("\tif tag.RowsAffected() == 0 {\n")
+	connect.WriteString(fmt.Sprintf("\t\treturn &NotFoundError{Table: %q}\n", target.Name))
+	connect.WriteString("\t}\n")
+
+	writeChild := func(method string, param string, loop string, body string) {
+		res.WriteString(fmt.Sprintf("func (%s *%s) %s%s(%s) *%s {\n", receiver, builder, method, fieldName, param, builder))
+		res.WriteString(fmt.Sprintf("\t%s.children = append(%s.children, func(ctx context.Context, q db.Querier, item *%s) error {\n", receiver, receiver, cls.Name))
+		if fld.IsArray() {
+			res.WriteString(fmt.Sprintf("\t\tfor _, %s := range %s {\n", loop, loop+"s"))
+			res.WriteString(indent(body, "\t\t"))
+			res.WriteString("\t\t}\n")
+		} else {
+			res.WriteString(indent(body, "\t"))
+		}
+		res.WriteString("\t\treturn nil\n")
+		res.WriteString("\t})\n")
+		res.WriteString(fmt.Sprintf("\treturn %s\n", receiver))
+		res.WriteString("}\n\n")
+	}
+
+	writeChild("With", createParam, "create", create.String())
+	writeChild("Connect", connectParam, "where", connect.String())
+
+	return res.String()
+}
+
+func indent(code string, prefix string) string {
+	lines := strings.SplitAfter(code, "\n")
+	var res strings.Builder
+	for _, line := range lines {
+		if line == "" {
+			continue
+		}
+		res.WriteString(prefix + line)
+	}
+	return res.String()
+}
+
+func isRequiredOnCreate(fld *field.Field, ast *ast.SchemaAST) bool {
+	if fld.IsObject() || fld.IsOptional() || fld.IsComputed() || fld.HasDefault() || fld.IsUpdatedAt() {
+		return false
+	}
+	if domain := ast.GetDomainByName(fld.GetBaseType()); domain != nil && domain.DefaultValue != nil {
+		return false
+	}
+	return true
+}
+
+func GenerateCreateManyBuilder(cls *class.Class, ast *ast.SchemaAST) string {
+	var res strings.Builder
+	lowerClass := cases.Lower(language.English).String(cls.Name)
+
+	var fields []*field.Field
+	for _, fld := range cls.Attributes.Fields {
+		if !fld.IsObject() && !fld.IsComputed() {
+			fields = append(fields, fld)
+		}
+	}
+
+	res.WriteString(fmt.Sprintf("type %sCreateInput struct {\n", cls.Name))
+	for _, fld := range fields {
+		goType := utils.GetGoType(fld, ast)
+		if !isRequiredOnCreate(fld, ast) && !strings.HasPrefix(goType, "*") {
+			goType = "*" + goType
+		}
+		res.WriteString(fmt.Sprintf("\t%s %s\n", utils.ToExportedName(fld.GetName()), goType))
+	}
+	res.WriteString("}\n\n")
+
+	res.WriteString(fmt.Sprintf("func (in %sCreateInput) values() map[string]interface{} {\n", cls.Name))
+	res.WriteString("\tvalues := make(map[string]interface{})\n")
+	for _, fld := range fields {
+		fieldName := utils.ToExportedName(fld.GetName())
+		if isRequiredOnCreate(fld, ast) {
+			res.WriteString(fmt.Sprintf("\tvalues[%q] = in.%s\n", fld.GetName(), fieldName))
+			continue
+		}
+		res.WriteString(fmt.Sprintf("\tif in.%s != nil {\n", fieldName))
+		res.WriteString(fmt.Sprintf("\t\tvalues[%q] = *in.%s\n", fld.GetName(), fieldName))
+		res.WriteString("\t}\n")
+	}
+	res.WriteString("\treturn values\n")
+	res.WriteString("}\n\n")
+
+	var casts []string
+	for _, fld := range fields {
+		if cast := columnCastType(fld, ast); cast != "" {
+			casts = append(casts, fmt.Sprintf("%q: %q", fld.GetName(), cast))
+		}
+	}
+	res.WriteString(fmt.Sprintf("var %sColumnTypes = map[string]string{%s}\n\n", lowerClass, strings.Join(casts, ", ")))
+
+	res.WriteString(fmt.Sprintf("type %sCreateMany struct {\n", cls.Name))
+	res.WriteString(fmt.Sprintf("\tclient *%sClient\n", cls.Name))
+	res.WriteString(fmt.Sprintf("\trows []%sCreateInput\n", cls.Name))
+	res.WriteString("\tskipDuplicates bool\n")
+	res.WriteString("}\n\n")
+
+	res.WriteString(fmt.Sprintf("func (m *%sCreateMany) SkipDuplicates() *%sCreateMany {\n", cls.Name, cls.Name))
+	res.WriteString("\tm.skipDuplicates = true\n")
+	res.WriteString("\treturn m\n")
+	res.WriteString("}\n\n")
+
+	res.WriteString(fmt.Sprintf("func (m *%sCreateMany) bulk() *db.BulkInserter {\n", cls.Name))
+	res.WriteString("\trows := make([]map[string]interface{}, len(m.rows))\n")
+	res.WriteString("\tfor i, row := range m.rows {\n")
+	res.WriteString("\t\trows[i] = row.values()\n")
+	res.WriteString("\t}\n")
+	res.WriteString(fmt.Sprintf("\tbulk := db.InsertMany(\"%s\", %sColumns, %sColumnTypes, rows)\n", cls.Name, lowerClass, lowerClass))
+	res.WriteString("\tif m.skipDuplicates {\n")
+	res.WriteString("\t\tbulk.SkipDuplicates()\n")
+	res.WriteString("\t}\n")
+	res.WriteString("\treturn bulk\n")
+	res.WriteString("}\n\n")
+
+	res.WriteString(fmt.Sprintf("func (m *%sCreateMany) Exec() (int64, error) {\n", cls.Name))
+	res.WriteString("\tcount, err := m.bulk().Exec(m.client.db.Context(), m.client.db.Pool)\n")
+	res.WriteString("\tif err != nil {\n")
+	res.WriteString("\t\treturn 0, mapDatabaseError(err)\n")
+	res.WriteString("\t}\n")
+	res.WriteString("\treturn count, nil\n")
+	res.WriteString("}\n\n")
+
+	res.WriteString(fmt.Sprintf("func (m *%sCreateMany) Save() ([]*%s, error) {\n", cls.Name, cls.Name))
+	res.WriteString(fmt.Sprintf("\tvar results []*%s\n", cls.Name))
+	res.WriteString(fmt.Sprintf("\terr := m.bulk().Returning(%sColumns...).Query(m.client.db.Context(), m.client.db.Pool, func(row db.Row) error {\n", lowerClass))
+	res.WriteString(fmt.Sprintf("\t\titem, err := scan%s(row)\n", cls.Name))
+	res.WriteString("\t\tif err != nil {\n")
+	res.WriteString("\t\t\treturn err\n")
+	res.WriteString("\t\t}\n")
+	res.WriteString("\t\tresults = append(results, item)\n")
+	res.WriteString("\t\treturn nil\n")
+	res.WriteString("\t})\n")
+	res.WriteString("\tif err != nil {\n")
+	res.WriteString("\t\treturn nil, mapDatabaseError(err)\n")
+	res.WriteString("\t}\n")
+	res.WriteString("\treturn results, nil\n")
 	res.WriteString("}\n\n")
 
 	return res.String()
 }
 
+func columnCastType(fld *field.Field, ast *ast.SchemaAST) string {
+	baseType := fld.GetBaseType()
+
+	var cast string
+	if ast.GetEnumByName(baseType) != nil || ast.GetCompositeByName(baseType) != nil || ast.GetDomainByName(baseType) != nil {
+		cast = fmt.Sprintf(`"%s"`, baseType)
+	} else if constants.ScalarType(baseType) == constants.JSON {
+		cast = "jsonb"
+	}
+
+	if cast != "" && fld.IsArray() {
+		cast += "[]"
+	}
+	return cast
+}
+
+func hasUUIDDefault(fld *field.Field) bool {
+	return fld.HasDefault() && fld.AttributeDefinition.DefaultValue != nil && fld.AttributeDefinition.DefaultValue.Value == constants.DEFAULT_UUID_CALLBACK
+}
+
+func isUUIDColumn(owner *class.Class, fld *field.Field, ast *ast.SchemaAST) bool {
+	if fld.GetBaseType() == constants.UUID.String() || hasUUIDDefault(fld) {
+		return true
+	}
+	if fld.GetBaseType() != constants.STRING.String() {
+		return false
+	}
+
+	for _, relationField := range owner.Attributes.Fields {
+		if !relationField.HasRelation() || relationField.AttributeDefinition.Relation == nil {
+			continue
+		}
+		relation := relationField.AttributeDefinition.Relation
+		referencedClass := ast.GetClassByName(relation.ToClass)
+		if referencedClass == nil {
+			continue
+		}
+		for i, from := range relation.From {
+			if from != fld.GetName() || i >= len(relation.To) {
+				continue
+			}
+			if referenced := referencedClass.Attributes.GetFieldByName(relation.To[i]); referenced != nil {
+				if referenced.GetBaseType() == constants.UUID.String() || (referenced.GetBaseType() == constants.STRING.String() && hasUUIDDefault(referenced)) {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+func includeKeyArrayType(owner *class.Class, fld *field.Field, ast *ast.SchemaAST) string {
+	if nativeType := fld.GetNativeType(); nativeType != nil {
+		return nativeType.SQL() + "[]"
+	}
+	if cast := columnCastType(fld, ast); cast != "" {
+		return cast + "[]"
+	}
+	if isUUIDColumn(owner, fld, ast) {
+		return "uuid[]"
+	}
+	if fld.GetBaseType() == constants.STRING.String() {
+		return "text[]"
+	}
+	for pgType, scalarType := range constants.PGTypeMapping {
+		if scalarType == fld.GetBaseType() {
+			return pgType + "[]"
+		}
+	}
+	return "text[]"
+}
+
 func GenerateUpdateBuilder(cls *class.Class, ast *ast.SchemaAST) string {
 	var res strings.Builder
 
+	lowerClass := cases.Lower(language.English).String(cls.Name)
+
 	res.WriteString(fmt.Sprintf("type %sUpdate struct {\n", cls.Name))
 	res.WriteString(fmt.Sprintf("\tclient *%sClient\n", cls.Name))
 	res.WriteString("\tpredicates []Predicate\n")
+	res.WriteString("\tall bool\n")
 	res.WriteString("\tdata map[string]interface{}\n")
 	res.WriteString("}\n\n")
 
@@ -617,8 +1527,13 @@ func GenerateUpdateBuilder(cls *class.Class, ast *ast.SchemaAST) string {
 	res.WriteString("\treturn u\n")
 	res.WriteString("}\n\n")
 
+	res.WriteString(fmt.Sprintf("func (u *%sUpdate) All() *%sUpdate {\n", cls.Name, cls.Name))
+	res.WriteString("\tu.all = true\n")
+	res.WriteString("\treturn u\n")
+	res.WriteString("}\n\n")
+
 	for _, fld := range cls.Attributes.Fields {
-		if fld.IsObject() || fld.IsPrimaryKey() {
+		if fld.IsObject() || fld.IsPrimaryKey() || fld.IsComputed() {
 			continue
 		}
 		fieldName := utils.ToExportedName(fld.GetName())
@@ -635,10 +1550,23 @@ func GenerateUpdateBuilder(cls *class.Class, ast *ast.SchemaAST) string {
 	}
 
 	res.WriteString(fmt.Sprintf("func (u *%sUpdate) Save() (int64, error) {\n", cls.Name))
-	res.WriteString("\tctx := u.client.db.Context()\n")
-	res.WriteString("\t_ = ctx\n")
-	res.WriteString("\t// TODO: Implement update\n")
-	res.WriteString("\treturn 0, fmt.Errorf(\"not implemented\")\n")
+	res.WriteString("\tif len(u.predicates) == 0 && !u.all {\n")
+	res.WriteString(fmt.Sprintf("\t\treturn 0, fmt.Errorf(\"refusing to update every %s row without a predicate, call All() to confirm\")\n", cls.Name))
+	res.WriteString("\t}\n")
+	res.WriteString("\n")
+	res.WriteString(fmt.Sprintf("\tstmt := db.Update(\"%s\", %sColumns, u.data)\n", cls.Name, lowerClass))
+	res.WriteString("\tfor _, predicate := range u.predicates {\n")
+	res.WriteString("\t\tstmt.Where(predicate)\n")
+	res.WriteString("\t}\n")
+	res.WriteString("\tquery, args, err := stmt.SQL()\n")
+	res.WriteString("\tif err != nil {\n")
+	res.WriteString("\t\treturn 0, err\n")
+	res.WriteString("\t}\n\n")
+	res.WriteString("\ttag, err := u.client.db.Pool.Exec(u.client.db.Context(), query, args...)\n")
+	res.WriteString("\tif err != nil {\n")
+	res.WriteString("\t\treturn 0, mapDatabaseError(err)\n")
+	res.WriteString("\t}\n")
+	res.WriteString("\treturn tag.RowsAffected(), nil\n")
 	res.WriteString("}\n\n")
 
 	return res.String()
@@ -646,15 +1574,20 @@ func GenerateUpdateBuilder(cls *class.Class, ast *ast.SchemaAST) string {
 
 func GenerateUpdateOneBuilder(cls *class.Class, ast *ast.SchemaAST) string {
 	var res strings.Builder
+	lowerClass := cases.Lower(language.English).String(cls.Name)
+
+	res.WriteString(generateWhereUniquePredicate(cls, ast))
 
 	res.WriteString(fmt.Sprintf("type %sUpdateOne struct {\n", cls.Name))
 	res.WriteString(fmt.Sprintf("\tclient *%sClient\n", cls.Name))
 	res.WriteString(fmt.Sprintf("\twhere %sWhereUniqueInput\n", cls.Name))
 	res.WriteString("\tdata map[string]interface{}\n")
+	res.WriteString("\tparents []func(ctx context.Context, q db.Querier) error\n")
+	res.WriteString(fmt.Sprintf("\tchildren []func(ctx context.Context, q db.Querier, item *%s) error\n", cls.Name))
 	res.WriteString("}\n\n")
 
 	for _, fld := range cls.Attributes.Fields {
-		if fld.IsObject() || fld.IsPrimaryKey() {
+		if fld.IsObject() || fld.IsPrimaryKey() || fld.IsComputed() {
 			continue
 		}
 		fieldName := utils.ToExportedName(fld.GetName())
@@ -670,11 +1603,249 @@ func GenerateUpdateOneBuilder(cls *class.Class, ast *ast.SchemaAST) string {
 		res.WriteString("}\n\n")
 	}
 
-	res.WriteString(fmt.Sprintf("func (u *%sUpdateOne) Save() (*%s, error) {\n", cls.Name, cls.Name))
-	res.WriteString("\tctx := u.client.db.Context()\n")
-	res.WriteString("\t_ = ctx\n")
-	res.WriteString("\t// TODO: Implement update one\n")
-	res.WriteString("\treturn nil, fmt.Errorf(\"not implemented\")\n")
+	res.WriteString(generateNestedWrites(cls, cls.Name+"UpdateOne", "u", ast))
+	res.WriteString(generateTransactionalSave(cls, cls.Name+"UpdateOne", "u"))
+
+	res.WriteString(fmt.Sprintf("func (u *%sUpdateOne) save(ctx context.Context, q db.Querier) (*%s, error) {\n", cls.Name, cls.Name))
+	res.WriteString("\twhere, err := u.where.predicate()\n")
+	res.WriteString("\tif err != nil {\n")
+	res.WriteString("\t\treturn nil, err\n")
+	res.WriteString("\t}\n")
+	res.WriteString(generateParentWrites("u"))
+	res.WriteString("\n")
+	res.WriteString("\tvar query string\n")
+	res.WriteString("\tvar args []interface{}\n")
+	res.WriteString("\tif len(u.data) == 0 && len(u.children) > 0 {\n")
+	res.WriteString(fmt.Sprintf("\t\tquery, args, err = db.Select(\"%s\", %sColumns...).Where(where).SQL()\n", cls.Name, lowerClass))
+	res.WriteString("\t} else {\n")
+	res.WriteString(fmt.Sprintf("\t\tquery, args, err = db.Update(\"%s\", %sColumns, u.data).Where(where).Returning(%sColumns...).SQL()\n", cls.Name, lowerClass, lowerClass))
+	res.WriteString("\t}\n")
+	res.WriteString("\tif err != nil {\n")
+	res.WriteString("\t\treturn nil, err\n")
+	res.WriteString("\t}\n\n")
+	res.WriteString(fmt.Sprintf("\titem, err := scan%s(q.QueryRow(ctx, query, args...))\n", cls.Name))
+	res.WriteString("\tif errors.Is(err, pgx.ErrNoRows) {\n")
+	res.WriteString(fmt.Sprintf("\t\treturn nil, &NotFoundError{Table: \"%s\"}\n", cls.Name))
+	res.WriteString("\t}\n")
+	res.WriteString("\tif err != nil {\n")
+	res.WriteString("\t\treturn nil, mapDatabaseError(err)\n")
+	res.WriteString("\t}\n")
+	res.WriteString(generateChildWrites("u"))
+	res.WriteString("\treturn item, nil\n")
+	res.WriteString("}\n\n")
+
+	return res.String()
+}
+
+func generateWhereUniquePredicate(cls *class.Class, ast *ast.SchemaAST) string {
+	var res strings.Builder
+
+	res.WriteString(fmt.Sprintf("func (w %sWhereUniqueInput) predicate() (Predicate, error) {\n", cls.Name))
+	res.WriteString("\tvar predicates []Predicate\n")
+
+	for _, fields := range uniqueInputMembers(cls) {
+		var nameParts []string
+		for _, fieldName := range fields {
+			nameParts = append(nameParts, utils.ToExportedName(fieldName))
+		}
+		inputField := strings.Join(nameParts, "")
+
+		res.WriteString(fmt.Sprintf("\tif w.%s != nil {\n", inputField))
+		if len(fields) == 1 {
+			res.WriteString(fmt.Sprintf("\t\tpredicates = append(predicates, db.EQ(db.Column(%q, %q), *w.%s))\n", cls.Name, fields[0], inputField))
+		} else {
+			var conditions []string
+			for _, fieldName := range fields {
+				conditions = append(conditions, fmt.Sprintf("db.EQ(db.Column(%q, %q), w.%s.%s)", cls.Name, fieldName, inputField, utils.ToExportedName(fieldName)))
+			}
+			res.WriteString(fmt.Sprintf("\t\tpredicates = append(predicates, db.And(%s))\n", strings.Join(conditions, ", ")))
+		}
+		res.WriteString("\t}\n")
+	}
+
+	res.WriteString("\tif len(predicates) == 0 {\n")
+	res.WriteString(fmt.Sprintf("\t\treturn nil, fmt.Errorf(\"%sWhereUniqueInput requires at least one unique field\")\n", cls.Name))
+	res.WriteString("\t}\n")
+	res.WriteString("\treturn db.And(predicates...), nil\n")
+	res.WriteString("}\n\n")
+
+	return res.String()
+}
+
+func uniqueInputMembers(cls *class.Class) [][]string {
+	var members [][]string
+	seen := make(map[string]bool)
+
+	add := func(fields []string) {
+		sorted := make([]string, len(fields))
+		copy(sorted, fields)
+		sort.Strings(sorted)
+		key := strings.Join(sorted, ",")
+		if seen[key] {
+			return
+		}
+		seen[key] = true
+		members = append(members, sorted)
+	}
+
+	if cls.HasPrimaryKey() {
+		add(cls.GetPrimaryKeyFields())
+	}
+
+	for _, fld := range cls.Attributes.Fields {
+		if fld.IsUnique() {
+			add([]string{fld.GetName()})
+		}
+	}
+
+	for _, directive := range cls.Attributes.Directives {
+		if directive.Name == constants.CLASS_ATTR_UNIQUE {
+			fields, err := directive.GetFields()
+			if err != nil || len(fields) == 0 {
+				continue
+			}
+			add(fields)
+		}
+	}
+
+	return members
+}
+
+func GenerateUpsertBuilder(cls *class.Class, ast *ast.SchemaAST) string {
+	var res strings.Builder
+	lowerClass := cases.Lower(language.English).String(cls.Name)
+
+	res.WriteString(generateWhereUniqueConflict(cls))
+
+	res.WriteString(fmt.Sprintf("type %sUpsert struct {\n", cls.Name))
+	res.WriteString(fmt.Sprintf("\tclient *%sClient\n", cls.Name))
+	res.WriteString(fmt.Sprintf("\twhere %sWhereUniqueInput\n", cls.Name))
+	res.WriteString("\ttarget []string\n")
+	res.WriteString("\tcreate map[string]interface{}\n")
+	res.WriteString("\tupdate map[string]interface{}\n")
+	res.WriteString("}\n\n")
+
+	for _, fields := range uniqueInputMembers(cls) {
+		var nameParts []string
+		var quoted []string
+		for _, fieldName := range fields {
+			nameParts = append(nameParts, utils.ToExportedName(fieldName))
+			quoted = append(quoted, fmt.Sprintf("%q", fieldName))
+		}
+
+		res.WriteString(fmt.Sprintf("func (u *%sUpsert) OnConflict%s() *%sUpsert {\n", cls.Name, strings.Join(nameParts, ""), cls.Name))
+		res.WriteString(fmt.Sprintf("\tu.target = []string{%s}\n", strings.Join(quoted, ", ")))
+		res.WriteString("\treturn u\n")
+		res.WriteString("}\n\n")
+	}
+
+	for _, fld := range cls.Attributes.Fields {
+		if fld.IsObject() || fld.IsComputed() {
+			continue
+		}
+		fieldName := utils.ToExportedName(fld.GetName())
+		goType := utils.GetGoType(fld, ast)
+
+		res.WriteString(fmt.Sprintf("func (u *%sUpsert) Create%s(value %s) *%sUpsert {\n",
+			cls.Name, fieldName, goType, cls.Name))
+		res.WriteString("\tif u.create == nil {\n")
+		res.WriteString("\t\tu.create = make(map[string]interface{})\n")
+		res.WriteString("\t}\n")
+		res.WriteString(fmt.Sprintf("\tu.create[\"%s\"] = value\n", fld.GetName()))
+		res.WriteString("\treturn u\n")
+		res.WriteString("}\n\n")
+
+		if fld.IsPrimaryKey() {
+			continue
+		}
+
+		res.WriteString(fmt.Sprintf("func (u *%sUpsert) Update%s(value %s) *%sUpsert {\n",
+			cls.Name, fieldName, goType, cls.Name))
+		res.WriteString("\tif u.update == nil {\n")
+		res.WriteString("\t\tu.update = make(map[string]interface{})\n")
+		res.WriteString("\t}\n")
+		res.WriteString(fmt.Sprintf("\tu.update[\"%s\"] = value\n", fld.GetName()))
+		res.WriteString("\treturn u\n")
+		res.WriteString("}\n\n")
+	}
+
+	var required []string
+	for _, fld := range cls.Attributes.Fields {
+		if isRequiredOnCreate(fld, ast) {
+			required = append(required, fmt.Sprintf("%q", fld.GetName()))
+		}
+	}
+
+	res.WriteString(fmt.Sprintf("func (u *%sUpsert) Save() (*%s, error) {\n", cls.Name, cls.Name))
+	res.WriteString("\ttarget, unique, err := u.where.conflict()\n")
+	res.WriteString("\tif err != nil {\n")
+	res.WriteString("\t\treturn nil, err\n")
+	res.WriteString("\t}\n")
+	res.WriteString("\tvalues := make(map[string]interface{}, len(u.create)+len(unique))\n")
+	res.WriteString("\tfor column, value := range u.create {\n")
+	res.WriteString("\t\tvalues[column] = value\n")
+	res.WriteString("\t}\n")
+	res.WriteString("\tfor column, value := range unique {\n")
+	res.WriteString("\t\tvalues[column] = value\n")
+	res.WriteString("\t}\n")
+	if len(required) > 0 {
+		res.WriteString(fmt.Sprintf("\tif missing := db.MissingColumns(values, []string{%s}); len(missing) > 0 {\n", strings.Join(required, ", ")))
+		res.WriteString(fmt.Sprintf("\t\treturn nil, fmt.Errorf(\"cannot upsert %s: missing required create fields %%v\", missing)\n", cls.Name))
+		res.WriteString("\t}\n")
+	}
+	res.WriteString("\n")
+	res.WriteString("\tif u.target != nil {\n")
+	res.WriteString("\t\ttarget = u.target\n")
+	res.WriteString("\t}\n")
+	res.WriteString(fmt.Sprintf("\tquery, args, err := db.Insert(\"%s\", %sColumns, values).Returning(%sColumns...).OnConflict(target, u.update).SQL()\n", cls.Name, lowerClass, lowerClass))
+	res.WriteString("\tif err != nil {\n")
+	res.WriteString("\t\treturn nil, err\n")
+	res.WriteString("\t}\n\n")
+	res.WriteString(fmt.Sprintf("\titem, err := scan%s(u.client.db.Pool.QueryRow(u.client.db.Context(), query, args...))\n", cls.Name))
+	res.WriteString("\tif err != nil {\n")
+	res.WriteString("\t\treturn nil, mapDatabaseError(err)\n")
+	res.WriteString("\t}\n")
+	res.WriteString("\treturn item, nil\n")
+	res.WriteString("}\n\n")
+
+	return res.String()
+}
+
+func generateWhereUniqueConflict(cls *class.Class) string {
+	var res strings.Builder
+
+	res.WriteString(fmt.Sprintf("func (w %sWhereUniqueInput) conflict() ([]string, map[string]interface{}, error) {\n", cls.Name))
+	res.WriteString("\tvar target []string\n")
+	res.WriteString("\tvalues := make(map[string]interface{})\n")
+
+	for _, fields := range uniqueInputMembers(cls) {
+		var nameParts []string
+		var quoted []string
+		for _, fieldName := range fields {
+			nameParts = append(nameParts, utils.ToExportedName(fieldName))
+			quoted = append(quoted, fmt.Sprintf("%q", fieldName))
+		}
+		inputField := strings.Join(nameParts, "")
+
+		res.WriteString(fmt.Sprintf("\tif w.%s != nil {\n", inputField))
+		res.WriteString("\t\tif target != nil {\n")
+		res.WriteString(fmt.Sprintf("\t\t\treturn nil, nil, fmt.Errorf(\"%sWhereUniqueInput must set exactly one unique field for an upsert\")\n", cls.Name))
+		res.WriteString("\t\t}\n")
+		res.WriteString(fmt.Sprintf("\t\ttarget = []string{%s}\n", strings.Join(quoted, ", ")))
+		if len(fields) == 1 {
+			res.WriteString(fmt.Sprintf("\t\tvalues[%q] = *w.%s\n", fields[0], inputField))
+		} else {
+			for _, fieldName := range fields {
+				res.WriteString(fmt.Sprintf("\t\tvalues[%q] = w.%s.%s\n", fieldName, inputField, utils.ToExportedName(fieldName)))
+			}
+		}
+		res.WriteString("\t}\n")
+	}
+
+	res.WriteString("\tif target == nil {\n")
+	res.WriteString(fmt.Sprintf("\t\treturn nil, nil, fmt.Errorf(\"%sWhereUniqueInput requires at least one unique field\")\n", cls.Name))
+	res.WriteString("\t}\n")
+	res.WriteString("\treturn target, values, nil\n")
 	res.WriteString("}\n\n")
 
 	return res.String()
@@ -687,6 +1858,7 @@ func GenerateDeleteBuilder(cls *class.Class, ast *ast.SchemaAST) string {
 	res.WriteString(fmt.Sprintf("\tclient *%sClient\n", cls.Name))
 	res.WriteString("\tpredicates []Predicate\n")
 	res.WriteString(fmt.Sprintf("\twhere *%sWhereUniqueInput\n", cls.Name))
+	res.WriteString("\tall bool\n")
 	res.WriteString("}\n\n")
 
 	res.WriteString(fmt.Sprintf("func (d *%sDelete) Where(predicates ...Predicate) *%sDelete {\n", cls.Name, cls.Name))
@@ -694,11 +1866,37 @@ func GenerateDeleteBuilder(cls *class.Class, ast *ast.SchemaAST) string {
 	res.WriteString("\treturn d\n")
 	res.WriteString("}\n\n")
 
+	res.WriteString(fmt.Sprintf("func (d *%sDelete) All() *%sDelete {\n", cls.Name, cls.Name))
+	res.WriteString("\td.all = true\n")
+	res.WriteString("\treturn d\n")
+	res.WriteString("}\n\n")
+
 	res.WriteString(fmt.Sprintf("func (d *%sDelete) Exec() (int64, error) {\n", cls.Name))
-	res.WriteString("\tctx := d.client.db.Context()\n")
-	res.WriteString("\t_ = ctx\n")
-	res.WriteString("\t// TODO: Implement delete\n")
-	res.WriteString("\treturn 0, fmt.Errorf(\"not implemented\")\n")
+	res.WriteString(fmt.Sprintf("\tstmt := db.Delete(\"%s\")\n", cls.Name))
+	res.WriteString("\tif d.where != nil {\n")
+	res.WriteString("\t\twhere, err := d.where.predicate()\n")
+	res.WriteString("\t\tif err != nil {\n")
+	res.WriteString("\t\t\treturn 0, err\n")
+	res.WriteString("\t\t}\n")
+	res.WriteString("\t\tstmt.Where(where)\n")
+	res.WriteString("\t} else if len(d.predicates) == 0 && !d.all {\n")
+	res.WriteString(fmt.Sprintf("\t\treturn 0, fmt.Errorf(\"refusing to delete every %s row without a predicate, call All() to confirm\")\n", cls.Name))
+	res.WriteString("\t}\n")
+	res.WriteString("\tfor _, predicate := range d.predicates {\n")
+	res.WriteString("\t\tstmt.Where(predicate)\n")
+	res.WriteString("\t}\n\n")
+	res.WriteString("\tquery, args, err := stmt.SQL()\n")
+	res.WriteString("\tif err != nil {\n")
+	res.WriteString("\t\treturn 0, err\n")
+	res.WriteString("\t}\n\n")
+	res.WriteString("\ttag, err := d.client.db.Pool.Exec(d.client.db.Context(), query, args...)\n")
+	res.WriteString("\tif err != nil {\n")
+	res.WriteString("\t\treturn 0, mapDatabaseError(err)\n")
+	res.WriteString("\t}\n")
+	res.WriteString("\tif d.where != nil && tag.RowsAffected() == 0 {\n")
+	res.WriteString(fmt.Sprintf("\t\treturn 0, &NotFoundError{Table: \"%s\"}\n", cls.Name))
+	res.WriteString("\t}\n")
+	res.WriteString("\treturn tag.RowsAffected(), nil\n")
 	res.WriteString("}\n\n")
 
 	return res.String()
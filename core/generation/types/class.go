@@ -44,8 +44,6 @@ func (cg *ClassGenerator) Generate() string {
 		cg.main.WriteString("\n")
 	}
 
-	cg.generateRelationPermutations(mainType)
-
 	classInfo := ClassInfo{
 		Name:    cg.class.Name,
 		Type:    mainType,
@@ -229,14 +227,10 @@ func (cg *ClassGenerator) generateCompositeTypesInternal(content *strings.Builde
 		}
 	}
 
-	relationComposites := cg.generateRelationCompositeType(content)
-	compositeTypes = append(compositeTypes, relationComposites...)
-
-	return compositeTypes
+	return cg.generateRelationCompositeType(content, compositeTypes)
 }
 
-func (cg *ClassGenerator) generateRelationCompositeType(content *strings.Builder) []string {
-	var compositeTypes []string
+func (cg *ClassGenerator) generateRelationCompositeType(content *strings.Builder, compositeTypes []string) []string {
 	relationFields := cg.getRelationFields()
 
 	for _, relField := range relationFields {
@@ -347,81 +341,35 @@ func (cg *ClassGenerator) generateRelationsType() string {
 			utils.ToExportedName(fieldName),
 			fieldType))
 	}
-
+	cg.main.WriteString("\tloaded map[string]bool\n")
+	cg.main.WriteString("}\n\n")
+
+	cg.main.WriteString(fmt.Sprintf("func (r *%s) markLoaded(relation string) {\n", typeName))
+	cg.main.WriteString("\tif r.loaded == nil {\n")
+	cg.main.WriteString("\t\tr.loaded = make(map[string]bool)\n")
+	cg.main.WriteString("\t}\n")
+	cg.main.WriteString("\tr.loaded[relation] = true\n")
 	cg.main.WriteString("}\n")
-	return typeName
-}
-
-func (cg *ClassGenerator) generateRelationPermutations(mainType string) {
-	relationFields := cg.getRelationFields()
-	if len(relationFields) == 0 {
-		return
-	}
-
-	combinations := cg.getCombinations(relationFields)
-
-	for _, combo := range combinations {
-		cg.generatePermutationType(mainType, combo)
-		cg.main.WriteString("\n")
-	}
-}
-
-func (cg *ClassGenerator) generatePermutationType(mainType string, relations []*field.Field) {
-	relationNames := make([]string, len(relations))
-	for i, rel := range relations {
-		relationNames[i] = utils.ToExportedName(rel.GetName())
-	}
-	sort.Strings(relationNames)
 
-	typeName := mainType + "With" + strings.Join(relationNames, "And")
-
-	cg.main.WriteString(fmt.Sprintf("type %s struct {\n", typeName))
-	cg.main.WriteString(fmt.Sprintf("\t%s\n", mainType))
-
-	for _, rel := range relations {
-		relationType := utils.GetGoType(rel, cg.ast)
-		fieldName := rel.GetName()
-		cg.main.WriteString(fmt.Sprintf("\t%s %s\n",
-			utils.ToExportedName(fieldName),
-			relationType))
+	for _, field := range relationFields {
+		cg.main.WriteString(fmt.Sprintf("\nfunc (r %s) %sLoaded() bool {\n", typeName, utils.ToExportedName(field.GetName())))
+		cg.main.WriteString(fmt.Sprintf("\treturn r.loaded[%q]\n", field.GetName()))
+		cg.main.WriteString("}\n")
 	}
 
-	cg.main.WriteString("}\n")
+	return typeName
 }
 
 func (cg *ClassGenerator) getRelationFields() []*field.Field {
 	var relations []*field.Field
 	for _, f := range cg.class.Attributes.Fields {
-		baseType := f.GetBaseType()
-		if utils.GetScalarType(baseType) == "" && cg.ast.GetEnumByName(baseType) == nil {
+		if f.IsObject() {
 			relations = append(relations, f)
 		}
 	}
 	return relations
 }
 
-func (cg *ClassGenerator) getCombinations(relations []*field.Field) [][]*field.Field {
-	var result [][]*field.Field
-	n := len(relations)
-
-	for i := 1; i < (1 << n); i++ {
-		var combo []*field.Field
-		for j := 0; j < n; j++ {
-			if i&(1<<j) != 0 {
-				combo = append(combo, relations[j])
-			}
-		}
-
-		sort.Slice(combo, func(a, b int) bool {
-			return utils.ToExportedName(combo[a].GetName()) < utils.ToExportedName(combo[b].GetName())
-		})
-
-		result = append(result, combo)
-	}
-
-	return result
-}
-
 func (cg *ClassGenerator) getUniqueFields() []*field.Field {
 	var uniqueFields []*field.Field
 	seen := make(map[string]bool)
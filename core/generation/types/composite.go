@@ -0,0 +1,36 @@
+package types
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/rit3sh-x/blaze/core/ast"
+	"github.com/rit3sh-x/blaze/core/ast/composite"
+	"github.com/rit3sh-x/blaze/core/utils"
+)
+
+type CompositeTypeGenerator struct {
+	compositeType *composite.CompositeType
+	ast           *ast.SchemaAST
+}
+
+func NewCompositeTypeGenerator(c *composite.CompositeType, ast *ast.SchemaAST) *CompositeTypeGenerator {
+	return &CompositeTypeGenerator{
+		compositeType: c,
+		ast:           ast,
+	}
+}
+
+func (cg *CompositeTypeGenerator) Generate() string {
+	var content strings.Builder
+
+	content.WriteString(fmt.Sprintf("type %s struct {\n", cg.compositeType.Name))
+	for _, fld := range cg.compositeType.Fields {
+		content.WriteString(fmt.Sprintf("\t%s %s\n",
+			utils.ToExportedName(fld.Name),
+			utils.GetCompositeFieldGoType(fld, cg.ast)))
+	}
+	content.WriteString("}\n\n")
+
+	return content.String()
+}
@@ -0,0 +1,36 @@
+package types
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/rit3sh-x/blaze/core/ast"
+	"github.com/rit3sh-x/blaze/core/ast/view"
+	"github.com/rit3sh-x/blaze/core/utils"
+)
+
+type ViewGenerator struct {
+	view *view.View
+	ast  *ast.SchemaAST
+}
+
+func NewViewGenerator(v *view.View, ast *ast.SchemaAST) *ViewGenerator {
+	return &ViewGenerator{
+		view: v,
+		ast:  ast,
+	}
+}
+
+func (vg *ViewGenerator) Generate() string {
+	var content strings.Builder
+
+	content.WriteString(fmt.Sprintf("type %s struct {\n", vg.view.Name))
+	for _, fld := range utils.ViewAsClass(vg.view, vg.ast).Attributes.Fields {
+		content.WriteString(fmt.Sprintf("\t%s %s\n",
+			utils.ToExportedName(fld.GetName()),
+			utils.GetGoType(fld, vg.ast)))
+	}
+	content.WriteString("}\n\n")
+
+	return content.String()
+}
@@ -6,6 +6,8 @@ import (
 
 	"github.com/rit3sh-x/blaze/core/ast/class"
 	"github.com/rit3sh-x/blaze/core/ast/field"
+	"github.com/rit3sh-x/blaze/core/ast/field/native"
+	"github.com/rit3sh-x/blaze/core/constants"
 )
 
 func (me *MigrationEngine) generateTableAlterationSQL(oldClass, newClass *class.Class) ([]MigrationStatement, error) {
@@ -37,6 +39,9 @@ func (me *MigrationEngine) generateTableAlterationSQL(oldClass, newClass *class.
 	}
 
 	for _, oldField := range oldClass.Attributes.Fields {
+		if oldField.HasRelation() {
+			continue
+		}
 		if _, exists := newFields[oldField.GetName()]; !exists {
 			columnName := applyQuotes(oldField.GetName())
 			statements = append(statements, MigrationStatement{
@@ -48,7 +53,10 @@ func (me *MigrationEngine) generateTableAlterationSQL(oldClass, newClass *class.
 	}
 
 	for _, newField := range newClass.Attributes.Fields {
-		if oldField, exists := oldFields[newField.GetName()]; exists {
+		if newField.HasRelation() {
+			continue
+		}
+		if oldField, exists := oldFields[newField.GetName()]; exists && !oldField.HasRelation() {
 			alterStatements := me.generateColumnAlterationSQL(tableName, oldField, newField, oldClass, newClass)
 			statements = append(statements, alterStatements...)
 		}
@@ -61,16 +69,50 @@ func (me *MigrationEngine) generateColumnAlterationSQL(tableName string, oldFiel
 	var statements []MigrationStatement
 	columnName := applyQuotes(newField.GetName())
 
+	if newField.IsComputed() && (!oldField.IsComputed() || oldField.GetComputedExpression() != newField.GetComputedExpression()) {
+		columnDef, possible := me.generateColumnDefinition(newField, newClass)
+		if !possible {
+			return statements
+		}
+		statements = append(statements, MigrationStatement{
+			SQL:      fmt.Sprintf("ALTER TABLE %s DROP COLUMN IF EXISTS %s", tableName, columnName),
+			Type:     "column_drop",
+			Priority: 8,
+		})
+		statements = append(statements, MigrationStatement{
+			SQL:      fmt.Sprintf("ALTER TABLE %s ADD COLUMN %s", tableName, columnDef),
+			Type:     "column_recreate",
+			Priority: 9,
+		})
+		return statements
+	}
+
+	if oldField.IsComputed() && !newField.IsComputed() {
+		statements = append(statements, MigrationStatement{
+			SQL:      fmt.Sprintf("ALTER TABLE %s ALTER COLUMN %s DROP EXPRESSION", tableName, columnName),
+			Type:     "column_drop_expression",
+			Priority: 9,
+		})
+	}
+
 	oldType, _ := me.mapToPGType(oldField, oldClass)
 	newType, _ := me.mapToPGType(newField, newClass)
 
 	if oldType != newType || oldField.IsArray() != newField.IsArray() {
+		if narrowingFamily(oldField.GetBaseType()) == narrowingFamily(newField.GetBaseType()) && native.IsNarrowing(newField.GetBaseType(), oldField.GetNativeType(), newField.GetNativeType()) {
+			me.warnings = append(me.warnings, fmt.Sprintf("narrowing %s.%s from %s to %s may fail or lose data for existing rows", newClass.Name, newField.GetName(), oldType, newType))
+		}
+
 		targetType := newType
 		if newField.IsArray() {
 			targetType += "[]"
 		}
+		using := ""
+		if newType == "UUID" && oldType != newType {
+			using = fmt.Sprintf(" USING %s::%s", columnName, targetType)
+		}
 		statements = append(statements, MigrationStatement{
-			SQL:      fmt.Sprintf("ALTER TABLE %s ALTER COLUMN %s TYPE %s", tableName, columnName, targetType),
+			SQL:      fmt.Sprintf("ALTER TABLE %s ALTER COLUMN %s TYPE %s%s", tableName, columnName, targetType, using),
 			Type:     "column_alter_type",
 			Priority: 9,
 		})
@@ -92,19 +134,43 @@ func (me *MigrationEngine) generateColumnAlterationSQL(tableName string, oldFiel
 		}
 	}
 
-	oldHasDefault := oldField.HasDefault()
-	newHasDefault := newField.HasDefault()
+	oldDefault, newDefault := "", ""
+	if oldField.HasDefault() {
+		oldDefault = me.generateDefaultValue(oldField, oldClass)
+	}
+	if newField.HasDefault() {
+		newDefault = me.generateDefaultValue(newField, newClass)
+	}
+
+	if oldDefault == newDefault {
+		return statements
+	}
+
+	if isAutoincrement(oldField) {
+		statements = append(statements, MigrationStatement{
+			SQL:      fmt.Sprintf("ALTER TABLE %s ALTER COLUMN %s DROP IDENTITY IF EXISTS", tableName, columnName),
+			Type:     "column_drop_identity",
+			Priority: 11,
+		})
+	}
 
-	if oldHasDefault && !newHasDefault {
+	if oldDefault != "" && !me.usesIdentity(oldField) && (newDefault == "" || me.usesIdentity(newField)) {
 		statements = append(statements, MigrationStatement{
 			SQL:      fmt.Sprintf("ALTER TABLE %s ALTER COLUMN %s DROP DEFAULT", tableName, columnName),
 			Type:     "column_drop_default",
 			Priority: 11,
 		})
-	} else if newHasDefault {
-		defaultValue := me.generateDefaultValue(newField)
+	}
+
+	if me.usesIdentity(newField) {
+		statements = append(statements, MigrationStatement{
+			SQL:      fmt.Sprintf("ALTER TABLE %s ALTER COLUMN %s ADD GENERATED BY DEFAULT AS IDENTITY", tableName, columnName),
+			Type:     "column_add_identity",
+			Priority: 11,
+		})
+	} else if newDefault != "" {
 		statements = append(statements, MigrationStatement{
-			SQL:      fmt.Sprintf("ALTER TABLE %s ALTER COLUMN %s SET DEFAULT %s", tableName, columnName, defaultValue),
+			SQL:      fmt.Sprintf("ALTER TABLE %s ALTER COLUMN %s SET DEFAULT %s", tableName, columnName, me.generateDefaultExpression(newField, newClass)),
 			Type:     "column_set_default",
 			Priority: 11,
 		})
@@ -116,7 +182,9 @@ func (me *MigrationEngine) generateColumnAlterationSQL(tableName string, oldFiel
 func (me *MigrationEngine) generateTableMigrations() ([]MigrationStatement, error) {
 	var statements []MigrationStatement
 
-	for _, oldClass := range me.fromSchema.Classes {
+	dropOrder, _ := me.fromSchema.GetDependencyOrder()
+	for i := len(dropOrder) - 1; i >= 0; i-- {
+		oldClass := dropOrder[i]
 		if me.toSchema.GetClassByName(oldClass.Name) == nil {
 			statements = append(statements, MigrationStatement{
 				SQL:      fmt.Sprintf(`DROP TABLE IF EXISTS "%s" CASCADE`, oldClass.Name),
@@ -126,7 +194,8 @@ func (me *MigrationEngine) generateTableMigrations() ([]MigrationStatement, erro
 		}
 	}
 
-	for _, newClass := range me.toSchema.Classes {
+	createOrder, _ := me.toSchema.GetDependencyOrder()
+	for _, newClass := range createOrder {
 		if me.fromSchema.GetClassByName(newClass.Name) == nil {
 			sql, err := me.generateCreateTableSQL(newClass)
 			if err != nil {
@@ -166,7 +235,7 @@ func (me *MigrationEngine) generateCreateTableSQL(cls *class.Class) (string, err
 		columns = append(columns, columnDef)
 	}
 
-	pkFields := cls.GetPrimaryKeyFields()
+	pkFields := cls.GetTablePrimaryKeyFields()
 	if len(pkFields) > 0 {
         pkColumns := make([]string, len(pkFields))
         for i, field := range pkFields {
@@ -190,14 +259,37 @@ func (me *MigrationEngine) generateCreateTableSQL(cls *class.Class) (string, err
 	if cls.Attributes.HasCheck() {
 		if checkDirective := cls.Attributes.GetCheckDirective(); checkDirective != nil {
 			if constraint, err := checkDirective.GetConstraint(); err == nil {
-				constraints = append(constraints, fmt.Sprintf("CHECK (%s)", constraint))
+				constraints = append(constraints, fmt.Sprintf("CONSTRAINT %s CHECK (%s)", checkConstraintName(cls), constraint))
 			}
 		}
 	}
 
+	for _, exclusion := range cls.Attributes.GetExclusions() {
+		constraints = append(constraints, exclusion.SQL(cls.Name))
+	}
+
+	for _, field := range cls.Attributes.Fields {
+		if !field.HasRelation() || me.isCyclicReference(cls.Name, field.GetName()) {
+			continue
+		}
+		constraints = append(constraints, fmt.Sprintf("CONSTRAINT %s %s", foreignKeyName(cls, field), me.generateForeignKeyClause(field.AttributeDefinition.Relation)))
+	}
+
 	var parts []string
 	parts = append(parts, columns...)
 	parts = append(parts, constraints...)
 
-	return fmt.Sprintf("CREATE TABLE %s (\n  %s\n)", tableName, strings.Join(parts, ",\n  ")), nil
+	sql := fmt.Sprintf("CREATE TABLE %s (\n  %s\n)", tableName, strings.Join(parts, ",\n  "))
+	if partitionBy := cls.Attributes.GetPartitionBy(); partitionBy != nil {
+		sql += " " + partitionBy.SQL()
+	}
+
+	return sql, nil
+}
+
+func narrowingFamily(baseType string) string {
+	if baseType == constants.DECIMAL.String() {
+		return constants.NUMERIC.String()
+	}
+	return baseType
 }
\ No newline at end of file
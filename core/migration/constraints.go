@@ -3,116 +3,282 @@ package migration
 import (
 	"fmt"
 	"strings"
+
+	"github.com/rit3sh-x/blaze/core/ast/class"
+	"github.com/rit3sh-x/blaze/core/ast/class/directives"
+	"github.com/rit3sh-x/blaze/core/ast/field"
+	"github.com/rit3sh-x/blaze/core/ast/field/relations"
 )
 
 func (me *MigrationEngine) generateConstraintMigrations() ([]MigrationStatement, error) {
 	var statements []MigrationStatement
 
 	for _, newClass := range me.toSchema.Classes {
+		oldClass := me.fromSchema.GetClassByName(newClass.Name)
+
+		oldRelations := make(map[string]*relations.Relation)
+		if oldClass != nil {
+			for _, oldField := range oldClass.Attributes.Fields {
+				if oldField.HasRelation() {
+					oldRelations[foreignKeyName(oldClass, oldField)] = oldField.AttributeDefinition.Relation
+				}
+			}
+		}
+
+		newRelations := make(map[string]bool)
+
 		for _, field := range newClass.Attributes.Fields {
-			if field.HasRelation() {
-				relation := field.AttributeDefinition.Relation
-				if relation != nil {
-					fkName := fmt.Sprintf("fk_%s_%s", strings.ToLower(newClass.Name), strings.ToLower(field.GetName()))
-					referencedTable := applyQuotes(relation.ToClass)
-
-					sourceColumns := make([]string, len(relation.From))
-					for i, col := range relation.From {
-                        sourceColumns[i] = applyQuotes(col)
-                    }
-
-					targetColumns := make([]string, len(relation.To))
-					for i, col := range relation.To {
-                        targetColumns[i] = applyQuotes(col)
-                    }
-
-					var constraintParts []string
-					constraintParts = append(constraintParts, fmt.Sprintf("FOREIGN KEY (%s)", strings.Join(sourceColumns, ", ")))
-					constraintParts = append(constraintParts, fmt.Sprintf("REFERENCES %s (%s)", referencedTable, strings.Join(targetColumns, ", ")))
-
-					if relation.HasOnDelete() {
-						constraintParts = append(constraintParts, fmt.Sprintf("ON DELETE %s", me.mapConstraintAction(relation.OnDelete)))
-					}
+			if !field.HasRelation() {
+				continue
+			}
 
-					if relation.HasOnUpdate() {
-						constraintParts = append(constraintParts, fmt.Sprintf("ON UPDATE %s", me.mapConstraintAction(relation.OnUpdate)))
-					}
+			relation := field.AttributeDefinition.Relation
+			fkName := foreignKeyName(newClass, field)
+			newRelations[fkName] = true
 
+			if oldClass == nil {
+				if me.isCyclicReference(newClass.Name, field.GetName()) {
 					statements = append(statements, MigrationStatement{
-						SQL:      fmt.Sprintf(`ALTER TABLE "%s" ADD CONSTRAINT %s %s`, newClass.Name, fkName, strings.Join(constraintParts, " ")),
+						SQL:      fmt.Sprintf(`ALTER TABLE "%s" ADD CONSTRAINT %s %s`, newClass.Name, fkName, me.generateForeignKeyClause(relation)),
 						Type:     "constraint_add",
 						Priority: 14,
 					})
+				}
+				if indexStatement, ok := me.generateForeignKeyIndex(newClass, field); ok {
+					statements = append(statements, indexStatement)
+				}
+				continue
+			}
 
-					shouldCreateIndex := true
+			oldRelation, exists := oldRelations[fkName]
+			if exists && me.generateForeignKeyClause(oldRelation) == me.generateForeignKeyClause(relation) {
+				continue
+			}
 
-					if len(sourceColumns) == 1 && field.IsUnique() {
-						shouldCreateIndex = false
-					}
+			if exists {
+				statements = append(statements, MigrationStatement{
+					SQL:      fmt.Sprintf(`ALTER TABLE "%s" DROP CONSTRAINT IF EXISTS %s`, newClass.Name, fkName),
+					Type:     "constraint_drop",
+					Priority: 5,
+				})
+			}
 
-					if shouldCreateIndex && newClass.Attributes.HasUnique() {
-						if uniqueDirective := newClass.Attributes.GetUniqueDirective(); uniqueDirective != nil {
-							if uniqueFields, err := uniqueDirective.GetFields(); err == nil {
-								sourceSet := make(map[string]bool)
-								for _, col := range sourceColumns {
-									sourceSet[col] = true
-								}
-
-								uniqueSet := make(map[string]bool)
-								for _, col := range uniqueFields {
-									uniqueSet[col] = true
-								}
-
-								allSourceInUnique := true
-								for _, sourceCol := range sourceColumns {
-									if !uniqueSet[sourceCol] {
-										allSourceInUnique = false
-										break
-									}
-								}
-
-								if allSourceInUnique {
-									shouldCreateIndex = false
-								}
-							}
-						}
-					}
+			statements = append(statements, me.generateValidatedConstraint(newClass.Name, fkName, me.generateForeignKeyClause(relation))...)
 
-					if shouldCreateIndex {
-						pkFields := newClass.GetPrimaryKeyFields()
-						pkSet := make(map[string]bool)
-						for _, pkField := range pkFields {
-							pkSet[pkField] = true
-						}
-
-						allInPK := len(sourceColumns) > 0
-						for _, sourceCol := range sourceColumns {
-							if !pkSet[sourceCol] {
-								allInPK = false
-								break
-							}
-						}
-						if allInPK {
-							shouldCreateIndex = false
-						}
-					}
+			if !exists || strings.Join(oldRelation.From, ",") != strings.Join(relation.From, ",") {
+				if indexStatement, ok := me.generateForeignKeyIndex(newClass, field); ok {
+					statements = append(statements, me.applyConcurrently(indexStatement)...)
+				}
+			}
+		}
 
-					if shouldCreateIndex {
-                        indexColumns := make([]string, len(relation.From))
-                        for i, col := range relation.From {
-                            indexColumns[i] = applyQuotes(col)
-                        }
-                        indexName := fmt.Sprintf("idx_%s_%s", strings.ToLower(newClass.Name), strings.ToLower(strings.Join(relation.From, "_")))
-                        statements = append(statements, MigrationStatement{
-                            SQL:      fmt.Sprintf(`CREATE INDEX %s ON "%s" (%s)`, indexName, newClass.Name, strings.Join(indexColumns, ", ")),
-                            Type:     "fk_index_create",
-                            Priority: 14,
-                        })
-                    }
+		if oldClass != nil {
+			statements = append(statements, me.generateCheckMigrations(oldClass, newClass)...)
+			statements = append(statements, me.generateExclusionMigrations(oldClass, newClass)...)
+
+			for _, oldField := range oldClass.Attributes.Fields {
+				if !oldField.HasRelation() {
+					continue
+				}
+				fkName := foreignKeyName(oldClass, oldField)
+				if !newRelations[fkName] {
+					statements = append(statements, MigrationStatement{
+						SQL:      fmt.Sprintf(`ALTER TABLE "%s" DROP CONSTRAINT IF EXISTS %s`, newClass.Name, fkName),
+						Type:     "constraint_drop",
+						Priority: 5,
+					})
 				}
 			}
 		}
 	}
 
 	return statements, nil
-}
\ No newline at end of file
+}
+
+func (me *MigrationEngine) generateCheckMigrations(oldClass, newClass *class.Class) []MigrationStatement {
+	var statements []MigrationStatement
+
+	oldCheck := ""
+	if checkDirective := oldClass.Attributes.GetCheckDirective(); checkDirective != nil {
+		oldCheck, _ = checkDirective.GetConstraint()
+	}
+
+	newCheck := ""
+	if checkDirective := newClass.Attributes.GetCheckDirective(); checkDirective != nil {
+		newCheck, _ = checkDirective.GetConstraint()
+	}
+
+	if oldCheck == newCheck {
+		return statements
+	}
+
+	constraintName := checkConstraintName(newClass)
+
+	if oldCheck != "" {
+		statements = append(statements, MigrationStatement{
+			SQL:      fmt.Sprintf(`ALTER TABLE "%s" DROP CONSTRAINT IF EXISTS %s`, newClass.Name, constraintName),
+			Type:     "constraint_drop",
+			Priority: 5,
+		})
+	}
+
+	if newCheck != "" {
+		statements = append(statements, me.generateValidatedConstraint(newClass.Name, constraintName, fmt.Sprintf("CHECK (%s)", newCheck))...)
+	}
+
+	return statements
+}
+
+func (me *MigrationEngine) generateExclusionMigrations(oldClass, newClass *class.Class) []MigrationStatement {
+	var statements []MigrationStatement
+
+	oldExclusions := make(map[string]*directives.Exclusion)
+	for _, exclusion := range oldClass.Attributes.GetExclusions() {
+		oldExclusions[exclusion.GetName(oldClass.Name)] = exclusion
+	}
+
+	newExclusions := make(map[string]bool)
+	for _, exclusion := range newClass.Attributes.GetExclusions() {
+		constraintName := exclusion.GetName(newClass.Name)
+		newExclusions[constraintName] = true
+
+		oldExclusion, exists := oldExclusions[constraintName]
+		if exists && oldExclusion.Equals(exclusion) {
+			continue
+		}
+
+		if exists {
+			statements = append(statements, me.generateDropExclusionStatement(newClass, constraintName))
+		}
+
+		statements = append(statements, MigrationStatement{
+			SQL:      fmt.Sprintf(`ALTER TABLE "%s" ADD %s`, newClass.Name, exclusion.SQL(newClass.Name)),
+			Type:     "constraint_add",
+			Priority: 14,
+		})
+	}
+
+	for _, exclusion := range oldClass.Attributes.GetExclusions() {
+		if constraintName := exclusion.GetName(oldClass.Name); !newExclusions[constraintName] {
+			statements = append(statements, me.generateDropExclusionStatement(newClass, constraintName))
+		}
+	}
+
+	return statements
+}
+
+func (me *MigrationEngine) generateDropExclusionStatement(cls *class.Class, constraintName string) MigrationStatement {
+	return MigrationStatement{
+		SQL:      fmt.Sprintf(`ALTER TABLE "%s" DROP CONSTRAINT IF EXISTS "%s"`, cls.Name, constraintName),
+		Type:     "constraint_drop",
+		Priority: 5,
+	}
+}
+
+func checkConstraintName(cls *class.Class) string {
+	return fmt.Sprintf("chk_%s", strings.ToLower(cls.Name))
+}
+
+func foreignKeyName(cls *class.Class, field *field.Field) string {
+	return fmt.Sprintf("fk_%s_%s", strings.ToLower(cls.Name), strings.ToLower(field.GetName()))
+}
+
+func (me *MigrationEngine) isCyclicReference(className string, fieldName string) bool {
+	if me.cycles == nil {
+		me.cycles = make(map[string]bool)
+		_, cycles := me.toSchema.GetDependencyOrder()
+		for _, ref := range cycles {
+			me.cycles[ref.Class+"."+ref.Field] = true
+		}
+	}
+	return me.cycles[className+"."+fieldName]
+}
+
+func (me *MigrationEngine) generateForeignKeyClause(relation *relations.Relation) string {
+	sourceColumns := make([]string, len(relation.From))
+	for i, col := range relation.From {
+		sourceColumns[i] = applyQuotes(col)
+	}
+
+	targetColumns := make([]string, len(relation.To))
+	for i, col := range relation.To {
+		targetColumns[i] = applyQuotes(col)
+	}
+
+	var constraintParts []string
+	constraintParts = append(constraintParts, fmt.Sprintf("FOREIGN KEY (%s)", strings.Join(sourceColumns, ", ")))
+	constraintParts = append(constraintParts, fmt.Sprintf("REFERENCES %s (%s)", applyQuotes(relation.ToClass), strings.Join(targetColumns, ", ")))
+
+	if relation.HasOnDelete() {
+		constraintParts = append(constraintParts, fmt.Sprintf("ON DELETE %s", me.mapConstraintAction(relation.OnDelete)))
+	}
+
+	if relation.HasOnUpdate() {
+		constraintParts = append(constraintParts, fmt.Sprintf("ON UPDATE %s", me.mapConstraintAction(relation.OnUpdate)))
+	}
+
+	if relation.Deferrable {
+		constraintParts = append(constraintParts, "DEFERRABLE INITIALLY DEFERRED")
+	}
+
+	return strings.Join(constraintParts, " ")
+}
+
+func (me *MigrationEngine) generateForeignKeyIndex(cls *class.Class, field *field.Field) (MigrationStatement, bool) {
+	relation := field.AttributeDefinition.Relation
+
+	if len(relation.From) == 1 && field.IsUnique() {
+		return MigrationStatement{}, false
+	}
+
+	if cls.Attributes.HasUnique() {
+		if uniqueDirective := cls.Attributes.GetUniqueDirective(); uniqueDirective != nil {
+			if uniqueFields, err := uniqueDirective.GetFields(); err == nil {
+				uniqueSet := make(map[string]bool)
+				for _, col := range uniqueFields {
+					uniqueSet[col] = true
+				}
+
+				allSourceInUnique := true
+				for _, sourceCol := range relation.From {
+					if !uniqueSet[sourceCol] {
+						allSourceInUnique = false
+						break
+					}
+				}
+
+				if allSourceInUnique {
+					return MigrationStatement{}, false
+				}
+			}
+		}
+	}
+
+	pkSet := make(map[string]bool)
+	for _, pkField := range cls.GetPrimaryKeyFields() {
+		pkSet[pkField] = true
+	}
+
+	allInPK := len(relation.From) > 0
+	for _, sourceCol := range relation.From {
+		if !pkSet[sourceCol] {
+			allInPK = false
+			break
+		}
+	}
+	if allInPK {
+		return MigrationStatement{}, false
+	}
+
+	indexColumns := make([]string, len(relation.From))
+	for i, col := range relation.From {
+		indexColumns[i] = applyQuotes(col)
+	}
+	indexName := fmt.Sprintf("idx_%s_%s", strings.ToLower(cls.Name), strings.ToLower(strings.Join(relation.From, "_")))
+
+	return MigrationStatement{
+		SQL:      fmt.Sprintf(`CREATE INDEX %s ON "%s" (%s)`, indexName, cls.Name, strings.Join(indexColumns, ", ")),
+		Type:     "fk_index_create",
+		Priority: 14,
+	}, true
+}
@@ -6,32 +6,66 @@ import (
 	"strings"
 
 	"github.com/rit3sh-x/blaze/core/ast"
+	"github.com/rit3sh-x/blaze/core/constants"
 )
 
 type MigrationEngine struct {
 	fromSchema *ast.SchemaAST
 	toSchema   *ast.SchemaAST
 	statements []string
+	warnings   []string
+	options    MigrationOptions
+	cycles     map[string]bool
+}
+
+type MigrationOptions struct {
+	Concurrent       bool
+	LockTimeout      string
+	StatementTimeout string
+	Autoincrement    string
 }
 
 type MigrationStatement struct {
-	SQL      string
-	Type     string
-	Priority int
+	SQL              string
+	Type             string
+	Priority         int
+	NonTransactional bool
 }
 
 func NewMigrationEngine(fromSchema *ast.SchemaAST, toSchema *ast.SchemaAST) *MigrationEngine {
+	return NewMigrationEngineWithOptions(fromSchema, toSchema, MigrationOptions{})
+}
+
+func NewMigrationEngineWithOptions(fromSchema *ast.SchemaAST, toSchema *ast.SchemaAST, options MigrationOptions) *MigrationEngine {
 	return &MigrationEngine{
 		fromSchema: fromSchema,
 		toSchema:   toSchema,
 		statements: []string{},
+		warnings:   []string{},
+		options:    options,
+	}
+}
+
+func (me *MigrationEngine) Warnings() []string {
+	return me.warnings
+}
+
+func (me *MigrationEngine) collectLegacyUUIDWarnings() {
+	for _, cls := range me.toSchema.Classes {
+		for _, field := range cls.Attributes.Fields {
+			if me.isLegacyUUIDField(field, cls) {
+				me.warnings = append(me.warnings, fmt.Sprintf("%s.%s is a String stored as UUID; declare it as Uuid instead", cls.Name, field.GetName()))
+			}
+		}
 	}
 }
 
 func (me *MigrationEngine) GenerateMigration() (string, error) {
 	var statements []MigrationStatement
 
+	statements = append(statements, me.generateSessionSettings()...)
 	statements = append(statements, me.generateExtensions()...)
+	statements = append(statements, me.generateViewDrops()...)
 
 	enumStatements, err := me.generateEnumMigrations()
 	if err != nil {
@@ -39,12 +73,27 @@ func (me *MigrationEngine) GenerateMigration() (string, error) {
 	}
 	statements = append(statements, enumStatements...)
 
+	domainStatements, err := me.generateDomainMigrations()
+	if err != nil {
+		return "", fmt.Errorf("failed to generate domain migrations: %v", err)
+	}
+	statements = append(statements, domainStatements...)
+	statements = append(statements, me.generateCompositeMigrations()...)
+
+	statements = append(statements, me.generateSequenceMigrations()...)
+
 	tableStatements, err := me.generateTableMigrations()
 	if err != nil {
 		return "", fmt.Errorf("failed to generate table migrations: %v", err)
 	}
 	statements = append(statements, tableStatements...)
 
+	partitionStatements, err := me.generatePartitionMigrations()
+	if err != nil {
+		return "", fmt.Errorf("failed to generate partition migrations: %v", err)
+	}
+	statements = append(statements, partitionStatements...)
+
 	indexStatements, err := me.generateIndexMigrations()
 	if err != nil {
 		return "", fmt.Errorf("failed to generate index migrations: %v", err)
@@ -57,16 +106,104 @@ func (me *MigrationEngine) GenerateMigration() (string, error) {
 	}
 	statements = append(statements, constraintStatements...)
 
-	sort.Slice(statements, func(i, j int) bool {
+	statements = append(statements, me.generateTriggerMigrations()...)
+	statements = append(statements, me.generateViewMigrations()...)
+	statements = append(statements, me.generatePolicyMigrations()...)
+	statements = append(statements, me.generateCompositeDrops()...)
+	statements = append(statements, me.generateDomainDrops()...)
+
+	me.collectLegacyUUIDWarnings()
+
+	sort.SliceStable(statements, func(i, j int) bool {
 		return statements[i].Priority < statements[j].Priority
 	})
 
 	var sqlStatements []string
 	for _, stmt := range statements {
-		if stmt.SQL != "" {
+		if stmt.SQL == "" {
+			continue
+		}
+		if stmt.NonTransactional {
+			sqlStatements = append(sqlStatements, constants.NO_TRANSACTION_MARKER+"\n"+stmt.SQL)
+		} else {
 			sqlStatements = append(sqlStatements, stmt.SQL)
 		}
 	}
 
 	return strings.Join(sqlStatements, ";\n\n") + ";", nil
+}
+
+func (me *MigrationEngine) generateSessionSettings() []MigrationStatement {
+	var statements []MigrationStatement
+
+	if me.options.LockTimeout != "" {
+		statements = append(statements, MigrationStatement{
+			SQL:      fmt.Sprintf("SET lock_timeout = '%s'", me.options.LockTimeout),
+			Type:     "session_setting",
+			Priority: 0,
+		})
+	}
+
+	if me.options.StatementTimeout != "" {
+		statements = append(statements, MigrationStatement{
+			SQL:      fmt.Sprintf("SET statement_timeout = '%s'", me.options.StatementTimeout),
+			Type:     "session_setting",
+			Priority: 0,
+		})
+	}
+
+	return statements
+}
+
+func (me *MigrationEngine) applyConcurrently(stmt MigrationStatement) []MigrationStatement {
+	if !me.options.Concurrent {
+		return []MigrationStatement{stmt}
+	}
+
+	for _, prefix := range []string{"CREATE INDEX ", "CREATE UNIQUE INDEX "} {
+		if !strings.HasPrefix(stmt.SQL, prefix) {
+			continue
+		}
+		definition := strings.TrimPrefix(stmt.SQL, prefix)
+		indexName := strings.Fields(definition)[0]
+
+		drop := stmt
+		drop.SQL = "DROP INDEX CONCURRENTLY IF EXISTS " + indexName
+		drop.Type = "index_drop"
+		drop.NonTransactional = true
+
+		stmt.SQL = strings.TrimSuffix(prefix, " ") + " CONCURRENTLY " + definition
+		stmt.NonTransactional = true
+		return []MigrationStatement{drop, stmt}
+	}
+
+	if strings.HasPrefix(stmt.SQL, "DROP INDEX ") {
+		stmt.SQL = "DROP INDEX CONCURRENTLY " + strings.TrimPrefix(stmt.SQL, "DROP INDEX ")
+		stmt.NonTransactional = true
+	}
+	return []MigrationStatement{stmt}
+}
+
+func (me *MigrationEngine) generateValidatedConstraint(tableName string, constraintName string, definition string) []MigrationStatement {
+	if !me.options.Concurrent {
+		return []MigrationStatement{{
+			SQL:      fmt.Sprintf(`ALTER TABLE "%s" ADD CONSTRAINT %s %s`, tableName, constraintName, definition),
+			Type:     "constraint_add",
+			Priority: 14,
+		}}
+	}
+
+	return []MigrationStatement{
+		{
+			SQL:      fmt.Sprintf(`ALTER TABLE "%s" ADD CONSTRAINT %s %s NOT VALID`, tableName, constraintName, definition),
+			Type:     "constraint_add",
+			Priority: 14,
+		},
+		{
+			SQL:              fmt.Sprintf(`ALTER TABLE "%s" VALIDATE CONSTRAINT %s`, tableName, constraintName),
+			Type:             "constraint_validate",
+			Priority:         15,
+			NonTransactional: true,
+		},
+	}
 }
\ No newline at end of file
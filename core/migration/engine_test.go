@@ -0,0 +1,1036 @@
+package migration
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/rit3sh-x/blaze/core/ast"
+	"github.com/rit3sh-x/blaze/core/utils"
+)
+
+func TestGenerateMigration(t *testing.T) {
+	tests := []struct {
+		name     string
+		from     string
+		to       string
+		options  MigrationOptions
+		want     []string
+		absent   []string
+		warnings []string
+		err      string
+	}{
+		{
+			name: "referenced tables are created first",
+			to: `class Post {
+  id Int @primaryKey
+  authorId Int
+  author User @relation([authorId], [id])
+}
+class User {
+  id Int @primaryKey
+  posts Post[]
+}`,
+			want: []string{
+				`CREATE TABLE "User"`,
+				`CREATE TABLE "Post"`,
+				`CONSTRAINT fk_post_author FOREIGN KEY ("authorId") REFERENCES "User" ("id")`,
+			},
+			absent: []string{`ALTER TABLE "Post" ADD CONSTRAINT`},
+		},
+		{
+			name: "cyclic foreign key is added after both tables",
+			to: `class User {
+  id Int @primaryKey
+  teamId Int?
+  team Team? @relation([teamId], [id], deferrable: true)
+  members Team[]
+}
+class Team {
+  id Int @primaryKey
+  ownerId Int
+  owner User @relation([ownerId], [id], deferrable: true)
+  users User[]
+}`,
+			want: []string{
+				`CREATE TABLE "User"`,
+				`CREATE TABLE "Team"`,
+				`CONSTRAINT fk_team_owner FOREIGN KEY ("ownerId") REFERENCES "User" ("id") DEFERRABLE INITIALLY DEFERRED`,
+				`ALTER TABLE "User" ADD CONSTRAINT fk_user_team FOREIGN KEY ("teamId") REFERENCES "Team" ("id") DEFERRABLE INITIALLY DEFERRED`,
+			},
+		},
+		{
+			name: "dropped tables go in reverse dependency order",
+			from: `class Post {
+  id Int @primaryKey
+  authorId Int
+  author User @relation([authorId], [id])
+}
+class User {
+  id Int @primaryKey
+  posts Post[]
+}`,
+			want: []string{
+				`DROP TABLE IF EXISTS "Post" CASCADE`,
+				`DROP TABLE IF EXISTS "User" CASCADE`,
+			},
+		},
+		{
+			name: "index method, sort order, partial, include and expression",
+			from: `class Doc {
+  id Int @primaryKey
+  title String
+  body String?
+  tags String[]
+  archived Boolean
+}`,
+			to: `class Doc {
+  id Int @primaryKey
+  title String
+  body String?
+  tags String[]
+  archived Boolean
+  @@index(fields: [title(sort: Desc), id], where: "archived = false", include: [body], name: "doc_live")
+  @@index([tags], type: Gin)
+  @@index([lower(title)])
+}`,
+			want: []string{
+				`CREATE INDEX doc_live ON "Doc" ("title" DESC, "id") INCLUDE ("body") WHERE archived = false`,
+				`CREATE INDEX idx_doc_tags_index ON "Doc" USING gin ("tags")`,
+				`CREATE INDEX idx_doc_lower_title_index ON "Doc" ((lower(title)))`,
+			},
+		},
+		{
+			name: "index matching the primary key is not skipped",
+			to: `class Item {
+  tenant String
+  code String
+  @@primaryKey([tenant, code])
+  @@index(fields: [tenant(sort: Desc), code])
+}`,
+			want: []string{`CREATE INDEX idx_item_tenant_code_index ON "Item" ("tenant" DESC, "code")`},
+		},
+		{
+			name: "concurrent index and foreign key",
+			from: `class User {
+  id Int @primaryKey
+}
+class Post {
+  id Int @primaryKey
+  authorId Int
+}`,
+			to: `class User {
+  id Int @primaryKey
+  posts Post[]
+}
+class Post {
+  id Int @primaryKey
+  authorId Int
+  author User @relation([authorId], [id])
+  @@index([authorId, id])
+}`,
+			options: MigrationOptions{Concurrent: true},
+			want: []string{
+				"-- blaze:no-transaction\nDROP INDEX CONCURRENTLY IF EXISTS idx_post_authorid_id_index;",
+				"-- blaze:no-transaction\nCREATE INDEX CONCURRENTLY idx_post_authorid_id_index ON \"Post\" (\"authorId\", \"id\");",
+				`ALTER TABLE "Post" ADD CONSTRAINT fk_post_author FOREIGN KEY ("authorId") REFERENCES "User" ("id") NOT VALID;`,
+				"-- blaze:no-transaction\nALTER TABLE \"Post\" VALIDATE CONSTRAINT fk_post_author;",
+			},
+			absent: []string{"IF NOT EXISTS"},
+		},
+		{
+			name: "concurrent index drop",
+			from: `class Post {
+  id Int @primaryKey
+  title String
+  @@index([title])
+}`,
+			to: `class Post {
+  id Int @primaryKey
+  title String
+}`,
+			options: MigrationOptions{Concurrent: true},
+			want:    []string{"-- blaze:no-transaction\nDROP INDEX CONCURRENTLY"},
+		},
+		{
+			name: "session settings come first",
+			from: `class Post {
+  id Int @primaryKey
+}`,
+			to: `class Post {
+  id Int @primaryKey
+  title String?
+}`,
+			options: MigrationOptions{LockTimeout: "5s", StatementTimeout: "0"},
+			want: []string{
+				"SET lock_timeout = '5s';",
+				"SET statement_timeout = '0';",
+				`ALTER TABLE "Post" ADD COLUMN "title" TEXT`,
+			},
+		},
+		{
+			name: "updatedAt triggers carry the column clock",
+			to: `class Event {
+  id Int @primaryKey
+  changedAt Timestamp @updatedAt
+  touchedAt TimestampTz @updatedAt
+  syncedAt TimestampTz @updatedAt @db.Timestamptz(6)
+}`,
+			want: []string{
+				"CREATE OR REPLACE FUNCTION blaze_set_updated_at() RETURNS TRIGGER AS $$",
+				"IF TG_ARGV[1] = 'timestamptz' THEN",
+				`"changedAt" TIMESTAMP(3) NOT NULL`,
+				`CREATE TRIGGER trg_event_changedat_updated_at BEFORE INSERT OR UPDATE ON "Event" FOR EACH ROW EXECUTE FUNCTION blaze_set_updated_at('changedAt', 'timestamp')`,
+				`CREATE TRIGGER trg_event_touchedat_updated_at BEFORE INSERT OR UPDATE ON "Event" FOR EACH ROW EXECUTE FUNCTION blaze_set_updated_at('touchedAt', 'timestamptz')`,
+				`CREATE TRIGGER trg_event_syncedat_updated_at BEFORE INSERT OR UPDATE ON "Event" FOR EACH ROW EXECUTE FUNCTION blaze_set_updated_at('syncedAt', 'timestamptz')`,
+			},
+			absent: []string{"pg_attribute", "DEFAULT"},
+		},
+		{
+			name: "changing the column clock recreates the trigger",
+			from: `class Event {
+  id Int @primaryKey
+  changedAt Timestamp @updatedAt
+}`,
+			to: `class Event {
+  id Int @primaryKey
+  changedAt TimestampTz @updatedAt
+}`,
+			want: []string{
+				`DROP TRIGGER IF EXISTS trg_event_changedat_updated_at ON "Event"`,
+				`CREATE TRIGGER trg_event_changedat_updated_at BEFORE INSERT OR UPDATE ON "Event" FOR EACH ROW EXECUTE FUNCTION blaze_set_updated_at('changedAt', 'timestamptz')`,
+			},
+			absent: []string{"CREATE OR REPLACE FUNCTION"},
+		},
+		{
+			name: "removing the last updatedAt field drops the function",
+			from: `class Event {
+  id Int @primaryKey
+  changedAt Timestamp @updatedAt
+}`,
+			to: `class Event {
+  id Int @primaryKey
+  changedAt Timestamp
+}`,
+			want: []string{
+				`DROP TRIGGER IF EXISTS trg_event_changedat_updated_at ON "Event"`,
+				"DROP FUNCTION IF EXISTS blaze_set_updated_at()",
+			},
+		},
+		{
+			name: "computed column is generated and stored",
+			to: `class Line {
+  id Int @primaryKey
+  qty Int
+  price Int
+  total Int @computed("qty * price")
+}`,
+			want: []string{`"total" INTEGER NOT NULL GENERATED ALWAYS AS (qty * price) STORED`},
+		},
+		{
+			name: "changed computed expression recreates the column",
+			from: `class Line {
+  id Int @primaryKey
+  qty Int
+  price Int
+  total Int @computed("qty * price")
+}`,
+			to: `class Line {
+  id Int @primaryKey
+  qty Int
+  price Int
+  total Int @computed("qty * price * 2")
+}`,
+			want: []string{
+				`ALTER TABLE "Line" DROP COLUMN IF EXISTS "total"`,
+				`ALTER TABLE "Line" ADD COLUMN "total" INTEGER NOT NULL GENERATED ALWAYS AS (qty * price * 2) STORED`,
+			},
+		},
+		{
+			name: "dropping the expression keeps the column",
+			from: `class Line {
+  id Int @primaryKey
+  qty Int
+  price Int
+  total Int @computed("qty * price")
+}`,
+			to: `class Line {
+  id Int @primaryKey
+  qty Int
+  price Int
+  total Int
+}`,
+			want:   []string{`ALTER TABLE "Line" ALTER COLUMN "total" DROP EXPRESSION`},
+			absent: []string{"DROP COLUMN"},
+		},
+		{
+			name: "autoincrement maps to an identity column",
+			to: `sequence orderNumber {
+  start 1000
+  increment 5
+  cache 10
+}
+class Order {
+  id Int @primaryKey @default(autoincrement())
+  number Int @default(nextval(orderNumber))
+}`,
+			want: []string{
+				`CREATE SEQUENCE "orderNumber" START WITH 1000 INCREMENT BY 5 CACHE 10`,
+				`"id" INTEGER NOT NULL GENERATED BY DEFAULT AS IDENTITY`,
+				`"number" INTEGER NOT NULL DEFAULT nextval('"orderNumber"')`,
+			},
+			absent: []string{"autoincrement()", "Order_id_seq"},
+		},
+		{
+			name: "autoincrement maps to an owned sequence",
+			to: `class Order {
+  id Int @primaryKey @default(autoincrement())
+}`,
+			options: MigrationOptions{Autoincrement: "sequence"},
+			want: []string{
+				`CREATE SEQUENCE IF NOT EXISTS "Order_id_seq" AS INTEGER`,
+				`"id" INTEGER NOT NULL DEFAULT nextval('"Order_id_seq"')`,
+				`ALTER SEQUENCE "Order_id_seq" OWNED BY "Order"."id"`,
+			},
+			absent: []string{"IDENTITY"},
+		},
+		{
+			name: "sequence options are altered in place",
+			from: `sequence orderNumber {
+  start 1000
+  increment 5
+}`,
+			to: `sequence orderNumber {
+  start 1000
+  increment 10
+}`,
+			want:   []string{`ALTER SEQUENCE "orderNumber" INCREMENT BY 10`},
+			absent: []string{"DROP SEQUENCE", "CREATE SEQUENCE"},
+		},
+		{
+			name: "adding autoincrement to an existing column",
+			from: `class Order {
+  id Int @primaryKey
+}`,
+			to: `class Order {
+  id Int @primaryKey @default(autoincrement())
+}`,
+			want: []string{`ALTER TABLE "Order" ALTER COLUMN "id" ADD GENERATED BY DEFAULT AS IDENTITY`},
+		},
+		{
+			name: "removing autoincrement drops the owned sequence",
+			from: `class Order {
+  id Int @primaryKey @default(autoincrement())
+}`,
+			to: `class Order {
+  id Int @primaryKey
+}`,
+			options: MigrationOptions{Autoincrement: "sequence"},
+			want: []string{
+				`ALTER TABLE "Order" ALTER COLUMN "id" DROP DEFAULT`,
+				`DROP SEQUENCE IF EXISTS "Order_id_seq"`,
+			},
+		},
+		{
+			name: "native types carry their modifiers",
+			to: `class Product {
+  id Int @primaryKey
+  code String @db.VarChar(16)
+  sku String @db.Char(8)
+  price Numeric @db.Numeric(12,2)
+  seenAt Timestamp @db.Timestamp(6)
+}`,
+			want: []string{
+				`"code" VARCHAR(16) NOT NULL`,
+				`"sku" CHAR(8) NOT NULL`,
+				`"price" NUMERIC(12,2) NOT NULL`,
+				`"seenAt" TIMESTAMP(6) NOT NULL`,
+			},
+		},
+		{
+			name: "widening native types alters without warnings",
+			from: `class Product {
+  id Int @primaryKey
+  code String @db.VarChar(16)
+  price Numeric @db.Numeric(10,2)
+}`,
+			to: `class Product {
+  id Int @primaryKey
+  code String @db.VarChar(32)
+  price Numeric @db.Numeric(12,2)
+}`,
+			want: []string{
+				`ALTER TABLE "Product" ALTER COLUMN "code" TYPE VARCHAR(32)`,
+				`ALTER TABLE "Product" ALTER COLUMN "price" TYPE NUMERIC(12,2)`,
+			},
+		},
+		{
+			name: "narrowing native types warns",
+			from: `class Product {
+  id Int @primaryKey
+  code String
+  price Numeric @db.Numeric(12,2)
+}`,
+			to: `class Product {
+  id Int @primaryKey
+  code String @db.VarChar(16)
+  price Numeric @db.Numeric(10,2)
+}`,
+			want: []string{
+				`ALTER TABLE "Product" ALTER COLUMN "code" TYPE VARCHAR(16)`,
+				`ALTER TABLE "Product" ALTER COLUMN "price" TYPE NUMERIC(10,2)`,
+			},
+			warnings: []string{
+				"narrowing Product.code from TEXT to VARCHAR(16)",
+				"narrowing Product.price from NUMERIC(12,2) to NUMERIC(10,2)",
+			},
+		},
+		{
+			name: "unchanged native types produce no statements",
+			from: `class Product {
+  id Int @primaryKey
+  code String @db.VarChar(16)
+}`,
+			to: `class Product {
+  id Int @primaryKey
+  code String @db.VarChar(16)
+}`,
+			absent: []string{"ALTER"},
+		},
+		{
+			name: "temporal scalars and now defaults",
+			to: `class Shift {
+  id Int @primaryKey
+  startsAt TimestampTz @default(now())
+  opensAt Time @default(now())
+  length Interval
+  breaks Interval[]
+}`,
+			want: []string{
+				`"startsAt" TIMESTAMPTZ(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3)`,
+				`"opensAt" TIME(3) NOT NULL DEFAULT LOCALTIME(3)`,
+				`"length" INTERVAL NOT NULL`,
+				`"breaks" INTERVAL[] NOT NULL`,
+			},
+		},
+		{
+			name: "timestamp to timestamptz is altered in place",
+			from: `class Shift {
+  id Int @primaryKey
+  startsAt Timestamp
+}`,
+			to: `class Shift {
+  id Int @primaryKey
+  startsAt TimestampTz
+}`,
+			want:   []string{`ALTER TABLE "Shift" ALTER COLUMN "startsAt" TYPE TIMESTAMPTZ(3)`},
+			absent: []string{"DROP COLUMN"},
+		},
+		{
+			name: "uuid scalar and defaults",
+			to: `class Account {
+  id Uuid @primaryKey @default(uuidv7())
+  token Uuid @default(uuid())
+  ownerId Uuid?
+}`,
+			want: []string{
+				"CREATE EXTENSION IF NOT EXISTS pgcrypto",
+				`"id" UUID NOT NULL DEFAULT uuidv7()`,
+				`"token" UUID NOT NULL DEFAULT gen_random_uuid()`,
+				`"ownerId" UUID`,
+			},
+		},
+		{
+			name: "string with a uuid default is stored as uuid with a warning",
+			to: `class Account {
+  id Int @primaryKey
+  legacyId String @default(uuid())
+}`,
+			want:     []string{`"legacyId" UUID NOT NULL DEFAULT gen_random_uuid()`},
+			warnings: []string{"Account.legacyId is a String stored as UUID; declare it as Uuid instead"},
+		},
+		{
+			name: "legacy uuid string to uuid scalar needs no alteration",
+			from: `class Account {
+  id Int @primaryKey
+  legacyId String @default(uuid())
+}`,
+			to: `class Account {
+  id Int @primaryKey
+  legacyId Uuid @default(uuid())
+}`,
+			absent: []string{"ALTER TABLE"},
+		},
+		{
+			name: "text column converted to uuid is cast",
+			from: `class Account {
+  id Int @primaryKey
+  ref String
+}`,
+			to: `class Account {
+  id Int @primaryKey
+  ref Uuid
+}`,
+			want: []string{`ALTER TABLE "Account" ALTER COLUMN "ref" TYPE UUID USING "ref"::UUID`},
+		},
+		{
+			name: "network, range and decimal scalars",
+			to: `class Booking {
+  id Int @primaryKey
+  ip Inet
+  net Cidr?
+  amount Decimal @db.Numeric(12,2)
+  total Decimal
+  seats Int4Range
+  during TsTzRange
+  peers Inet[]
+}`,
+			want: []string{
+				`"ip" INET NOT NULL`,
+				`"net" CIDR,`,
+				`"amount" NUMERIC(12,2) NOT NULL`,
+				`"total" NUMERIC NOT NULL`,
+				`"seats" INT4RANGE NOT NULL`,
+				`"during" TSTZRANGE NOT NULL`,
+				`"peers" INET[] NOT NULL`,
+			},
+		},
+		{
+			name: "replayed decimal narrowed to numeric warns",
+			from: `class Invoice {
+  id Int @primaryKey
+  amount Decimal @db.Numeric(12,2)
+}`,
+			to: `class Invoice {
+  id Int @primaryKey
+  amount Numeric @db.Numeric(10,2)
+}`,
+			want:     []string{`ALTER TABLE "Invoice" ALTER COLUMN "amount" TYPE NUMERIC(10,2)`},
+			warnings: []string{"narrowing Invoice.amount from NUMERIC(12,2) to NUMERIC(10,2)"},
+		},
+		{
+			name: "numeric to decimal keeps the column type",
+			from: `class Invoice {
+  id Int @primaryKey
+  amount Numeric
+}`,
+			to: `class Invoice {
+  id Int @primaryKey
+  amount Decimal
+}`,
+			absent: []string{"ALTER TABLE"},
+		},
+		{
+			name: "domains and composite types precede the tables using them",
+			to: `type Address {
+  street String
+  city String
+  zip String?
+}
+domain Email = String @check("VALUE ~ '@'")
+class Customer {
+  id Int @primaryKey
+  email Email
+  home Address
+  previous Address[]
+}`,
+			want: []string{
+				`CREATE DOMAIN "Email" AS TEXT CONSTRAINT "Email_check" CHECK (VALUE ~ '@')`,
+				`CREATE TYPE "Address" AS ("street" TEXT, "city" TEXT, "zip" TEXT)`,
+				`CREATE TABLE "Customer"`,
+				`"email" "Email" NOT NULL`,
+				`"home" "Address" NOT NULL`,
+				`"previous" "Address"[] NOT NULL`,
+			},
+		},
+		{
+			name: "changed domain checks and composite attributes are altered",
+			from: `type Address {
+  street String
+  city String
+  zip String?
+}
+domain Email = String @check("VALUE ~ '@'")
+class Customer {
+  id Int @primaryKey
+  email Email
+  home Address
+  previous Address[]
+}`,
+			to: `type Address {
+  street String
+  city String
+  country String
+}
+domain Email = String @check("VALUE ~ '@.+'")
+class Customer {
+  id Int @primaryKey
+  email Email
+  home Address
+  previous Address[]
+}`,
+			want: []string{
+				`ALTER DOMAIN "Email" DROP CONSTRAINT IF EXISTS "Email_check"`,
+				`ALTER DOMAIN "Email" ADD CONSTRAINT "Email_check" CHECK (VALUE ~ '@.+')`,
+				`ALTER TYPE "Address" DROP ATTRIBUTE IF EXISTS "zip", ADD ATTRIBUTE "country" TEXT`,
+			},
+			absent: []string{"DROP TYPE", "DROP DOMAIN"},
+		},
+		{
+			name: "types are dropped after the tables using them",
+			from: `type Address {
+  street String
+  city String
+  zip String?
+}
+domain Email = String @check("VALUE ~ '@'")
+class Customer {
+  id Int @primaryKey
+  email Email
+  home Address
+  previous Address[]
+}`,
+			want: []string{
+				`DROP TABLE IF EXISTS "Customer" CASCADE`,
+				`DROP TYPE IF EXISTS "Address"`,
+				`DROP DOMAIN IF EXISTS "Email"`,
+			},
+		},
+		{
+			name: "views are created after their tables in dependency order",
+			to: `class User {
+  id Int @primaryKey
+  email String
+  active Boolean
+}
+class Post {
+  id Int @primaryKey
+  authorId Int
+  title String
+}
+view ActiveUser {
+  id Int
+  email String
+} @@sql("SELECT \"id\", \"email\" FROM \"User\" WHERE \"active\"")
+view RecentUser {
+  id Int
+  email String
+  @@sql("SELECT \"id\", \"email\" FROM \"ActiveUser\"
+         WHERE \"id\" > 100")
+}
+materializedView UserStats {
+  userId Int
+  posts BigInt
+  @@unique([userId])
+  @@index([posts])
+  @@sql("SELECT \"authorId\" AS \"userId\", count(*) AS \"posts\" FROM \"Post\" GROUP BY \"authorId\"")
+}`,
+			want: []string{
+				`CREATE TABLE "User"`,
+				`CREATE TABLE "Post"`,
+				`CREATE VIEW "ActiveUser" AS SELECT "id", "email" FROM "User" WHERE "active"`,
+				`CREATE VIEW "RecentUser" AS SELECT "id", "email" FROM "ActiveUser" WHERE "id" > 100`,
+				`CREATE MATERIALIZED VIEW "UserStats" AS SELECT "authorId" AS "userId", count(*) AS "posts" FROM "Post" GROUP BY "authorId"`,
+				`CREATE UNIQUE INDEX idx_userstats_userid_unique ON "UserStats" ("userId")`,
+				`CREATE INDEX idx_userstats_posts_index ON "UserStats" ("posts")`,
+			},
+		},
+		{
+			name: "changed views and their dependents are recreated around table changes",
+			from: `class User {
+  id Int @primaryKey
+  email String
+  active Boolean
+}
+class Post {
+  id Int @primaryKey
+  authorId Int
+  title String
+}
+view ActiveUser {
+  id Int
+  email String
+} @@sql("SELECT \"id\", \"email\" FROM \"User\" WHERE \"active\"")
+view RecentUser {
+  id Int
+  email String
+  @@sql("SELECT \"id\", \"email\" FROM \"ActiveUser\"
+         WHERE \"id\" > 100")
+}
+materializedView UserStats {
+  userId Int
+  posts BigInt
+  @@unique([userId])
+  @@index([posts])
+  @@sql("SELECT \"authorId\" AS \"userId\", count(*) AS \"posts\" FROM \"Post\" GROUP BY \"authorId\"")
+}`,
+			to: `class User {
+  id Int @primaryKey
+  email String @db.VarChar(100)
+  active Boolean
+}
+class Post {
+  id Int @primaryKey
+  authorId Int
+  title String
+}
+view ActiveUser {
+  id Int
+  email String
+} @@sql("SELECT \"id\", \"email\" FROM \"User\" WHERE \"active\" AND \"id\" > 0")
+view RecentUser {
+  id Int
+  email String
+  @@sql("SELECT \"id\", \"email\" FROM \"ActiveUser\"
+         WHERE \"id\" > 100")
+}
+materializedView UserStats {
+  userId Int
+  posts BigInt
+  @@unique([userId])
+  @@index([posts])
+  @@sql("SELECT \"authorId\" AS \"userId\", count(*) AS \"posts\" FROM \"Post\" GROUP BY \"authorId\"")
+}`,
+			want: []string{
+				`DROP VIEW IF EXISTS "RecentUser"`,
+				`DROP VIEW IF EXISTS "ActiveUser"`,
+				`ALTER TABLE "User" ALTER COLUMN "email" TYPE VARCHAR(100)`,
+				`CREATE VIEW "ActiveUser" AS SELECT "id", "email" FROM "User" WHERE "active" AND "id" > 0`,
+				`CREATE VIEW "RecentUser"`,
+			},
+			absent:   []string{"MATERIALIZED"},
+			warnings: []string{"narrowing User.email from TEXT to VARCHAR(100)"},
+		},
+		{
+			name: "views are dropped before their tables",
+			from: `class User {
+  id Int @primaryKey
+  email String
+  active Boolean
+}
+class Post {
+  id Int @primaryKey
+  authorId Int
+  title String
+}
+view ActiveUser {
+  id Int
+  email String
+} @@sql("SELECT \"id\", \"email\" FROM \"User\" WHERE \"active\"")
+view RecentUser {
+  id Int
+  email String
+  @@sql("SELECT \"id\", \"email\" FROM \"ActiveUser\"
+         WHERE \"id\" > 100")
+}
+materializedView UserStats {
+  userId Int
+  posts BigInt
+  @@unique([userId])
+  @@index([posts])
+  @@sql("SELECT \"authorId\" AS \"userId\", count(*) AS \"posts\" FROM \"Post\" GROUP BY \"authorId\"")
+}`,
+			want: []string{
+				`DROP MATERIALIZED VIEW IF EXISTS "UserStats"`,
+				`DROP VIEW IF EXISTS "RecentUser"`,
+				`DROP VIEW IF EXISTS "ActiveUser"`,
+				`DROP TABLE IF EXISTS`,
+			},
+		},
+		{
+			name: "row level security is enabled with its policies",
+			from: `class Doc {
+  id Int @primaryKey
+  tenantId Int
+  title String
+}`,
+			to: `class Doc {
+  id Int @primaryKey
+  tenantId Int
+  title String
+  @@rls
+  @@policy(tenant_isolation, using: "\"tenantId\" = current_setting('app.tenant_id')::int")
+  @@policy(tenant_insert, for: Insert, to: app_user, check: "\"tenantId\" = current_setting('app.tenant_id')::int")
+}`,
+			want: []string{
+				`ALTER TABLE "Doc" ENABLE ROW LEVEL SECURITY`,
+				`CREATE POLICY "tenant_isolation" ON "Doc" FOR ALL USING ("tenantId" = current_setting('app.tenant_id')::int)`,
+				`CREATE POLICY "tenant_insert" ON "Doc" FOR INSERT TO app_user WITH CHECK ("tenantId" = current_setting('app.tenant_id')::int)`,
+			},
+		},
+		{
+			name: "changed policies are dropped and recreated",
+			from: `class Doc {
+  id Int @primaryKey
+  tenantId Int
+  @@rls
+  @@policy(tenant_isolation, using: "\"tenantId\" = current_setting('app.tenant_id')::int")
+  @@policy(tenant_insert, for: Insert, to: app_user, check: "true")
+}`,
+			to: `class Doc {
+  id Int @primaryKey
+  tenantId Int
+  @@rls
+  @@policy(tenant_isolation, for: Select, using: "\"tenantId\" = current_setting('app.tenant_id')::int")
+}`,
+			want: []string{
+				`DROP POLICY IF EXISTS "tenant_isolation" ON "Doc"`,
+				`DROP POLICY IF EXISTS "tenant_insert" ON "Doc"`,
+				`CREATE POLICY "tenant_isolation" ON "Doc" FOR SELECT USING`,
+			},
+			absent: []string{"ROW LEVEL SECURITY"},
+		},
+		{
+			name: "row level security is disabled after its policies are dropped",
+			from: `class Doc {
+  id Int @primaryKey
+  tenantId Int
+  @@rls
+  @@policy(tenant_isolation, using: "\"tenantId\" = current_setting('app.tenant_id')::int")
+}`,
+			to: `class Doc {
+  id Int @primaryKey
+  tenantId Int
+}`,
+			want: []string{
+				`DROP POLICY IF EXISTS "tenant_isolation" ON "Doc"`,
+				`ALTER TABLE "Doc" DISABLE ROW LEVEL SECURITY`,
+			},
+		},
+		{
+			name: "partitioned tables are created before their partitions and indexes",
+			to: `class Event {
+  id Int
+  kind String
+  createdAt Timestamp @default(now())
+
+  @@primaryKey([id, createdAt])
+  @@index([kind])
+  @@partitionBy(range: [createdAt], interval: monthly, start: "2026-08", premake: 2)
+  @@partition(Event_old, from: MINVALUE, to: "2026-08-01")
+}
+
+class Region {
+  id Int
+  code String
+
+  @@primaryKey([id, code])
+  @@partitionBy(list: [code])
+  @@partition(Region_eu, in: ["eu", "uk"])
+  @@partition(Region_other, default: true)
+}
+
+class Bucket {
+  id Int @primaryKey
+
+  @@partitionBy(hash: [id])
+  @@partition(Bucket_0, modulus: 2, remainder: 0)
+  @@partition(Bucket_1, modulus: 2, remainder: 1)
+}`,
+			want: []string{
+				`) PARTITION BY RANGE ("createdAt")`,
+				`) PARTITION BY LIST ("code")`,
+				`) PARTITION BY HASH ("id")`,
+				`CREATE TABLE "Event_old" PARTITION OF "Event" FOR VALUES FROM (MINVALUE) TO ('2026-08-01')`,
+				`CREATE TABLE "Event_2026_08" PARTITION OF "Event" FOR VALUES FROM ('2026-08-01') TO ('2026-09-01')`,
+				`CREATE TABLE "Region_eu" PARTITION OF "Region" FOR VALUES IN ('eu', 'uk')`,
+				`CREATE TABLE "Region_other" PARTITION OF "Region" DEFAULT`,
+				`CREATE TABLE "Bucket_0" PARTITION OF "Bucket" FOR VALUES WITH (MODULUS 2, REMAINDER 0)`,
+				`CREATE TABLE "Bucket_1" PARTITION OF "Bucket" FOR VALUES WITH (MODULUS 2, REMAINDER 1)`,
+				`CREATE INDEX idx_event_kind_index ON "Event" ("kind")`,
+			},
+		},
+		{
+			name: "raising premake only adds the new interval partition",
+			from: `class Event {
+  id Int
+  createdAt Timestamp
+
+  @@primaryKey([id, createdAt])
+  @@partitionBy(range: [createdAt], interval: monthly, start: "2026-08", premake: 2)
+}`,
+			to: `class Event {
+  id Int
+  createdAt Timestamp
+
+  @@primaryKey([id, createdAt])
+  @@partitionBy(range: [createdAt], interval: monthly, start: "2026-08", premake: 3)
+}`,
+			want:   []string{`PARTITION OF "Event" FOR VALUES FROM (`},
+			absent: []string{"DROP TABLE", "Event_2026_08"},
+		},
+		{
+			name: "removed partitions are dropped",
+			from: `class Region {
+  id Int
+  code String
+
+  @@primaryKey([id, code])
+  @@partitionBy(list: [code])
+  @@partition(Region_eu, in: ["eu", "uk"])
+  @@partition(Region_other, default: true)
+}`,
+			to: `class Region {
+  id Int
+  code String
+
+  @@primaryKey([id, code])
+  @@partitionBy(list: [code])
+  @@partition(Region_other, default: true)
+}`,
+			want:   []string{`DROP TABLE IF EXISTS "Region_eu"`},
+			absent: []string{`"Region_other"`},
+		},
+		{
+			name: "changed partition bounds are rejected",
+			from: `class Region {
+  id Int
+  code String
+
+  @@primaryKey([id, code])
+  @@partitionBy(list: [code])
+  @@partition(Region_eu, in: ["eu", "uk"])
+}`,
+			to: `class Region {
+  id Int
+  code String
+
+  @@primaryKey([id, code])
+  @@partitionBy(list: [code])
+  @@partition(Region_eu, in: ["eu"])
+}`,
+			err: "changing the bounds of partition 'Region_eu' is not supported",
+		},
+		{
+			name: "changed partition keys are rejected",
+			from: `class Region {
+  id Int
+  code String
+
+  @@primaryKey([id, code])
+  @@partitionBy(list: [code])
+}`,
+			to: `class Region {
+  id Int
+  code String
+
+  @@primaryKey([id, code])
+  @@partitionBy(hash: [code])
+}`,
+			err: "changing the partitioning of class 'Region' is not supported",
+		},
+		{
+			name: "exclusion constraints install btree_gist for scalar equality",
+			from: `class Booking {
+  id Int @primaryKey
+  roomId Int
+  during TsTzRange
+}`,
+			to: `class Booking {
+  id Int @primaryKey
+  roomId Int
+  during TsTzRange
+  cancelled Boolean @default(false)
+
+  @@exclude(using: Gist, [roomId WITH =, during WITH &&], where: "NOT cancelled")
+}`,
+			want: []string{
+				"CREATE EXTENSION IF NOT EXISTS btree_gist",
+				`ALTER TABLE "Booking" ADD COLUMN "cancelled"`,
+				`ALTER TABLE "Booking" ADD CONSTRAINT "excl_booking_roomid_during" EXCLUDE USING gist ("roomId" WITH =, "during" WITH &&) WHERE (NOT cancelled)`,
+			},
+		},
+		{
+			name: "changed exclusion constraints are dropped and recreated",
+			from: `class Booking {
+  id Int @primaryKey
+  during TsTzRange
+  @@exclude([during WITH &&])
+}`,
+			to: `class Booking {
+  id Int @primaryKey
+  during TsTzRange
+  @@exclude([(upper(during)) WITH &&], name: "booking_end_once")
+}`,
+			want: []string{
+				`ALTER TABLE "Booking" DROP CONSTRAINT IF EXISTS "excl_booking_during"`,
+				`ALTER TABLE "Booking" ADD CONSTRAINT "booking_end_once" EXCLUDE USING gist ((upper(during)) WITH &&)`,
+			},
+			absent: []string{"btree_gist"},
+		},
+		{
+			name: "exclusion constraints are dropped before their columns",
+			from: `class Booking {
+  id Int @primaryKey
+  during TsTzRange
+  cancelled Boolean
+  @@exclude([during WITH &&], where: "NOT cancelled")
+}`,
+			to: `class Booking {
+  id Int @primaryKey
+  during TsTzRange
+}`,
+			want: []string{
+				`ALTER TABLE "Booking" DROP CONSTRAINT IF EXISTS "excl_booking_during"`,
+				`ALTER TABLE "Booking" DROP COLUMN IF EXISTS "cancelled"`,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			engine := NewMigrationEngineWithOptions(buildSchema(t, tt.from), buildSchema(t, tt.to), tt.options)
+			sql, err := engine.GenerateMigration()
+			if tt.err != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.err) {
+					t.Fatalf("GenerateMigration() error = %v, want %q", err, tt.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("GenerateMigration() error: %v", err)
+			}
+
+			rest := sql
+			for _, fragment := range tt.want {
+				index := strings.Index(rest, fragment)
+				if index == -1 {
+					t.Fatalf("missing %q after the previous fragments in:\n%s", fragment, sql)
+				}
+				rest = rest[index+len(fragment):]
+			}
+			for _, fragment := range tt.absent {
+				if strings.Contains(sql, fragment) {
+					t.Errorf("unexpected %q in:\n%s", fragment, sql)
+				}
+			}
+			warnings := strings.Join(engine.Warnings(), "\n")
+			for _, fragment := range tt.warnings {
+				if !strings.Contains(warnings, fragment) {
+					t.Errorf("missing warning %q in:\n%s", fragment, warnings)
+				}
+			}
+			if len(tt.warnings) == 0 && len(engine.Warnings()) > 0 {
+				t.Errorf("unexpected warnings:\n%s", warnings)
+			}
+		})
+	}
+}
+
+func buildSchema(t *testing.T, source string) *ast.SchemaAST {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "schema.blaze")
+	if err := os.WriteFile(path, []byte(source), 0644); err != nil {
+		t.Fatalf("writing schema: %v", err)
+	}
+	content, err := utils.ReadAndSeparateSchema(path)
+	if err != nil {
+		t.Fatalf("ReadAndSeparateSchema() error: %v", err)
+	}
+	schema, err := ast.BuildSchemaAST(content)
+	if err != nil {
+		t.Fatalf("BuildSchemaAST() error: %v", err)
+	}
+	return schema
+}
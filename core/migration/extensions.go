@@ -16,6 +16,15 @@ func (me *MigrationEngine) generateExtensions() []MigrationStatement {
 		})
 	}
 
+	needsBtreeGist := me.needsBtreeGistExtension()
+	if needsBtreeGist {
+		statements = append(statements, MigrationStatement{
+			SQL:      "CREATE EXTENSION IF NOT EXISTS btree_gist",
+			Type:     "extension",
+			Priority: 1,
+		})
+	}
+
 	needsPgCrypto := me.needsPgCryptoExtension()
 	if needsPgCrypto {
 		statements = append(statements, MigrationStatement{
@@ -37,6 +46,17 @@ func (me *MigrationEngine) needsPgTrgmExtension() bool {
 	return false
 }
 
+func (me *MigrationEngine) needsBtreeGistExtension() bool {
+	for _, cls := range me.toSchema.Classes {
+		for _, exclusion := range cls.Attributes.GetExclusions() {
+			if exclusion.NeedsBtreeGist() {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 func (me *MigrationEngine) needsPgCryptoExtension() bool {
 	for _, cls := range me.toSchema.Classes {
 		for _, field := range cls.Attributes.Fields {
@@ -5,6 +5,8 @@ import (
 	"strings"
 
 	"github.com/rit3sh-x/blaze/core/ast/class"
+	"github.com/rit3sh-x/blaze/core/ast/class/directives"
+	"github.com/rit3sh-x/blaze/core/constants"
 )
 
 func (me *MigrationEngine) generateIndexMigrations() ([]MigrationStatement, error) {
@@ -30,40 +32,18 @@ func (me *MigrationEngine) generateIndexMigrations() ([]MigrationStatement, erro
 func (me *MigrationEngine) generateIndexDropStatements(oldClass, newClass *class.Class) []MigrationStatement {
 	var statements []MigrationStatement
 
-	if oldClass.Attributes.HasIndex() && (newClass == nil || !newClass.Attributes.HasIndex()) {
-		if indexDirective := oldClass.Attributes.GetIndexDirective(); indexDirective != nil {
-			if fields, err := indexDirective.GetFields(); err == nil {
-				indexColumns := make([]string, len(fields))
-				copy(indexColumns, fields)
-				indexName := fmt.Sprintf("idx_%s_%s_index",
-					strings.ToLower(oldClass.Name),
-					strings.ToLower(strings.Join(indexColumns, "_")),
-				)
-				statements = append(statements, MigrationStatement{
-					SQL:      fmt.Sprintf("DROP INDEX IF EXISTS %s", indexName),
-					Type:     "index_drop",
-					Priority: 12,
-				})
-			}
-		}
-	}
+	oldIndexes, oldOrder := me.collectIndexes(oldClass)
+	newIndexes, _ := me.collectIndexes(newClass)
 
-	if oldClass.Attributes.HasTextIndex() && (newClass == nil || !newClass.Attributes.HasTextIndex()) {
-		if textIndexDirective := oldClass.Attributes.GetTextIndexDirective(); textIndexDirective != nil {
-			if fields, err := textIndexDirective.GetFields(); err == nil {
-				indexColumns := make([]string, len(fields))
-				copy(indexColumns, fields)
-				indexName := fmt.Sprintf("idx_%s_%s_text_index",
-					strings.ToLower(oldClass.Name),
-					strings.ToLower(strings.Join(indexColumns, "_")),
-				)
-				statements = append(statements, MigrationStatement{
-					SQL:      fmt.Sprintf("DROP INDEX IF EXISTS %s", indexName),
-					Type:     "index_drop",
-					Priority: 12,
-				})
-			}
+	for _, indexName := range oldOrder {
+		if newSQL, exists := newIndexes[indexName]; exists && newSQL == oldIndexes[indexName] {
+			continue
 		}
+		statements = append(statements, me.applyConcurrently(MigrationStatement{
+			SQL:      fmt.Sprintf("DROP INDEX IF EXISTS %s", indexName),
+			Type:     "index_drop",
+			Priority: 12,
+		})...)
 	}
 
 	return statements
@@ -72,53 +52,124 @@ func (me *MigrationEngine) generateIndexDropStatements(oldClass, newClass *class
 func (me *MigrationEngine) generateIndexCreateStatements(oldClass, newClass *class.Class) []MigrationStatement {
 	var statements []MigrationStatement
 
-	needsIndex := newClass.Attributes.HasIndex() && (oldClass == nil || !oldClass.Attributes.HasIndex())
-	needsTextIndex := newClass.Attributes.HasTextIndex() && (oldClass == nil || !oldClass.Attributes.HasTextIndex())
-
-	if needsIndex {
-		if indexDirective := newClass.Attributes.GetIndexDirective(); indexDirective != nil {
-			if fields, err := indexDirective.GetFields(); err == nil {
-				indexColumns := make([]string, len(fields))
-				for i, field := range fields {
-                    indexColumns[i] = applyQuotes(field)
-                }
-				indexName := fmt.Sprintf("idx_%s_%s_index",
-					strings.ToLower(newClass.Name),
-					strings.ToLower(strings.Join(indexColumns, "_")),
-				)
-				statements = append(statements, MigrationStatement{
-					SQL:      fmt.Sprintf(`CREATE INDEX %s ON "%s" (%s)`, indexName, newClass.Name, strings.Join(indexColumns, ", ")),
-					Type:     "index_create",
-					Priority: 13,
-				})
-			}
-		}
+	oldIndexes := make(map[string]string)
+	if oldClass != nil {
+		oldIndexes, _ = me.collectIndexes(oldClass)
 	}
+	newIndexes, newOrder := me.collectIndexes(newClass)
 
-	if needsTextIndex {
-		if textIndexDirective := newClass.Attributes.GetTextIndexDirective(); textIndexDirective != nil {
-			if fields, err := textIndexDirective.GetFields(); err == nil {
-				indexColumns := make([]string, len(fields))
-				for i, field := range fields {
-                    indexColumns[i] = applyQuotes(field)
-                }
-				indexName := fmt.Sprintf("idx_%s_%s_text_index",
-					strings.ToLower(newClass.Name),
-					strings.ToLower(strings.Join(indexColumns, "_")),
-				)
-				statements = append(statements, MigrationStatement{
-					SQL: fmt.Sprintf(
-						`CREATE INDEX %s ON "%s" USING gin ((%s) gin_trgm_ops)`,
-						indexName,
-						newClass.Name,
-						strings.Join(indexColumns, " || ' ' || "),
-					),
-					Type:     "text_index_create",
-					Priority: 13,
-				})
-			}
+	for _, indexName := range newOrder {
+		if oldSQL, exists := oldIndexes[indexName]; exists && oldSQL == newIndexes[indexName] {
+			continue
+		}
+		statement := MigrationStatement{
+			SQL:      newIndexes[indexName],
+			Type:     "index_create",
+			Priority: 13,
+		}
+		if oldClass != nil {
+			statements = append(statements, me.applyConcurrently(statement)...)
+		} else {
+			statements = append(statements, statement)
 		}
 	}
 
 	return statements
-}
\ No newline at end of file
+}
+
+func (me *MigrationEngine) collectIndexes(cls *class.Class) (map[string]string, []string) {
+	indexes := make(map[string]string)
+	var order []string
+
+	for _, directive := range cls.Attributes.Directives {
+		isText := directive.Name == constants.CLASS_ATTR_TEXT_INDEX
+		if directive.Name != constants.CLASS_ATTR_INDEX && !isText {
+			continue
+		}
+
+		index, err := directive.GetIndex()
+		if err != nil {
+			continue
+		}
+
+		indexName := index.GetName(cls.Name, isText)
+		if _, exists := indexes[indexName]; !exists {
+			order = append(order, indexName)
+		}
+		indexes[indexName] = me.generateCreateIndexSQL(cls, index, isText)
+	}
+
+	return indexes, order
+}
+
+func (me *MigrationEngine) generateCreateIndexSQL(cls *class.Class, index *directives.Index, isText bool) string {
+	indexName := index.GetName(cls.Name, isText)
+	if !isText {
+		return me.generateIndexSQL(cls.Name, indexName, index, false)
+	}
+
+	indexColumns := make([]string, len(index.Columns))
+	for i, column := range index.Columns {
+		indexColumns[i] = applyQuotes(column.Field)
+	}
+
+	method, opclass := "gin", "gin_trgm_ops"
+	if index.GetType() == constants.INDEX_TYPE_GIST {
+		method, opclass = "gist", "gist_trgm_ops"
+	}
+
+	sql := fmt.Sprintf(
+		`CREATE INDEX %s ON "%s" USING %s ((%s) %s)`,
+		indexName,
+		cls.Name,
+		method,
+		strings.Join(indexColumns, " || ' ' || "),
+		opclass,
+	)
+
+	if index.Where != "" {
+		sql += fmt.Sprintf(" WHERE %s", index.Where)
+	}
+
+	return sql
+}
+
+func (me *MigrationEngine) generateIndexSQL(tableName string, indexName string, index *directives.Index, unique bool) string {
+	indexColumns := make([]string, len(index.Columns))
+	for i, column := range index.Columns {
+		if column.Expression != "" {
+			indexColumns[i] = fmt.Sprintf("(%s)", column.Expression)
+		} else {
+			indexColumns[i] = applyQuotes(column.Field)
+		}
+		if column.Sort != "" {
+			indexColumns[i] += " " + strings.ToUpper(column.Sort)
+		}
+	}
+
+	using := ""
+	if index.GetType() != constants.INDEX_TYPE_BTREE {
+		using = fmt.Sprintf(" USING %s", constants.PGIndexMethodMapping[index.GetType()])
+	}
+
+	createIndex := "CREATE INDEX"
+	if unique {
+		createIndex = "CREATE UNIQUE INDEX"
+	}
+
+	sql := fmt.Sprintf(`%s %s ON "%s"%s (%s)`, createIndex, indexName, tableName, using, strings.Join(indexColumns, ", "))
+
+	if len(index.Include) > 0 {
+		includeColumns := make([]string, len(index.Include))
+		for i, field := range index.Include {
+			includeColumns[i] = applyQuotes(field)
+		}
+		sql += fmt.Sprintf(" INCLUDE (%s)", strings.Join(includeColumns, ", "))
+	}
+
+	if index.Where != "" {
+		sql += fmt.Sprintf(" WHERE %s", index.Where)
+	}
+
+	return sql
+}
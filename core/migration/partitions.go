@@ -0,0 +1,68 @@
+package migration
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/rit3sh-x/blaze/core/ast/class/directives"
+)
+
+func (me *MigrationEngine) generatePartitionMigrations() ([]MigrationStatement, error) {
+	var statements []MigrationStatement
+	now := time.Now().UTC()
+
+	createOrder, _ := me.toSchema.GetDependencyOrder()
+	for _, newClass := range createOrder {
+		oldClass := me.fromSchema.GetClassByName(newClass.Name)
+		newPartitionBy := newClass.Attributes.GetPartitionBy()
+
+		oldPartitions := make(map[string]*directives.Partition)
+		if oldClass != nil {
+			if !oldClass.Attributes.GetPartitionBy().KeyEquals(newPartitionBy) {
+				return nil, fmt.Errorf("changing the partitioning of class '%s' is not supported; create a new class and move the data to it", newClass.Name)
+			}
+			for _, partition := range oldClass.GetPartitions(now) {
+				oldPartitions[partition.Name] = partition
+			}
+		}
+
+		newPartitions := make(map[string]bool)
+		for _, partition := range newClass.GetPartitions(now) {
+			newPartitions[partition.Name] = true
+
+			oldPartition, exists := oldPartitions[partition.Name]
+			if exists && !oldPartition.Equals(partition) {
+				return nil, fmt.Errorf("changing the bounds of partition '%s' is not supported; detach it and declare a new partition instead", partition.Name)
+			}
+			if exists {
+				continue
+			}
+
+			statements = append(statements, MigrationStatement{
+				SQL:      partition.SQL(newClass.Name),
+				Type:     "partition_create",
+				Priority: 6,
+			})
+		}
+
+		if oldClass == nil {
+			continue
+		}
+
+		for _, partition := range oldClass.GetPartitions(now) {
+			if newPartitions[partition.Name] {
+				continue
+			}
+			if newPartitionBy != nil && newPartitionBy.Interval != "" && directives.IsIntervalPartitionName(newClass.Name, partition.Name) {
+				continue
+			}
+			statements = append(statements, MigrationStatement{
+				SQL:      fmt.Sprintf(`DROP TABLE IF EXISTS "%s"`, partition.Name),
+				Type:     "partition_drop",
+				Priority: 5,
+			})
+		}
+	}
+
+	return statements, nil
+}
@@ -0,0 +1,80 @@
+package migration
+
+import (
+	"fmt"
+
+	"github.com/rit3sh-x/blaze/core/ast/class"
+	"github.com/rit3sh-x/blaze/core/ast/class/directives"
+)
+
+func (me *MigrationEngine) generatePolicyMigrations() []MigrationStatement {
+	var statements []MigrationStatement
+
+	for _, newClass := range me.toSchema.Classes {
+		oldClass := me.fromSchema.GetClassByName(newClass.Name)
+
+		oldRLS := oldClass != nil && oldClass.Attributes.HasRLS()
+		newRLS := newClass.Attributes.HasRLS()
+
+		if newRLS && !oldRLS {
+			statements = append(statements, MigrationStatement{
+				SQL:      fmt.Sprintf(`ALTER TABLE "%s" ENABLE ROW LEVEL SECURITY`, newClass.Name),
+				Type:     "rls_enable",
+				Priority: 13,
+			})
+		}
+
+		oldPolicies := make(map[string]*directives.Policy)
+		if oldClass != nil {
+			for _, policy := range oldClass.Attributes.GetPolicies() {
+				oldPolicies[policy.Name] = policy
+			}
+		}
+
+		newPolicies := make(map[string]bool)
+		for _, policy := range newClass.Attributes.GetPolicies() {
+			newPolicies[policy.Name] = true
+
+			oldPolicy, exists := oldPolicies[policy.Name]
+			if exists && oldPolicy.Equals(policy) {
+				continue
+			}
+
+			if exists {
+				statements = append(statements, me.generateDropPolicyStatement(newClass, oldPolicy))
+			}
+
+			statements = append(statements, MigrationStatement{
+				SQL:      policy.SQL(newClass.Name),
+				Type:     "policy_create",
+				Priority: 13,
+			})
+		}
+
+		if oldClass != nil {
+			for _, policy := range oldClass.Attributes.GetPolicies() {
+				if !newPolicies[policy.Name] {
+					statements = append(statements, me.generateDropPolicyStatement(newClass, policy))
+				}
+			}
+		}
+
+		if oldRLS && !newRLS {
+			statements = append(statements, MigrationStatement{
+				SQL:      fmt.Sprintf(`ALTER TABLE "%s" DISABLE ROW LEVEL SECURITY`, newClass.Name),
+				Type:     "rls_disable",
+				Priority: 13,
+			})
+		}
+	}
+
+	return statements
+}
+
+func (me *MigrationEngine) generateDropPolicyStatement(cls *class.Class, policy *directives.Policy) MigrationStatement {
+	return MigrationStatement{
+		SQL:      fmt.Sprintf(`DROP POLICY IF EXISTS "%s" ON "%s"`, policy.Name, cls.Name),
+		Type:     "policy_drop",
+		Priority: 5,
+	}
+}
@@ -0,0 +1,135 @@
+package migration
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/rit3sh-x/blaze/core/ast/sequence"
+	"github.com/rit3sh-x/blaze/core/constants"
+)
+
+func (me *MigrationEngine) generateSequenceMigrations() []MigrationStatement {
+	var statements []MigrationStatement
+
+	for _, name := range sortedSequenceNames(me.toSchema.Sequences) {
+		newSequence := me.toSchema.Sequences[name]
+		oldSequence := me.fromSchema.GetSequenceByName(name)
+
+		if oldSequence == nil {
+			statements = append(statements, MigrationStatement{
+				SQL: fmt.Sprintf(`CREATE SEQUENCE "%s" START WITH %d INCREMENT BY %d CACHE %d`,
+					name, newSequence.Start, newSequence.Increment, newSequence.Cache),
+				Type:     "sequence_create",
+				Priority: 4,
+			})
+			continue
+		}
+
+		if oldSequence.Equals(newSequence) {
+			continue
+		}
+
+		var changes []string
+		if oldSequence.Start != newSequence.Start {
+			changes = append(changes, fmt.Sprintf("START WITH %d", newSequence.Start))
+		}
+		if oldSequence.Increment != newSequence.Increment {
+			changes = append(changes, fmt.Sprintf("INCREMENT BY %d", newSequence.Increment))
+		}
+		if oldSequence.Cache != newSequence.Cache {
+			changes = append(changes, fmt.Sprintf("CACHE %d", newSequence.Cache))
+		}
+		statements = append(statements, MigrationStatement{
+			SQL:      fmt.Sprintf(`ALTER SEQUENCE "%s" %s`, name, strings.Join(changes, " ")),
+			Type:     "sequence_alter",
+			Priority: 4,
+		})
+	}
+
+	for _, name := range sortedSequenceNames(me.fromSchema.Sequences) {
+		if me.toSchema.GetSequenceByName(name) == nil {
+			statements = append(statements, MigrationStatement{
+				SQL:      fmt.Sprintf(`DROP SEQUENCE IF EXISTS "%s"`, name),
+				Type:     "sequence_drop",
+				Priority: 12,
+			})
+		}
+	}
+
+	statements = append(statements, me.generateOwnedSequenceMigrations()...)
+
+	return statements
+}
+
+func (me *MigrationEngine) generateOwnedSequenceMigrations() []MigrationStatement {
+	var statements []MigrationStatement
+
+	for _, newClass := range me.toSchema.Classes {
+		oldClass := me.fromSchema.GetClassByName(newClass.Name)
+
+		for _, newField := range newClass.Attributes.Fields {
+			if !isAutoincrement(newField) || me.usesIdentity(newField) {
+				continue
+			}
+			if oldClass != nil {
+				if oldField := oldClass.Attributes.GetFieldByName(newField.GetName()); oldField != nil && isAutoincrement(oldField) {
+					continue
+				}
+			}
+
+			sequenceName := ownedSequenceName(newClass.Name, newField.GetName())
+			dataType, err := me.mapToPGType(newField, newClass)
+			if err != nil {
+				continue
+			}
+
+			statements = append(statements, MigrationStatement{
+				SQL:      fmt.Sprintf(`CREATE SEQUENCE IF NOT EXISTS "%s" AS %s`, sequenceName, dataType),
+				Type:     "sequence_create",
+				Priority: 4,
+			})
+			statements = append(statements, MigrationStatement{
+				SQL:      fmt.Sprintf(`ALTER SEQUENCE "%s" OWNED BY "%s"."%s"`, sequenceName, newClass.Name, newField.GetName()),
+				Type:     "sequence_owned_by",
+				Priority: 14,
+			})
+		}
+
+		if oldClass == nil {
+			continue
+		}
+
+		for _, oldField := range oldClass.Attributes.Fields {
+			if !isAutoincrement(oldField) {
+				continue
+			}
+			if newField := newClass.Attributes.GetFieldByName(oldField.GetName()); newField != nil && !isAutoincrement(newField) {
+				statements = append(statements, MigrationStatement{
+					SQL:      fmt.Sprintf(`DROP SEQUENCE IF EXISTS "%s"`, ownedSequenceName(newClass.Name, oldField.GetName())),
+					Type:     "sequence_drop",
+					Priority: 12,
+				})
+			}
+		}
+	}
+
+	return statements
+}
+
+func sortedSequenceNames(sequences map[string]*sequence.Sequence) []string {
+	var names []string
+	for name := range sequences {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func ownedSequenceName(className string, fieldName string) string {
+	return fmt.Sprintf(constants.OWNED_SEQUENCE_NAME, className, fieldName)
+}
+
+func nextvalExpression(sequenceName string) string {
+	return fmt.Sprintf(`%s('"%s"')`, constants.DEFAULT_NEXTVAL_FUNCTION, sequenceName)
+}
@@ -0,0 +1,119 @@
+package migration
+
+import (
+	"strings"
+
+	"github.com/rit3sh-x/blaze/core/constants"
+)
+
+type MigrationStep struct {
+	SQL              string
+	NonTransactional bool
+}
+
+func SplitMigrationSteps(migrationSQL string) []MigrationStep {
+	var steps []MigrationStep
+	var current strings.Builder
+	nonTransactional := false
+
+	flush := func() {
+		stmt := strings.TrimSpace(current.String())
+		current.Reset()
+		if stmt != "" {
+			steps = append(steps, MigrationStep{SQL: stmt, NonTransactional: nonTransactional})
+		}
+		nonTransactional = false
+	}
+
+	var quote byte
+	dollarTag := ""
+
+	for i := 0; i < len(migrationSQL); i++ {
+		char := migrationSQL[i]
+
+		if dollarTag != "" {
+			if strings.HasPrefix(migrationSQL[i:], dollarTag) {
+				current.WriteString(dollarTag)
+				i += len(dollarTag) - 1
+				dollarTag = ""
+				continue
+			}
+			current.WriteByte(char)
+			continue
+		}
+
+		if quote != 0 {
+			if char == quote {
+				quote = 0
+			}
+			current.WriteByte(char)
+			continue
+		}
+
+		switch {
+		case char == '-' && strings.HasPrefix(migrationSQL[i:], "--"):
+			end := strings.IndexByte(migrationSQL[i:], '\n')
+			if end == -1 {
+				end = len(migrationSQL) - i
+			}
+			if strings.TrimSpace(migrationSQL[i:i+end]) == constants.NO_TRANSACTION_MARKER {
+				nonTransactional = true
+			}
+			i += end - 1
+			continue
+		case char == '\'' || char == '"':
+			quote = char
+		case char == '$':
+			if end := strings.IndexByte(migrationSQL[i+1:], '$'); end != -1 && isDollarTag(migrationSQL[i+1:i+1+end]) {
+				dollarTag = migrationSQL[i : i+end+2]
+				current.WriteString(dollarTag)
+				i += len(dollarTag) - 1
+				continue
+			}
+		case char == ';':
+			flush()
+			continue
+		}
+
+		current.WriteByte(char)
+	}
+
+	flush()
+	return steps
+}
+
+func ResumePoint(steps []MigrationStep, applied int) int {
+	if applied <= 0 || applied >= len(steps) {
+		return applied
+	}
+
+	indexName, ok := concurrentIndexName(steps[applied].SQL)
+	if ok && steps[applied-1].SQL == "DROP INDEX CONCURRENTLY IF EXISTS "+indexName {
+		return applied - 1
+	}
+	return applied
+}
+
+func concurrentIndexName(sql string) (string, bool) {
+	for _, prefix := range []string{"CREATE INDEX CONCURRENTLY ", "CREATE UNIQUE INDEX CONCURRENTLY "} {
+		if !strings.HasPrefix(sql, prefix) {
+			continue
+		}
+		if fields := strings.Fields(strings.TrimPrefix(sql, prefix)); len(fields) > 0 {
+			return fields[0], true
+		}
+	}
+	return "", false
+}
+
+func isDollarTag(tag string) bool {
+	if tag != "" && tag[0] >= '0' && tag[0] <= '9' {
+		return false
+	}
+	for _, r := range tag {
+		if !(r == '_' || (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9')) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,142 @@
+package migration
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSplitMigrationSteps(t *testing.T) {
+	tests := []struct {
+		name string
+		sql  string
+		want []MigrationStep
+	}{
+		{
+			name: "empty",
+			sql:  "  \n-- only a comment\n",
+		},
+		{
+			name: "statements",
+			sql:  "CREATE TABLE \"A\" (\"id\" INTEGER);\n\nALTER TABLE \"A\" ADD COLUMN \"b\" TEXT;\n",
+			want: []MigrationStep{
+				{SQL: `CREATE TABLE "A" ("id" INTEGER)`},
+				{SQL: `ALTER TABLE "A" ADD COLUMN "b" TEXT`},
+			},
+		},
+		{
+			name: "trailing statement without semicolon",
+			sql:  "SELECT 1;\nSELECT 2",
+			want: []MigrationStep{
+				{SQL: "SELECT 1"},
+				{SQL: "SELECT 2"},
+			},
+		},
+		{
+			name: "marker applies to the next statement only",
+			sql:  "-- blaze:no-transaction\nCREATE INDEX CONCURRENTLY \"i\" ON \"A\" (\"b\");\nDROP TABLE \"B\";\n",
+			want: []MigrationStep{
+				{SQL: `CREATE INDEX CONCURRENTLY "i" ON "A" ("b")`, NonTransactional: true},
+				{SQL: `DROP TABLE "B"`},
+			},
+		},
+		{
+			name: "indented marker",
+			sql:  "SELECT 1;\n   -- blaze:no-transaction   \nVACUUM \"A\";",
+			want: []MigrationStep{
+				{SQL: "SELECT 1"},
+				{SQL: `VACUUM "A"`, NonTransactional: true},
+			},
+		},
+		{
+			name: "other comments are dropped",
+			sql:  "-- blaze:no-transaction-ish\nSELECT 1; -- trailing\nSELECT 2;",
+			want: []MigrationStep{
+				{SQL: "SELECT 1"},
+				{SQL: "SELECT 2"},
+			},
+		},
+		{
+			name: "marker inside a string literal",
+			sql:  "INSERT INTO \"A\" VALUES ('\n-- blaze:no-transaction\n');",
+			want: []MigrationStep{
+				{SQL: "INSERT INTO \"A\" VALUES ('\n-- blaze:no-transaction\n')"},
+			},
+		},
+		{
+			name: "semicolons inside quotes",
+			sql:  `COMMENT ON TABLE "a;b" IS 'x; y';SELECT 1;`,
+			want: []MigrationStep{
+				{SQL: `COMMENT ON TABLE "a;b" IS 'x; y'`},
+				{SQL: "SELECT 1"},
+			},
+		},
+		{
+			name: "dollar quoted body",
+			sql:  "CREATE FUNCTION f() RETURNS TRIGGER AS $$\nBEGIN\n  NEW.a := 'x;'; -- not a marker\n  RETURN NEW;\nEND;\n$$ LANGUAGE plpgsql;\nSELECT 1;",
+			want: []MigrationStep{
+				{SQL: "CREATE FUNCTION f() RETURNS TRIGGER AS $$\nBEGIN\n  NEW.a := 'x;'; -- not a marker\n  RETURN NEW;\nEND;\n$$ LANGUAGE plpgsql"},
+				{SQL: "SELECT 1"},
+			},
+		},
+		{
+			name: "tagged dollar quote",
+			sql:  "DO $body$ BEGIN PERFORM '$$;'; END $body$;SELECT 2;",
+			want: []MigrationStep{
+				{SQL: "DO $body$ BEGIN PERFORM '$$;'; END $body$"},
+				{SQL: "SELECT 2"},
+			},
+		},
+		{
+			name: "positional parameters are not dollar quotes",
+			sql:  "PREPARE p AS SELECT $1, $2;SELECT 3;",
+			want: []MigrationStep{
+				{SQL: "PREPARE p AS SELECT $1, $2"},
+				{SQL: "SELECT 3"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := SplitMigrationSteps(tt.sql)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("SplitMigrationSteps() = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestResumePoint(t *testing.T) {
+	steps := []MigrationStep{
+		{SQL: "SET lock_timeout = '5s'"},
+		{SQL: "DROP INDEX CONCURRENTLY IF EXISTS idx_post_authorid", NonTransactional: true},
+		{SQL: `CREATE INDEX CONCURRENTLY idx_post_authorid ON "Post" ("authorId")`, NonTransactional: true},
+		{SQL: "DROP INDEX CONCURRENTLY IF EXISTS idx_user_email", NonTransactional: true},
+		{SQL: `CREATE UNIQUE INDEX CONCURRENTLY idx_user_email ON "User" ("email")`, NonTransactional: true},
+		{SQL: `CREATE INDEX CONCURRENTLY idx_other ON "Post" ("id")`, NonTransactional: true},
+		{SQL: `ALTER TABLE "Post" VALIDATE CONSTRAINT fk_post_author`, NonTransactional: true},
+	}
+
+	tests := []struct {
+		name    string
+		applied int
+		want    int
+	}{
+		{name: "nothing applied", applied: 0, want: 0},
+		{name: "after a plain step", applied: 1, want: 1},
+		{name: "create after its drop restarts at the drop", applied: 2, want: 1},
+		{name: "after a finished create", applied: 3, want: 3},
+		{name: "unique create after its drop restarts at the drop", applied: 4, want: 3},
+		{name: "create without a matching drop", applied: 5, want: 5},
+		{name: "constraint validation", applied: 6, want: 6},
+		{name: "everything applied", applied: 7, want: 7},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ResumePoint(steps, tt.applied); got != tt.want {
+				t.Errorf("ResumePoint(%d) = %d, want %d", tt.applied, got, tt.want)
+			}
+		})
+	}
+}
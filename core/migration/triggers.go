@@ -0,0 +1,147 @@
+package migration
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/rit3sh-x/blaze/core/ast"
+	"github.com/rit3sh-x/blaze/core/ast/class"
+	"github.com/rit3sh-x/blaze/core/ast/field"
+	"github.com/rit3sh-x/blaze/core/constants"
+)
+
+func (me *MigrationEngine) generateTriggerMigrations() []MigrationStatement {
+	var statements []MigrationStatement
+
+	fromNeedsFunction := me.needsUpdatedAtFunction(me.fromSchema)
+	toNeedsFunction := me.needsUpdatedAtFunction(me.toSchema)
+
+	if toNeedsFunction && !fromNeedsFunction {
+		statements = append(statements, MigrationStatement{
+			SQL:      me.generateUpdatedAtFunctionSQL(),
+			Type:     "function_create",
+			Priority: 1,
+		})
+	}
+
+	for _, newClass := range me.toSchema.Classes {
+		newClocks := updatedAtClocks(newClass, me.toSchema)
+		oldClocks := make(map[string]string)
+		if oldClass := me.fromSchema.GetClassByName(newClass.Name); oldClass != nil {
+			oldClocks = updatedAtClocks(oldClass, me.fromSchema)
+
+			for _, fieldName := range updatedAtFields(oldClass) {
+				if newClocks[fieldName] == oldClocks[fieldName] {
+					continue
+				}
+				statements = append(statements, MigrationStatement{
+					SQL:      fmt.Sprintf(`DROP TRIGGER IF EXISTS %s ON "%s"`, updatedAtTriggerName(newClass.Name, fieldName), newClass.Name),
+					Type:     "trigger_drop",
+					Priority: 5,
+				})
+			}
+		}
+
+		for _, fieldName := range updatedAtFields(newClass) {
+			if oldClocks[fieldName] == newClocks[fieldName] {
+				continue
+			}
+			statements = append(statements, MigrationStatement{
+				SQL: fmt.Sprintf(
+					`CREATE TRIGGER %s BEFORE INSERT OR UPDATE ON "%s" FOR EACH ROW EXECUTE FUNCTION %s('%s', '%s')`,
+					updatedAtTriggerName(newClass.Name, fieldName),
+					newClass.Name,
+					constants.UPDATED_AT_FUNCTION_NAME,
+					fieldName,
+					newClocks[fieldName],
+				),
+				Type:     "trigger_create",
+				Priority: 13,
+			})
+		}
+	}
+
+	if fromNeedsFunction && !toNeedsFunction {
+		statements = append(statements, MigrationStatement{
+			SQL:      fmt.Sprintf("DROP FUNCTION IF EXISTS %s()", constants.UPDATED_AT_FUNCTION_NAME),
+			Type:     "function_drop",
+			Priority: 8,
+		})
+	}
+
+	return statements
+}
+
+func (me *MigrationEngine) generateUpdatedAtFunctionSQL() string {
+	var builder strings.Builder
+
+	builder.WriteString(fmt.Sprintf("CREATE OR REPLACE FUNCTION %s() RETURNS TRIGGER AS $$\n", constants.UPDATED_AT_FUNCTION_NAME))
+	builder.WriteString("DECLARE\n")
+	builder.WriteString("  stamp jsonb;\n")
+	builder.WriteString("BEGIN\n")
+	builder.WriteString("  IF TG_OP = 'INSERT' AND (to_jsonb(NEW) -> TG_ARGV[0]) <> 'null'::jsonb THEN\n")
+	builder.WriteString("    RETURN NEW;\n")
+	builder.WriteString("  END IF;\n")
+	builder.WriteString("  IF TG_OP = 'UPDATE' AND (to_jsonb(NEW) -> TG_ARGV[0]) IS DISTINCT FROM (to_jsonb(OLD) -> TG_ARGV[0]) THEN\n")
+	builder.WriteString("    RETURN NEW;\n")
+	builder.WriteString("  END IF;\n")
+	builder.WriteString("  IF TG_ARGV[1] = 'timestamptz' THEN\n")
+	builder.WriteString("    stamp := to_jsonb(now());\n")
+	builder.WriteString("  ELSE\n")
+	builder.WriteString("    stamp := to_jsonb(LOCALTIMESTAMP);\n")
+	builder.WriteString("  END IF;\n")
+	builder.WriteString("  NEW := jsonb_populate_record(NEW, jsonb_build_object(TG_ARGV[0], stamp));\n")
+	builder.WriteString("  RETURN NEW;\n")
+	builder.WriteString("END;\n")
+	builder.WriteString("$$ LANGUAGE plpgsql")
+
+	return builder.String()
+}
+
+func (me *MigrationEngine) needsUpdatedAtFunction(schema *ast.SchemaAST) bool {
+	for _, cls := range schema.Classes {
+		if len(updatedAtFields(cls)) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+func updatedAtFields(cls *class.Class) []string {
+	var fields []string
+	for _, field := range cls.Attributes.Fields {
+		if field.IsUpdatedAt() {
+			fields = append(fields, field.GetName())
+		}
+	}
+	return fields
+}
+
+func updatedAtClocks(cls *class.Class, schema *ast.SchemaAST) map[string]string {
+	clocks := make(map[string]string)
+	for _, fld := range cls.Attributes.Fields {
+		if fld.IsUpdatedAt() {
+			clocks[fld.GetName()] = updatedAtClock(fld, schema)
+		}
+	}
+	return clocks
+}
+
+func updatedAtClock(fld *field.Field, schema *ast.SchemaAST) string {
+	sqlType := fld.GetBaseType()
+	if nativeType := fld.GetNativeType(); nativeType != nil {
+		sqlType = nativeType.SQL()
+	} else if d := schema.GetDomainByName(sqlType); d != nil {
+		sqlType = d.BaseType
+	}
+
+	upper := strings.ToUpper(sqlType)
+	if strings.HasPrefix(upper, "TIMESTAMPTZ") || strings.Contains(upper, "WITH TIME ZONE") {
+		return "timestamptz"
+	}
+	return "timestamp"
+}
+
+func updatedAtTriggerName(className string, fieldName string) string {
+	return fmt.Sprintf(constants.UPDATED_AT_TRIGGER_NAME, strings.ToLower(className), strings.ToLower(fieldName))
+}
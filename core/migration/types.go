@@ -0,0 +1,194 @@
+package migration
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/rit3sh-x/blaze/core/ast/composite"
+	"github.com/rit3sh-x/blaze/core/ast/domain"
+)
+
+func (me *MigrationEngine) generateDomainMigrations() ([]MigrationStatement, error) {
+	var statements []MigrationStatement
+
+	for _, name := range sortedDomainNames(me.toSchema.Domains) {
+		newDomain := me.toSchema.Domains[name]
+		oldDomain := me.fromSchema.GetDomainByName(name)
+
+		if oldDomain == nil {
+			statements = append(statements, MigrationStatement{
+				SQL:      me.generateCreateDomainSQL(newDomain),
+				Type:     "domain_create",
+				Priority: 3,
+			})
+			continue
+		}
+
+		if oldDomain.Equals(newDomain) {
+			continue
+		}
+
+		if me.domainSQLType(oldDomain) != me.domainSQLType(newDomain) {
+			return nil, fmt.Errorf("changing the base type of domain '%s' from %s to %s is not supported; create a new domain and move columns to it",
+				name, me.domainSQLType(oldDomain), me.domainSQLType(newDomain))
+		}
+
+		if !oldDomain.DefaultEquals(newDomain) {
+			sql := fmt.Sprintf(`ALTER DOMAIN "%s" DROP DEFAULT`, name)
+			if newDomain.DefaultValue != nil {
+				sql = fmt.Sprintf(`ALTER DOMAIN "%s" SET DEFAULT %s`, name, me.formatDefaultExpression(newDomain.DefaultValue, newDomain.BaseType))
+			}
+			statements = append(statements, MigrationStatement{
+				SQL:      sql,
+				Type:     "domain_alter",
+				Priority: 4,
+			})
+		}
+
+		if oldDomain.Check != newDomain.Check {
+			if oldDomain.Check != "" {
+				statements = append(statements, MigrationStatement{
+					SQL:      fmt.Sprintf(`ALTER DOMAIN "%s" DROP CONSTRAINT IF EXISTS "%s"`, name, domainCheckName(name)),
+					Type:     "domain_alter",
+					Priority: 4,
+				})
+			}
+			if newDomain.Check != "" {
+				statements = append(statements, MigrationStatement{
+					SQL:      fmt.Sprintf(`ALTER DOMAIN "%s" ADD CONSTRAINT "%s" CHECK (%s)`, name, domainCheckName(name), newDomain.Check),
+					Type:     "domain_alter",
+					Priority: 4,
+				})
+			}
+		}
+	}
+
+	return statements, nil
+}
+
+func (me *MigrationEngine) generateDomainDrops() []MigrationStatement {
+	var statements []MigrationStatement
+
+	for _, name := range sortedDomainNames(me.fromSchema.Domains) {
+		if me.toSchema.GetDomainByName(name) == nil {
+			statements = append(statements, MigrationStatement{
+				SQL:      fmt.Sprintf(`DROP DOMAIN IF EXISTS "%s"`, name),
+				Type:     "domain_drop",
+				Priority: 12,
+			})
+		}
+	}
+
+	return statements
+}
+
+func (me *MigrationEngine) generateCreateDomainSQL(d *domain.Domain) string {
+	sql := fmt.Sprintf(`CREATE DOMAIN "%s" AS %s`, d.Name, me.domainSQLType(d))
+	if d.DefaultValue != nil {
+		sql += fmt.Sprintf(" DEFAULT %s", me.formatDefaultExpression(d.DefaultValue, d.BaseType))
+	}
+	if d.Check != "" {
+		sql += fmt.Sprintf(` CONSTRAINT "%s" CHECK (%s)`, domainCheckName(d.Name), d.Check)
+	}
+	return sql
+}
+
+func (me *MigrationEngine) domainSQLType(d *domain.Domain) string {
+	if d.NativeType != nil {
+		return d.NativeType.SQL()
+	}
+	pgType, _ := mapScalarToPGType(d.BaseType)
+	return pgType
+}
+
+func (me *MigrationEngine) generateCompositeMigrations() []MigrationStatement {
+	var statements []MigrationStatement
+
+	for _, newType := range me.toSchema.GetCompositeOrder() {
+		oldType := me.fromSchema.GetCompositeByName(newType.Name)
+
+		if oldType == nil {
+			statements = append(statements, MigrationStatement{
+				SQL:      me.generateCreateCompositeSQL(newType),
+				Type:     "type_create",
+				Priority: 3,
+			})
+			continue
+		}
+
+		var changes []string
+		for _, oldField := range oldType.Fields {
+			if newType.GetFieldByName(oldField.Name) == nil {
+				changes = append(changes, fmt.Sprintf(`DROP ATTRIBUTE IF EXISTS "%s"`, oldField.Name))
+			}
+		}
+		for _, newField := range newType.Fields {
+			oldField := oldType.GetFieldByName(newField.Name)
+			if oldField == nil {
+				changes = append(changes, fmt.Sprintf(`ADD ATTRIBUTE "%s" %s`, newField.Name, me.compositeFieldSQLType(newField)))
+			} else if !oldField.Equals(newField) {
+				changes = append(changes, fmt.Sprintf(`ALTER ATTRIBUTE "%s" TYPE %s`, newField.Name, me.compositeFieldSQLType(newField)))
+			}
+		}
+
+		if len(changes) > 0 {
+			statements = append(statements, MigrationStatement{
+				SQL:      fmt.Sprintf(`ALTER TYPE "%s" %s`, newType.Name, strings.Join(changes, ", ")),
+				Type:     "type_alter",
+				Priority: 4,
+			})
+		}
+	}
+
+	return statements
+}
+
+func (me *MigrationEngine) generateCompositeDrops() []MigrationStatement {
+	var statements []MigrationStatement
+
+	order := me.fromSchema.GetCompositeOrder()
+	for i := len(order) - 1; i >= 0; i-- {
+		if me.toSchema.GetCompositeByName(order[i].Name) == nil {
+			statements = append(statements, MigrationStatement{
+				SQL:      fmt.Sprintf(`DROP TYPE IF EXISTS "%s"`, order[i].Name),
+				Type:     "type_drop",
+				Priority: 12,
+			})
+		}
+	}
+
+	return statements
+}
+
+func (me *MigrationEngine) generateCreateCompositeSQL(compositeType *composite.CompositeType) string {
+	var fields []string
+	for _, fld := range compositeType.Fields {
+		fields = append(fields, fmt.Sprintf(`"%s" %s`, fld.Name, me.compositeFieldSQLType(fld)))
+	}
+	return fmt.Sprintf(`CREATE TYPE "%s" AS (%s)`, compositeType.Name, strings.Join(fields, ", "))
+}
+
+func (me *MigrationEngine) compositeFieldSQLType(fld *composite.CompositeField) string {
+	sqlType, ok := mapScalarToPGType(fld.Type)
+	if !ok {
+		sqlType = applyQuotes(fld.Type)
+	}
+	if fld.IsArray {
+		sqlType += "[]"
+	}
+	return sqlType
+}
+
+func sortedDomainNames(domains map[string]*domain.Domain) []string {
+	var names []string
+	for name := range domains {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func domainCheckName(domainName string) string {
+	return fmt.Sprintf("%s_check", domainName)
+}
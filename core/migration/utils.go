@@ -6,6 +6,7 @@ import (
 
 	"github.com/rit3sh-x/blaze/core/ast/class"
 	"github.com/rit3sh-x/blaze/core/ast/field"
+	"github.com/rit3sh-x/blaze/core/ast/field/defaults"
 	"github.com/rit3sh-x/blaze/core/constants"
 )
 
@@ -27,8 +28,10 @@ func (me *MigrationEngine) generateColumnDefinition(field *field.Field, cls *cla
 		parts = append(parts, "NOT NULL")
 	}
 
-	if field.HasDefault() {
-		defaultValue := me.generateDefaultValue(field)
+	if field.IsComputed() {
+		parts = append(parts, fmt.Sprintf("GENERATED ALWAYS AS (%s) STORED", field.GetComputedExpression()))
+	} else if field.HasDefault() {
+		defaultValue := me.generateDefaultValue(field, cls)
 		if defaultValue != "" {
 			parts = append(parts, defaultValue)
 		}
@@ -44,103 +47,177 @@ func (me *MigrationEngine) generateColumnDefinition(field *field.Field, cls *cla
 func (me *MigrationEngine) mapToPGType(field *field.Field, cls *class.Class) (string, error) {
 	baseType := field.GetBaseType()
 
-	if constants.IsScalarType(baseType) {
-		switch constants.ScalarType(baseType) {
-		case constants.INT:
-			return "INTEGER", nil
-		case constants.BIGINT:
-			return "BIGINT", nil
-		case constants.SMALLINT:
-			return "SMALLINT", nil
-		case constants.FLOAT:
-			return "DOUBLE PRECISION", nil
-		case constants.NUMERIC:
-			return "NUMERIC", nil
-		case constants.STRING:
-			if field.HasDefault() && field.AttributeDefinition.DefaultValue != nil {
-				if callback, ok := field.AttributeDefinition.DefaultValue.Value.(string); ok {
-					if callback == constants.DEFAULT_UUID_CALLBACK {
-						return "UUID", nil
-					}
-				}
-			}
+	if nativeType := field.GetNativeType(); nativeType != nil {
+		return nativeType.SQL(), nil
+	}
 
-			if !field.HasRelation() {
-				if cls != nil {
-					for _, otherField := range cls.Attributes.Fields {
-						if otherField.HasRelation() && otherField != field {
-							relation := otherField.AttributeDefinition.Relation
-							if relation != nil {
-								for _, fromField := range relation.From {
-									if fromField == field.GetName() {
-										referencedClass := me.toSchema.GetClassByName(relation.ToClass)
-										if referencedClass != nil {
-											for i, toFieldName := range relation.To {
-												if i < len(relation.From) && relation.From[i] == field.GetName() {
-													for _, referencedField := range referencedClass.Attributes.Fields {
-														if referencedField.GetName() == toFieldName {
-															if referencedField.GetBaseType() == "String" &&
-																referencedField.HasDefault() &&
-																referencedField.AttributeDefinition.DefaultValue != nil {
-																switch v := referencedField.AttributeDefinition.DefaultValue.Value.(type) {
-																case string:
-																	if v == constants.DEFAULT_UUID_CALLBACK {
-																		return "UUID", nil
-																	}
-																}
-															}
-															break
-														}
-													}
-												}
-											}
-										}
-										break
-									}
-								}
-							}
-						}
-					}
-				}
-			}
+	if baseType == constants.STRING.String() && me.isLegacyUUIDField(field, cls) {
+		return "UUID", nil
+	}
 
-			return "TEXT", nil
-		case constants.BOOLEAN:
-			return "BOOLEAN", nil
-		case constants.DATE:
-			return "DATE", nil
-		case constants.TIMESTAMP:
-			return "TIMESTAMP(3)", nil
-		case constants.JSON:
-			return "JSONB", nil
-		case constants.BYTES:
-			return "BYTEA", nil
-		case constants.CHAR:
-			return "CHAR(1)", nil
-		}
+	if pgType, ok := mapScalarToPGType(baseType); ok {
+		return pgType, nil
 	}
 
 	if me.toSchema.GetEnumByName(baseType) != nil {
 		return baseType, nil
 	}
 
+	if me.toSchema.GetCompositeByName(baseType) != nil || me.toSchema.GetDomainByName(baseType) != nil {
+		return applyQuotes(baseType), nil
+	}
+
 	return "", fmt.Errorf("unknown type: %s", baseType)
 }
 
-func (me *MigrationEngine) generateDefaultValue(field *field.Field) string {
-	defaultValue := field.AttributeDefinition.DefaultValue
+func mapScalarToPGType(baseType string) (string, bool) {
+	if !constants.IsScalarType(baseType) {
+		return "", false
+	}
+
+	switch constants.ScalarType(baseType) {
+	case constants.INT:
+		return "INTEGER", true
+	case constants.BIGINT:
+		return "BIGINT", true
+	case constants.SMALLINT:
+		return "SMALLINT", true
+	case constants.FLOAT:
+		return "DOUBLE PRECISION", true
+	case constants.NUMERIC:
+		return "NUMERIC", true
+	case constants.STRING:
+		return "TEXT", true
+	case constants.BOOLEAN:
+		return "BOOLEAN", true
+	case constants.DATE:
+		return "DATE", true
+	case constants.TIMESTAMP:
+		return "TIMESTAMP(3)", true
+	case constants.TIMESTAMPTZ:
+		return "TIMESTAMPTZ(3)", true
+	case constants.TIME:
+		return "TIME(3)", true
+	case constants.INTERVAL:
+		return "INTERVAL", true
+	case constants.UUID:
+		return "UUID", true
+	case constants.DECIMAL:
+		return "NUMERIC", true
+	case constants.INET:
+		return "INET", true
+	case constants.CIDR:
+		return "CIDR", true
+	case constants.INT4RANGE:
+		return "INT4RANGE", true
+	case constants.TSTZRANGE:
+		return "TSTZRANGE", true
+	case constants.JSON:
+		return "JSONB", true
+	case constants.BYTES:
+		return "BYTEA", true
+	case constants.CHAR:
+		return "CHAR(1)", true
+	}
+
+	return "", false
+}
+
+func (me *MigrationEngine) resolveBaseType(typeName string) string {
+	if d := me.toSchema.GetDomainByName(typeName); d != nil {
+		return d.BaseType
+	}
+	return typeName
+}
+
+func (me *MigrationEngine) isLegacyUUIDField(field *field.Field, cls *class.Class) bool {
+	if field.GetBaseType() != constants.STRING.String() {
+		return false
+	}
+
+	if isUUIDDefault(field) {
+		return true
+	}
+
+	if field.HasRelation() || cls == nil {
+		return false
+	}
+
+	for _, otherField := range cls.Attributes.Fields {
+		if otherField == field || !otherField.HasRelation() {
+			continue
+		}
+
+		relation := otherField.AttributeDefinition.Relation
+		if relation == nil {
+			continue
+		}
+
+		referencedClass := me.toSchema.GetClassByName(relation.ToClass)
+		if referencedClass == nil {
+			continue
+		}
+
+		for i, fromField := range relation.From {
+			if fromField != field.GetName() || i >= len(relation.To) {
+				continue
+			}
+
+			referencedField := referencedClass.Attributes.GetFieldByName(relation.To[i])
+			if referencedField == nil {
+				continue
+			}
+
+			if referencedField.GetBaseType() == constants.UUID.String() || (referencedField.GetBaseType() == constants.STRING.String() && isUUIDDefault(referencedField)) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func isUUIDDefault(field *field.Field) bool {
+	if !field.HasDefault() || field.AttributeDefinition.DefaultValue == nil {
+		return false
+	}
+	return field.AttributeDefinition.DefaultValue.Value == constants.DEFAULT_UUID_CALLBACK
+}
+
+func (me *MigrationEngine) generateDefaultValue(field *field.Field, cls *class.Class) string {
+	if me.usesIdentity(field) {
+		return "GENERATED BY DEFAULT AS IDENTITY"
+	}
+	return fmt.Sprintf("DEFAULT %s", me.generateDefaultExpression(field, cls))
+}
+
+func (me *MigrationEngine) generateDefaultExpression(field *field.Field, cls *class.Class) string {
+	if isAutoincrement(field) {
+		return nextvalExpression(ownedSequenceName(cls.Name, field.GetName()))
+	}
+	return me.formatDefaultExpression(field.AttributeDefinition.DefaultValue, me.resolveBaseType(field.GetBaseType()))
+}
+
+func (me *MigrationEngine) formatDefaultExpression(defaultValue *defaults.DefaultValue, baseType string) string {
 	if defaultValue.IsCallback() {
 		callback := defaultValue.GetValue().(string)
 		switch callback {
 		case constants.DEFAULT_NOW_CALLBACK:
-			return "DEFAULT CURRENT_TIMESTAMP(3)"
+			if baseType == constants.TIME.String() {
+				return "LOCALTIME(3)"
+			}
+			return "CURRENT_TIMESTAMP(3)"
 		case constants.DEFAULT_UUID_CALLBACK:
-			return "DEFAULT gen_random_uuid()"
-		case constants.DEFAULT_AUTOINCREMENT_CALLBACK:
-			return "GENERATED BY DEFAULT AS IDENTITY"
+			return "gen_random_uuid()"
+		case constants.DEFAULT_UUIDV7_CALLBACK:
+			return "uuidv7()"
 		}
 	}
 
+	if defaultValue.IsSequence() {
+		return nextvalExpression(fmt.Sprintf("%v", defaultValue.GetValue()))
+	}
+
 	if defaultValue.IsArray {
 		if elements, ok := defaultValue.GetArrayElements(); ok {
 			var values []string
@@ -151,7 +228,23 @@ func (me *MigrationEngine) generateDefaultValue(field *field.Field) string {
 		}
 	}
 
-	return fmt.Sprintf("DEFAULT %s", me.formatValue(defaultValue.GetValue()))
+	if baseType == constants.DECIMAL.String() {
+		return fmt.Sprintf("%v", defaultValue.GetValue())
+	}
+
+	return me.formatValue(defaultValue.GetValue())
+}
+
+func (me *MigrationEngine) usesIdentity(field *field.Field) bool {
+	return isAutoincrement(field) && me.options.Autoincrement != constants.AUTOINCREMENT_SEQUENCE
+}
+
+func isAutoincrement(field *field.Field) bool {
+	if !field.HasDefault() {
+		return false
+	}
+	defaultValue := field.AttributeDefinition.DefaultValue
+	return defaultValue.IsCallback() && defaultValue.GetValue() == constants.DEFAULT_AUTOINCREMENT_CALLBACK
 }
 
 func (me *MigrationEngine) formatValue(value interface{}) string {
@@ -0,0 +1,208 @@
+package migration
+
+import (
+	"fmt"
+
+	"github.com/rit3sh-x/blaze/core/ast/view"
+)
+
+func (me *MigrationEngine) generateViewDrops() []MigrationStatement {
+	var statements []MigrationStatement
+	recreate, _ := me.collectViewChanges()
+
+	order := me.fromSchema.GetViewOrder()
+	for i := len(order) - 1; i >= 0; i-- {
+		oldView := order[i]
+		if me.toSchema.GetViewByName(oldView.Name) != nil && !recreate[oldView.Name] {
+			continue
+		}
+		statements = append(statements, MigrationStatement{
+			SQL:      me.generateDropViewSQL(oldView),
+			Type:     "view_drop",
+			Priority: 2,
+		})
+	}
+
+	return statements
+}
+
+func (me *MigrationEngine) generateViewMigrations() []MigrationStatement {
+	var statements []MigrationStatement
+	recreate, replace := me.collectViewChanges()
+
+	for _, newView := range me.toSchema.GetViewOrder() {
+		oldView := me.fromSchema.GetViewByName(newView.Name)
+
+		if oldView == nil || recreate[newView.Name] {
+			statements = append(statements, MigrationStatement{
+				SQL:      me.generateCreateViewSQL(newView, false),
+				Type:     "view_create",
+				Priority: 13,
+			})
+			statements = append(statements, me.generateViewIndexStatements(nil, newView)...)
+			continue
+		}
+
+		if replace[newView.Name] {
+			statements = append(statements, MigrationStatement{
+				SQL:      me.generateCreateViewSQL(newView, true),
+				Type:     "view_replace",
+				Priority: 13,
+			})
+		}
+
+		statements = append(statements, me.generateViewIndexStatements(oldView, newView)...)
+	}
+
+	return statements
+}
+
+func (me *MigrationEngine) collectViewChanges() (map[string]bool, map[string]bool) {
+	recreate := make(map[string]bool)
+	replace := make(map[string]bool)
+	changedClasses := me.collectRebuiltClasses()
+
+	for _, newView := range me.toSchema.GetViewOrder() {
+		oldView := me.fromSchema.GetViewByName(newView.Name)
+		if oldView == nil {
+			continue
+		}
+
+		dependencyChanged := false
+		for _, dependency := range me.fromSchema.GetViewDependencies(oldView) {
+			if changedClasses[dependency] || recreate[dependency] {
+				dependencyChanged = true
+				break
+			}
+			if me.fromSchema.GetViewByName(dependency) != nil && me.toSchema.GetViewByName(dependency) == nil {
+				dependencyChanged = true
+				break
+			}
+		}
+
+		switch {
+		case dependencyChanged:
+			recreate[newView.Name] = true
+		case oldView.Equals(newView):
+		case !newView.Materialized && !oldView.Materialized && viewFieldsEqual(oldView, newView):
+			replace[newView.Name] = true
+		default:
+			recreate[newView.Name] = true
+		}
+	}
+
+	return recreate, replace
+}
+
+func (me *MigrationEngine) collectRebuiltClasses() map[string]bool {
+	changed := make(map[string]bool)
+
+	for _, oldClass := range me.fromSchema.Classes {
+		newClass := me.toSchema.GetClassByName(oldClass.Name)
+		if newClass == nil {
+			changed[oldClass.Name] = true
+			continue
+		}
+
+		for _, oldField := range oldClass.Attributes.Fields {
+			if oldField.IsObject() {
+				continue
+			}
+			newField := newClass.Attributes.GetFieldByName(oldField.GetName())
+			if newField == nil {
+				changed[oldClass.Name] = true
+				break
+			}
+			oldType, oldErr := me.mapToPGType(oldField, oldClass)
+			newType, newErr := me.mapToPGType(newField, newClass)
+			if oldErr != nil || newErr != nil || oldType != newType {
+				changed[oldClass.Name] = true
+				break
+			}
+		}
+	}
+
+	return changed
+}
+
+func (me *MigrationEngine) generateViewIndexStatements(oldView, newView *view.View) []MigrationStatement {
+	var statements []MigrationStatement
+
+	oldIndexes := make(map[string]string)
+	if oldView != nil {
+		oldIndexes, _ = me.collectViewIndexes(oldView)
+	}
+	newIndexes, newOrder := me.collectViewIndexes(newView)
+
+	if oldView != nil {
+		_, oldOrder := me.collectViewIndexes(oldView)
+		for _, indexName := range oldOrder {
+			if newSQL, exists := newIndexes[indexName]; exists && newSQL == oldIndexes[indexName] {
+				continue
+			}
+			statements = append(statements, MigrationStatement{
+				SQL:      fmt.Sprintf("DROP INDEX IF EXISTS %s", indexName),
+				Type:     "index_drop",
+				Priority: 12,
+			})
+		}
+	}
+
+	for _, indexName := range newOrder {
+		if oldSQL, exists := oldIndexes[indexName]; exists && oldSQL == newIndexes[indexName] {
+			continue
+		}
+		statements = append(statements, MigrationStatement{
+			SQL:      newIndexes[indexName],
+			Type:     "index_create",
+			Priority: 13,
+		})
+	}
+
+	return statements
+}
+
+func (me *MigrationEngine) collectViewIndexes(v *view.View) (map[string]string, []string) {
+	indexes := make(map[string]string)
+	var order []string
+
+	for _, viewIndex := range v.Indexes {
+		indexName := v.IndexName(viewIndex)
+		if _, exists := indexes[indexName]; !exists {
+			order = append(order, indexName)
+		}
+		indexes[indexName] = me.generateIndexSQL(v.Name, indexName, viewIndex.Index, viewIndex.Unique)
+	}
+
+	return indexes, order
+}
+
+func (me *MigrationEngine) generateCreateViewSQL(v *view.View, orReplace bool) string {
+	if v.Materialized {
+		return fmt.Sprintf(`CREATE MATERIALIZED VIEW "%s" AS %s`, v.Name, v.SQL)
+	}
+	if orReplace {
+		return fmt.Sprintf(`CREATE OR REPLACE VIEW "%s" AS %s`, v.Name, v.SQL)
+	}
+	return fmt.Sprintf(`CREATE VIEW "%s" AS %s`, v.Name, v.SQL)
+}
+
+func (me *MigrationEngine) generateDropViewSQL(v *view.View) string {
+	if v.Materialized {
+		return fmt.Sprintf(`DROP MATERIALIZED VIEW IF EXISTS "%s"`, v.Name)
+	}
+	return fmt.Sprintf(`DROP VIEW IF EXISTS "%s"`, v.Name)
+}
+
+func viewFieldsEqual(oldView, newView *view.View) bool {
+	if len(oldView.Fields) == 0 || len(oldView.Fields) != len(newView.Fields) {
+		return false
+	}
+	for i, oldField := range oldView.Fields {
+		newField := newView.Fields[i]
+		if oldField.Name != newField.Name || oldField.Type != newField.Type || oldField.IsArray != newField.IsArray {
+			return false
+		}
+	}
+	return true
+}
@@ -3,18 +3,24 @@ package shadow
 import (
 	"fmt"
 	"regexp"
+	"strconv"
 	"strings"
 
 	"github.com/rit3sh-x/blaze/core/ast"
 	"github.com/rit3sh-x/blaze/core/ast/class"
 	"github.com/rit3sh-x/blaze/core/ast/class/attributes"
 	"github.com/rit3sh-x/blaze/core/ast/class/directives"
+	"github.com/rit3sh-x/blaze/core/ast/composite"
+	"github.com/rit3sh-x/blaze/core/ast/domain"
 	"github.com/rit3sh-x/blaze/core/ast/enum"
 	"github.com/rit3sh-x/blaze/core/ast/field"
 	fieldattributes "github.com/rit3sh-x/blaze/core/ast/field/attributes"
 	"github.com/rit3sh-x/blaze/core/ast/field/defaults"
 	fielddirectives "github.com/rit3sh-x/blaze/core/ast/field/directives"
+	"github.com/rit3sh-x/blaze/core/ast/field/native"
 	"github.com/rit3sh-x/blaze/core/ast/field/relations"
+	"github.com/rit3sh-x/blaze/core/ast/sequence"
+	"github.com/rit3sh-x/blaze/core/ast/view"
 	"github.com/rit3sh-x/blaze/core/constants"
 )
 
@@ -27,57 +33,152 @@ type SQLParser struct {
 	alterTableRegex      *regexp.Regexp
 	addColumnRegex       *regexp.Regexp
 	dropColumnRegex      *regexp.Regexp
+	dropConstraintRegex  *regexp.Regexp
 	indexRegex           *regexp.Regexp
 	dropIndexRegex       *regexp.Regexp
 	foreignKeyRegex      *regexp.Regexp
 	checkConstraintRegex *regexp.Regexp
+	createTriggerRegex   *regexp.Regexp
+	dropTriggerRegex     *regexp.Regexp
+	generatedRegex       *regexp.Regexp
+	setExpressionRegex   *regexp.Regexp
+	dropExpressionRegex  *regexp.Regexp
+	createSequenceRegex  *regexp.Regexp
+	alterSequenceRegex   *regexp.Regexp
+	dropSequenceRegex    *regexp.Regexp
+	sequenceOptionRegex  *regexp.Regexp
+	ownedByRegex         *regexp.Regexp
+	nextvalRegex         *regexp.Regexp
+	defaultKeywordRegex  *regexp.Regexp
+	setDefaultRegex      *regexp.Regexp
+	dropDefaultRegex     *regexp.Regexp
+	addIdentityRegex     *regexp.Regexp
+	dropIdentityRegex    *regexp.Regexp
+	alterTypeRegex       *regexp.Regexp
+	nativeTypeRegex      *regexp.Regexp
+	createTypeRegex      *regexp.Regexp
+	alterTypeAttrRegex   *regexp.Regexp
+	typeAttributeRegex   *regexp.Regexp
+	dropAttributeRegex   *regexp.Regexp
+	createDomainRegex    *regexp.Regexp
+	alterDomainRegex     *regexp.Regexp
+	dropDomainRegex      *regexp.Regexp
+	domainCheckRegex     *regexp.Regexp
+	createViewRegex      *regexp.Regexp
+	dropViewRegex        *regexp.Regexp
+	uniqueIndexRegex     *regexp.Regexp
+	createPolicyRegex    *regexp.Regexp
+	dropPolicyRegex      *regexp.Regexp
+	rowSecurityRegex     *regexp.Regexp
+	partitionOfRegex     *regexp.Regexp
+	partitionByRegex     *regexp.Regexp
 }
 
-type ParsedIndex struct {
-	Name    string
-	Table   string
-	Columns []string
-	Type    string
-	IsText  bool
+const columnTypePattern = `((?:"[^"]+"|[A-Z][A-Z0-9_]*(?:\s+PRECISION)?(?:\(\d+(?:,\s*\d+)?\))?)(?:\[\])?)`
+
+var temporalNativeTypes = map[string]string{
+	"TIMESTAMP":   constants.NATIVE_TYPE_TIMESTAMP,
+	"TIMESTAMPTZ": constants.NATIVE_TYPE_TIMESTAMPTZ,
+	"TIME":        constants.NATIVE_TYPE_TIME,
 }
 
 func NewSQLParser() *SQLParser {
 	return &SQLParser{
-		createTableRegex:     regexp.MustCompile(`CREATE\s+TABLE\s+"([^"]+)"\s*\(\s*((?:[^;])*?)\s*\)`),
+		createTableRegex:     regexp.MustCompile(`CREATE\s+TABLE\s+"([^"]+)"\s*\(\s*((?:[^;])*)\s*\)\s*$`),
 		createEnumRegex:      regexp.MustCompile(`CREATE\s+TYPE\s+"([^"]+)"\s+AS\s+ENUM\s*\(\s*([^)]+)\s*\)`),
 		alterEnumRegex:       regexp.MustCompile(`ALTER\s+TYPE\s+"([^"]+)"\s+ADD\s+VALUE\s+'([^']+)'`),
 		dropTableRegex:       regexp.MustCompile(`DROP\s+TABLE\s+(?:IF\s+EXISTS\s+)?"([^"]+)"`),
 		dropEnumRegex:        regexp.MustCompile(`DROP\s+TYPE\s+(?:IF\s+EXISTS\s+)?"([^"]+)"`),
 		alterTableRegex:      regexp.MustCompile(`ALTER\s+TABLE\s+"([^"]+)"\s+(.*)`),
-		addColumnRegex:       regexp.MustCompile(`ADD\s+COLUMN\s+"([^"]+)"\s+([A-Z][A-Z0-9_\(\)]+(?:\[\])?)\s*(.*)`),
+		addColumnRegex:       regexp.MustCompile(`ADD\s+COLUMN\s+"([^"]+)"\s+` + columnTypePattern + `\s*(.*)`),
 		dropColumnRegex:      regexp.MustCompile(`DROP\s+COLUMN\s+(?:IF\s+EXISTS\s+)?"([^"]+)"`),
-		indexRegex:           regexp.MustCompile(`CREATE\s+INDEX\s+(\w+)\s+ON\s+"([^"]+)"\s*(?:USING\s+(\w+))?\s*\(\s*([^)]+)\s*\)`),
-		dropIndexRegex:       regexp.MustCompile(`DROP\s+INDEX\s+(?:IF\s+EXISTS\s+)?(\w+)`),
-		foreignKeyRegex:      regexp.MustCompile(`FOREIGN\s+KEY\s*\(\s*([^)]+)\s*\)\s+REFERENCES\s+"([^"]+)"\s*\(\s*([^)]+)\s*\)(?:\s+ON\s+DELETE\s+(\w+(?:\s+\w+)?))?(?:\s+ON\s+UPDATE\s+(\w+(?:\s+\w+)?))?`),
-		checkConstraintRegex: regexp.MustCompile(`CHECK\s*\(\s*([^)]+)\s*\)`),
+		dropConstraintRegex:  regexp.MustCompile(`DROP\s+CONSTRAINT\s+(?:IF\s+EXISTS\s+)?"?(\w+)"?`),
+		indexRegex:           regexp.MustCompile(`(?i)^CREATE\s+(?:UNIQUE\s+)?INDEX\s`),
+		dropIndexRegex:       regexp.MustCompile(`DROP\s+INDEX\s+(?:CONCURRENTLY\s+)?(?:IF\s+EXISTS\s+)?(\w+)`),
+		foreignKeyRegex:      regexp.MustCompile(`(?:CONSTRAINT\s+(\w+)\s+)?FOREIGN\s+KEY\s*\(\s*([^)]+)\s*\)\s+REFERENCES\s+"([^"]+)"\s*\(\s*([^)]+)\s*\)(?:\s+ON\s+DELETE\s+(CASCADE|RESTRICT|SET\s+NULL|SET\s+DEFAULT|NO\s+ACTION))?(?:\s+ON\s+UPDATE\s+(CASCADE|RESTRICT|SET\s+NULL|SET\s+DEFAULT|NO\s+ACTION))?(\s+DEFERRABLE)?`),
+		checkConstraintRegex: regexp.MustCompile(`CHECK\s*\(\s*(.+)\s*\)`),
+		createTriggerRegex:   regexp.MustCompile(`^CREATE\s+TRIGGER\s+(\w+)\s+BEFORE\s+(?:INSERT\s+OR\s+)?UPDATE\s+ON\s+"([^"]+)"\s+FOR\s+EACH\s+ROW\s+EXECUTE\s+(?:FUNCTION|PROCEDURE)\s+` + constants.UPDATED_AT_FUNCTION_NAME + `\(\s*'([^']+)'(?:\s*,\s*'[^']*')?\s*\)`),
+		generatedRegex:       regexp.MustCompile(`(?i)GENERATED\s+ALWAYS\s+AS\s*\(`),
+		setExpressionRegex:   regexp.MustCompile(`^ALTER\s+COLUMN\s+"([^"]+)"\s+SET\s+EXPRESSION\s+AS\s*\((.*)\)\s*$`),
+		dropExpressionRegex:  regexp.MustCompile(`^ALTER\s+COLUMN\s+"([^"]+)"\s+DROP\s+EXPRESSION`),
+		dropTriggerRegex:     regexp.MustCompile(`^DROP\s+TRIGGER\s+(?:IF\s+EXISTS\s+)?(\w+)\s+ON\s+"([^"]+)"`),
+		createSequenceRegex:  regexp.MustCompile(`^CREATE\s+SEQUENCE\s+(?:IF\s+NOT\s+EXISTS\s+)?"([^"]+)"(.*)$`),
+		alterSequenceRegex:   regexp.MustCompile(`^ALTER\s+SEQUENCE\s+(?:IF\s+EXISTS\s+)?"([^"]+)"\s+(.*)$`),
+		dropSequenceRegex:    regexp.MustCompile(`^DROP\s+SEQUENCE\s+(?:IF\s+EXISTS\s+)?"([^"]+)"`),
+		sequenceOptionRegex:  regexp.MustCompile(`(START\s+WITH|INCREMENT\s+BY|CACHE)\s+(-?\d+)`),
+		ownedByRegex:         regexp.MustCompile(`OWNED\s+BY\s+"[^"]+"\."[^"]+"`),
+		nextvalRegex:         regexp.MustCompile(`^nextval\('"?([^"']+)"?'(?:::regclass)?\)$`),
+		defaultKeywordRegex:  regexp.MustCompile(`(?:^|\s)DEFAULT\s+`),
+		setDefaultRegex:      regexp.MustCompile(`^ALTER\s+COLUMN\s+"([^"]+)"\s+SET\s+DEFAULT\s+(.+)$`),
+		dropDefaultRegex:     regexp.MustCompile(`^ALTER\s+COLUMN\s+"([^"]+)"\s+DROP\s+DEFAULT`),
+		addIdentityRegex:     regexp.MustCompile(`^ALTER\s+COLUMN\s+"([^"]+)"\s+ADD\s+GENERATED\s+BY\s+DEFAULT\s+AS\s+IDENTITY`),
+		dropIdentityRegex:    regexp.MustCompile(`^ALTER\s+COLUMN\s+"([^"]+)"\s+DROP\s+IDENTITY`),
+		alterTypeRegex:       regexp.MustCompile(`^ALTER\s+COLUMN\s+"([^"]+)"\s+TYPE\s+` + columnTypePattern),
+		nativeTypeRegex:      regexp.MustCompile(`^(VARCHAR|CHAR|NUMERIC|TIMESTAMPTZ|TIMESTAMP|TIME)\((\d+)(?:,\s*(\d+))?\)$`),
+		createTypeRegex:      regexp.MustCompile(`^CREATE\s+TYPE\s+"([^"]+)"\s+AS\s*\((.*)\)\s*$`),
+		alterTypeAttrRegex:   regexp.MustCompile(`^ALTER\s+TYPE\s+"([^"]+)"\s+((?:ADD|DROP|ALTER)\s+ATTRIBUTE\s.*)$`),
+		typeAttributeRegex:   regexp.MustCompile(`^(?:(?:ADD|ALTER)\s+ATTRIBUTE\s+)?"([^"]+)"\s+(?:TYPE\s+)?` + columnTypePattern + `\s*$`),
+		dropAttributeRegex:   regexp.MustCompile(`^DROP\s+ATTRIBUTE\s+(?:IF\s+EXISTS\s+)?"([^"]+)"`),
+		createDomainRegex:    regexp.MustCompile(`^CREATE\s+DOMAIN\s+"([^"]+)"\s+AS\s+` + columnTypePattern + `\s*(.*)$`),
+		alterDomainRegex:     regexp.MustCompile(`^ALTER\s+DOMAIN\s+"([^"]+)"\s+(.*)$`),
+		dropDomainRegex:      regexp.MustCompile(`^DROP\s+DOMAIN\s+(?:IF\s+EXISTS\s+)?"([^"]+)"`),
+		domainCheckRegex:     regexp.MustCompile(`(?:CONSTRAINT\s+"?[^"\s]+"?\s+)?CHECK\s*\((.*)\)\s*$`),
+		createViewRegex:      regexp.MustCompile(`(?s)^CREATE\s+(?:OR\s+REPLACE\s+)?(MATERIALIZED\s+)?VIEW\s+"([^"]+)"\s+AS\s+(.*)$`),
+		dropViewRegex:        regexp.MustCompile(`^DROP\s+(?:MATERIALIZED\s+)?VIEW\s+(?:IF\s+EXISTS\s+)?"([^"]+)"`),
+		uniqueIndexRegex:     regexp.MustCompile(`(?i)^CREATE\s+UNIQUE\s`),
+		createPolicyRegex:    regexp.MustCompile(`(?i)^CREATE\s+POLICY\s`),
+		dropPolicyRegex:      regexp.MustCompile(`^DROP\s+POLICY\s+(?:IF\s+EXISTS\s+)?"([^"]+)"\s+ON\s+"([^"]+)"`),
+		rowSecurityRegex:     regexp.MustCompile(`^(ENABLE|DISABLE)\s+ROW\s+LEVEL\s+SECURITY`),
+		partitionOfRegex:     regexp.MustCompile(`(?i)^CREATE\s+TABLE\s+(?:IF\s+NOT\s+EXISTS\s+)?"?[a-zA-Z0-9_]+"?\s+PARTITION\s+OF\s`),
+		partitionByRegex:     regexp.MustCompile(`(?is)\)\s*PARTITION\s+BY\s+((?:RANGE|LIST|HASH)\s*\([^)]*\))\s*$`),
 	}
 }
 
 func (p *SQLParser) ApplyMigrationToAST(currentAST *ast.SchemaAST, migrationSQL string) (*ast.SchemaAST, error) {
 	if currentAST == nil {
 		currentAST = &ast.SchemaAST{
-			Enums:   make(map[string]*enum.Enum),
-			Classes: []*class.Class{},
+			Enums:      make(map[string]*enum.Enum),
+			Sequences:  make(map[string]*sequence.Sequence),
+			Composites: make(map[string]*composite.CompositeType),
+			Domains:    make(map[string]*domain.Domain),
+			Classes:    []*class.Class{},
+			Views:      make(map[string]*view.View),
 		}
 	}
 
 	newAST := &ast.SchemaAST{
-		Enums:   make(map[string]*enum.Enum),
-		Classes: make([]*class.Class, len(currentAST.Classes)),
+		Enums:      make(map[string]*enum.Enum),
+		Sequences:  make(map[string]*sequence.Sequence),
+		Composites: make(map[string]*composite.CompositeType),
+		Domains:    make(map[string]*domain.Domain),
+		Classes:    make([]*class.Class, len(currentAST.Classes)),
+		Views:      make(map[string]*view.View),
 	}
 
 	for k, v := range currentAST.Enums {
 		newAST.Enums[k] = p.copyEnum(v)
 	}
 
+	for k, v := range currentAST.Sequences {
+		seqCopy := *v
+		newAST.Sequences[k] = &seqCopy
+	}
+
+	for k, v := range currentAST.Composites {
+		newAST.Composites[k] = p.copyComposite(v)
+	}
+
+	for k, v := range currentAST.Domains {
+		domainCopy := *v
+		newAST.Domains[k] = &domainCopy
+	}
+
 	copy(newAST.Classes, currentAST.Classes)
 
-	indexes := make(map[string]*ParsedIndex)
+	for k, v := range currentAST.Views {
+		newAST.Views[k] = p.copyView(v)
+	}
+
 	statements := p.splitSQLStatements(migrationSQL)
 
 	for _, stmt := range statements {
@@ -86,13 +187,11 @@ func (p *SQLParser) ApplyMigrationToAST(currentAST *ast.SchemaAST, migrationSQL
 			continue
 		}
 
-		if err := p.applyStatement(stmt, newAST, indexes); err != nil {
+		if err := p.applyStatement(stmt, newAST); err != nil {
 			continue
 		}
 	}
 
-	p.applyIndexesToAST(newAST, indexes)
-
 	return newAST, nil
 }
 
@@ -111,9 +210,38 @@ func (p *SQLParser) copyEnum(e *enum.Enum) *enum.Enum {
 	}
 }
 
-func (p *SQLParser) applyStatement(stmt string, ast *ast.SchemaAST, indexes map[string]*ParsedIndex) error {
+func (p *SQLParser) copyComposite(c *composite.CompositeType) *composite.CompositeType {
+	fieldsCopy := make([]*composite.CompositeField, len(c.Fields))
+	for i, f := range c.Fields {
+		fieldCopy := *f
+		fieldsCopy[i] = &fieldCopy
+	}
+
+	return &composite.CompositeType{
+		Name:     c.Name,
+		Fields:   fieldsCopy,
+		Position: c.Position,
+	}
+}
+
+func (p *SQLParser) copyView(v *view.View) *view.View {
+	viewCopy := *v
+	viewCopy.Indexes = append([]*view.ViewIndex{}, v.Indexes...)
+	return &viewCopy
+}
+
+func (p *SQLParser) applyStatement(stmt string, ast *ast.SchemaAST) error {
 	stmt = strings.TrimSpace(stmt)
 
+	if matches := p.createViewRegex.FindStringSubmatch(stmt); matches != nil {
+		return p.applyCreateView(matches, ast)
+	}
+
+	if matches := p.dropViewRegex.FindStringSubmatch(stmt); matches != nil {
+		delete(ast.Views, matches[1])
+		return nil
+	}
+
 	if matches := p.createEnumRegex.FindStringSubmatch(stmt); matches != nil {
 		return p.applyCreateEnum(matches, ast)
 	}
@@ -122,10 +250,52 @@ func (p *SQLParser) applyStatement(stmt string, ast *ast.SchemaAST, indexes map[
 		return p.applyAlterEnum(matches, ast)
 	}
 
+	if matches := p.createTypeRegex.FindStringSubmatch(stmt); matches != nil {
+		return p.applyCreateType(matches, ast)
+	}
+
+	if matches := p.alterTypeAttrRegex.FindStringSubmatch(stmt); matches != nil {
+		return p.applyAlterType(matches, ast)
+	}
+
 	if matches := p.dropEnumRegex.FindStringSubmatch(stmt); matches != nil {
 		return p.applyDropEnum(matches, ast)
 	}
 
+	if matches := p.createDomainRegex.FindStringSubmatch(stmt); matches != nil {
+		return p.applyCreateDomain(matches, ast)
+	}
+
+	if matches := p.alterDomainRegex.FindStringSubmatch(stmt); matches != nil {
+		return p.applyAlterDomain(matches, ast)
+	}
+
+	if matches := p.dropDomainRegex.FindStringSubmatch(stmt); matches != nil {
+		delete(ast.Domains, matches[1])
+		return nil
+	}
+
+	if matches := p.createSequenceRegex.FindStringSubmatch(stmt); matches != nil {
+		return p.applyCreateSequence(matches, ast)
+	}
+
+	if matches := p.alterSequenceRegex.FindStringSubmatch(stmt); matches != nil {
+		return p.applyAlterSequence(matches, ast)
+	}
+
+	if matches := p.dropSequenceRegex.FindStringSubmatch(stmt); matches != nil {
+		delete(ast.Sequences, matches[1])
+		return nil
+	}
+
+	if p.partitionOfRegex.MatchString(stmt) {
+		return p.applyCreatePartition(stmt, ast)
+	}
+
+	if matches := p.partitionByRegex.FindStringSubmatch(stmt); matches != nil {
+		return p.applyCreatePartitionedTable(stmt, matches, ast)
+	}
+
 	if matches := p.createTableRegex.FindStringSubmatch(stmt); matches != nil {
 		return p.applyCreateTable(matches, ast)
 	}
@@ -138,12 +308,28 @@ func (p *SQLParser) applyStatement(stmt string, ast *ast.SchemaAST, indexes map[
 		return p.applyAlterTable(matches, ast)
 	}
 
-	if matches := p.indexRegex.FindStringSubmatch(stmt); matches != nil {
-		return p.applyCreateIndex(matches, indexes)
+	if p.indexRegex.MatchString(stmt) {
+		return p.applyCreateIndex(stmt, ast)
 	}
 
 	if matches := p.dropIndexRegex.FindStringSubmatch(stmt); matches != nil {
-		return p.applyDropIndex(matches, indexes)
+		return p.applyDropIndex(matches, ast)
+	}
+
+	if matches := p.createTriggerRegex.FindStringSubmatch(stmt); matches != nil {
+		return p.applyCreateTrigger(matches, ast)
+	}
+
+	if p.createPolicyRegex.MatchString(stmt) {
+		return p.applyCreatePolicy(stmt, ast)
+	}
+
+	if matches := p.dropPolicyRegex.FindStringSubmatch(stmt); matches != nil {
+		return p.applyDropPolicy(matches, ast)
+	}
+
+	if matches := p.dropTriggerRegex.FindStringSubmatch(stmt); matches != nil {
+		return p.applyDropTrigger(matches, ast)
 	}
 
 	return nil
@@ -204,9 +390,150 @@ func (p *SQLParser) applyAlterEnum(matches []string, ast *ast.SchemaAST) error {
 func (p *SQLParser) applyDropEnum(matches []string, ast *ast.SchemaAST) error {
 	enumName := matches[1]
 	delete(ast.Enums, enumName)
+	delete(ast.Composites, enumName)
+	return nil
+}
+
+func (p *SQLParser) applyCreateType(matches []string, ast *ast.SchemaAST) error {
+	typeName := matches[1]
+	if _, exists := ast.Composites[typeName]; exists {
+		return nil
+	}
+
+	compositeType := &composite.CompositeType{
+		Name:     typeName,
+		Position: len(ast.Composites),
+	}
+
+	for _, part := range p.splitTableParts(matches[2]) {
+		if compositeField := p.parseTypeAttribute(strings.TrimSpace(part)); compositeField != nil {
+			compositeField.Position = len(compositeType.Fields)
+			compositeType.Fields = append(compositeType.Fields, compositeField)
+		}
+	}
+
+	ast.Composites[typeName] = compositeType
+	return nil
+}
+
+func (p *SQLParser) applyAlterType(matches []string, ast *ast.SchemaAST) error {
+	compositeType, exists := ast.Composites[matches[1]]
+	if !exists {
+		return fmt.Errorf("type %s does not exist", matches[1])
+	}
+
+	for _, action := range p.splitTableParts(matches[2]) {
+		action = strings.TrimSpace(action)
+
+		if dropMatches := p.dropAttributeRegex.FindStringSubmatch(action); dropMatches != nil {
+			var remaining []*composite.CompositeField
+			for _, f := range compositeType.Fields {
+				if f.Name != dropMatches[1] {
+					f.Position = len(remaining)
+					remaining = append(remaining, f)
+				}
+			}
+			compositeType.Fields = remaining
+			continue
+		}
+
+		compositeField := p.parseTypeAttribute(action)
+		if compositeField == nil {
+			continue
+		}
+
+		if existing := compositeType.GetFieldByName(compositeField.Name); existing != nil {
+			existing.Type = compositeField.Type
+			existing.IsArray = compositeField.IsArray
+			continue
+		}
+
+		compositeField.Position = len(compositeType.Fields)
+		compositeType.Fields = append(compositeType.Fields, compositeField)
+	}
+
 	return nil
 }
 
+func (p *SQLParser) parseTypeAttribute(part string) *composite.CompositeField {
+	matches := p.typeAttributeRegex.FindStringSubmatch(part)
+	if matches == nil {
+		return nil
+	}
+
+	columnType := matches[2]
+	isArray := strings.HasSuffix(columnType, "[]")
+
+	return &composite.CompositeField{
+		Name:       matches[1],
+		Type:       p.mapSQLTypeToSchemaType(strings.TrimSuffix(columnType, "[]")),
+		IsArray:    isArray,
+		IsOptional: true,
+	}
+}
+
+func (p *SQLParser) applyCreateDomain(matches []string, ast *ast.SchemaAST) error {
+	domainName := matches[1]
+	if _, exists := ast.Domains[domainName]; exists {
+		return nil
+	}
+
+	d := &domain.Domain{
+		Name:     domainName,
+		BaseType: p.mapSQLTypeToSchemaType(matches[2]),
+		Position: len(ast.Domains),
+	}
+	d.NativeType, _ = p.parseNativeType(matches[2])
+
+	constraints := strings.TrimSpace(matches[3])
+	if loc := p.domainCheckRegex.FindStringSubmatchIndex(constraints); loc != nil {
+		d.Check = strings.TrimSpace(constraints[loc[2]:loc[3]])
+		constraints = strings.TrimSpace(constraints[:loc[0]])
+	}
+	if defaultVal := p.extractDefaultExpression(constraints); defaultVal != "" {
+		p.setDomainDefault(d, defaultVal)
+	}
+
+	ast.Domains[domainName] = d
+	return nil
+}
+
+func (p *SQLParser) applyAlterDomain(matches []string, ast *ast.SchemaAST) error {
+	d, exists := ast.Domains[matches[1]]
+	if !exists {
+		return fmt.Errorf("domain %s does not exist", matches[1])
+	}
+
+	action := strings.TrimSpace(matches[2])
+	upperAction := strings.ToUpper(action)
+
+	switch {
+	case strings.HasPrefix(upperAction, "SET DEFAULT"):
+		p.setDomainDefault(d, strings.TrimSpace(action[len("SET DEFAULT"):]))
+	case strings.HasPrefix(upperAction, "DROP DEFAULT"):
+		d.Default = ""
+		d.DefaultValue = nil
+	case strings.HasPrefix(upperAction, "DROP CONSTRAINT"):
+		d.Check = ""
+	case strings.HasPrefix(upperAction, "ADD CONSTRAINT"):
+		if checkMatches := p.domainCheckRegex.FindStringSubmatch(action); checkMatches != nil {
+			d.Check = strings.TrimSpace(checkMatches[1])
+		}
+	}
+
+	return nil
+}
+
+func (p *SQLParser) setDomainDefault(d *domain.Domain, sqlDefault string) {
+	schemaDefault := p.mapDefaultValueToSchema(sqlDefault, "", "")
+	defaultValue, err := defaults.NewDefaultValidator(nil).ValidateDefault(schemaDefault, d.BaseType, false)
+	if err != nil {
+		return
+	}
+	d.Default = defaultValue.String()
+	d.DefaultValue = defaultValue
+}
+
 func (p *SQLParser) applyCreateTable(matches []string, ast *ast.SchemaAST) error {
 	tableName := matches[1]
 
@@ -225,6 +552,7 @@ func (p *SQLParser) applyCreateTable(matches []string, ast *ast.SchemaAST) error
 	var primaryKeys []string
 	var uniques [][]string
 	var checks []string
+	var exclusions []*directives.Exclusion
 
 	fieldPosition := 0
 
@@ -235,9 +563,9 @@ func (p *SQLParser) applyCreateTable(matches []string, ast *ast.SchemaAST) error
 		}
 
 		if p.isConstraint(part) {
-			p.parseConstraintForAST(part, &primaryKeys, &uniques, &checks, &foreignKeys)
+			p.parseConstraintForAST(part, &primaryKeys, &uniques, &checks, &exclusions, &foreignKeys)
 		} else {
-			parsedField, err := p.parseColumnForAST(part, ast.Enums, fieldPosition)
+			parsedField, err := p.parseColumnForAST(part, tableName, ast, fieldPosition)
 			if err != nil {
 				continue
 			}
@@ -279,7 +607,14 @@ func (p *SQLParser) applyCreateTable(matches []string, ast *ast.SchemaAST) error
 		classDirectives = append(classDirectives, checkDirective)
 	}
 
-	p.applyForeignKeysToFields(fields, foreignKeys)
+	for _, exclusion := range exclusions {
+		classDirectives = append(classDirectives, &directives.ClassDirective{
+			Name:  constants.CLASS_ATTR_EXCLUDE,
+			Value: exclusion,
+		})
+	}
+
+	fields = p.applyForeignKeysToFields(tableName, fields, foreignKeys)
 
 	classAttrs := &attributes.ClassAttributes{
 		Fields:     fields,
@@ -297,17 +632,21 @@ func (p *SQLParser) applyCreateTable(matches []string, ast *ast.SchemaAST) error
 }
 
 type ForeignKeyInfo struct {
+	Name        string
 	FromColumns []string
 	ToTable     string
 	ToColumns   []string
 	OnDelete    string
 	OnUpdate    string
+	Deferrable  bool
 }
 
-func (p *SQLParser) parseConstraintForAST(part string, primaryKeys *[]string, uniques *[][]string, checks *[]string, foreignKeys *[]ForeignKeyInfo) {
+func (p *SQLParser) parseConstraintForAST(part string, primaryKeys *[]string, uniques *[][]string, checks *[]string, exclusions *[]*directives.Exclusion, foreignKeys *[]ForeignKeyInfo) {
 	upperPart := strings.ToUpper(part)
 
-	if strings.Contains(upperPart, "PRIMARY KEY") {
+	if exclusion, ok := p.parseExclusionConstraint(part); ok {
+		*exclusions = append(*exclusions, exclusion)
+	} else if strings.Contains(upperPart, "PRIMARY KEY") {
 		pkPattern := regexp.MustCompile(`PRIMARY\s+KEY\s*\(\s*([^)]+)\s*\)`)
 		if matches := pkPattern.FindStringSubmatch(part); matches != nil {
 			*primaryKeys = p.parseColumnList(matches[1])
@@ -323,20 +662,13 @@ func (p *SQLParser) parseConstraintForAST(part string, primaryKeys *[]string, un
 		}
 	} else if strings.Contains(upperPart, "FOREIGN KEY") {
 		if matches := p.foreignKeyRegex.FindStringSubmatch(part); matches != nil {
-			fk := ForeignKeyInfo{
-				FromColumns: p.parseColumnList(matches[1]),
-				ToTable:     matches[2],
-				ToColumns:   p.parseColumnList(matches[3]),
-				OnDelete:    p.mapSQLActionToConstant(matches[4]),
-				OnUpdate:    p.mapSQLActionToConstant(matches[5]),
-			}
-			*foreignKeys = append(*foreignKeys, fk)
+			*foreignKeys = append(*foreignKeys, p.buildForeignKeyInfo(matches))
 		}
 	}
 }
 
-func (p *SQLParser) parseColumnForAST(part string, enums map[string]*enum.Enum, position int) (*field.Field, error) {
-	columnPattern := regexp.MustCompile(`^"([^"]+)"\s+([A-Z][A-Z0-9_\(\)]+(?:\[\])?)\s*(.*)$`)
+func (p *SQLParser) parseColumnForAST(part string, tableName string, schema *ast.SchemaAST, position int) (*field.Field, error) {
+	columnPattern := regexp.MustCompile(`^"([^"]+)"\s+` + columnTypePattern + `\s*(.*)$`)
 	matches := columnPattern.FindStringSubmatch(part)
 
 	if matches == nil {
@@ -345,7 +677,7 @@ func (p *SQLParser) parseColumnForAST(part string, enums map[string]*enum.Enum,
 
 	columnName := matches[1]
 	columnType := matches[2]
-	constraints := strings.TrimSpace(matches[3])
+	computed, constraints := p.extractGeneratedExpression(strings.TrimSpace(matches[3]))
 
 	isArray := strings.HasSuffix(columnType, "[]")
 	if isArray {
@@ -358,41 +690,20 @@ func (p *SQLParser) parseColumnForAST(part string, enums map[string]*enum.Enum,
 	var fieldAttrs []*fieldattributes.Attribute
 	var fieldDirectivesList []*fielddirectives.FieldDirective
 
+	if computed != "" {
+		fieldAttrs = append(fieldAttrs, &fieldattributes.Attribute{
+			Name:  constants.FIELD_ATTR_COMPUTED,
+			Value: strconv.Quote(computed),
+		})
+	}
+
 	if strings.Contains(strings.ToUpper(constraints), "UNIQUE") {
 		fieldDirectivesList = append(fieldDirectivesList, &fielddirectives.FieldDirective{
 			Name: constants.FIELD_ATTR_UNIQUE,
 		})
 	}
 
-	defaultPattern := regexp.MustCompile(`DEFAULT\s+([^,\s]+(?:\s+[^,\s]+)*)`)
-	if defaultMatches := defaultPattern.FindStringSubmatch(constraints); defaultMatches != nil {
-		defaultVal := strings.TrimSpace(defaultMatches[1])
-		schemaDefault := p.mapDefaultValueToSchema(defaultVal)
-
-		if schemaDefault != "" {
-			validator := defaults.NewDefaultValidator(enums)
-			defaultValue, err := validator.ValidateDefault(schemaDefault, schemaType, isArray)
-			if err == nil {
-				fieldAttrs = append(fieldAttrs, &fieldattributes.Attribute{
-					Name:  constants.FIELD_ATTR_DEFAULT,
-					Value: defaultValue,
-				})
-			}
-		}
-	}
-
-	if strings.Contains(strings.ToUpper(constraints), "GENERATED BY DEFAULT AS IDENTITY") {
-		validator := defaults.NewDefaultValidator(enums)
-		defaultValue, err := validator.ValidateDefault(constants.DEFAULT_AUTOINCREMENT_CALLBACK, schemaType, false)
-		if err == nil {
-			fieldAttrs = append(fieldAttrs, &fieldattributes.Attribute{
-				Name:  constants.FIELD_ATTR_DEFAULT,
-				Value: defaultValue,
-			})
-		}
-	}
-
-	kind := p.determineFieldKind(schemaType, enums)
+	kind := p.determineFieldKind(schemaType, schema)
 
 	attrDef := &fieldattributes.AttributeDefinition{
 		Name:       columnName,
@@ -402,87 +713,113 @@ func (p *SQLParser) parseColumnForAST(part string, enums map[string]*enum.Enum,
 		IsOptional: isOptional,
 		Attributes: fieldAttrs,
 		Directives: fieldDirectivesList,
+		Computed:   computed,
 	}
 
-	return &field.Field{
+	parsedField := &field.Field{
 		AttributeDefinition: attrDef,
 		Position:            position,
-	}, nil
+	}
+
+	p.setFieldNativeType(parsedField, columnType)
+
+	if strings.Contains(strings.ToUpper(constraints), "GENERATED BY DEFAULT AS IDENTITY") {
+		p.setFieldDefault(parsedField, constants.DEFAULT_AUTOINCREMENT_CALLBACK, schema)
+	} else if defaultVal := p.extractDefaultExpression(constraints); defaultVal != "" {
+		p.setFieldDefault(parsedField, p.mapDefaultValueToSchema(defaultVal, tableName, columnName), schema)
+	}
+
+	return parsedField, nil
 }
 
-func (p *SQLParser) applyForeignKeysToFields(fields []*field.Field, foreignKeys []ForeignKeyInfo) {
+func (p *SQLParser) buildForeignKeyInfo(matches []string) ForeignKeyInfo {
+	return ForeignKeyInfo{
+		Name:        matches[1],
+		FromColumns: p.parseColumnList(matches[2]),
+		ToTable:     matches[3],
+		ToColumns:   p.parseColumnList(matches[4]),
+		OnDelete:    p.mapSQLActionToConstant(matches[5]),
+		OnUpdate:    p.mapSQLActionToConstant(matches[6]),
+		Deferrable:  strings.TrimSpace(matches[7]) != "",
+	}
+}
+
+func (p *SQLParser) applyForeignKeysToFields(tableName string, fields []*field.Field, foreignKeys []ForeignKeyInfo) []*field.Field {
+	relationValidator := relations.NewRelationValidator()
+
 	for _, fk := range foreignKeys {
 		if len(fk.FromColumns) == 0 || len(fk.ToColumns) == 0 {
 			continue
 		}
 
-		if len(fk.FromColumns) == 1 {
-			for _, f := range fields {
-				if f.GetName() == fk.FromColumns[0] {
-					relationValidator := relations.NewRelationValidator()
-					relation := &relations.Relation{
-						From:      fk.FromColumns,
-						To:        fk.ToColumns,
-						ToClass:   fk.ToTable,
-						FromClass: "",
-						OnDelete:  fk.OnDelete,
-						OnUpdate:  fk.OnUpdate,
-					}
-
-					if err := relationValidator.ValidateRelation(relation); err == nil {
-						f.AttributeDefinition.Attributes = append(f.AttributeDefinition.Attributes, &fieldattributes.Attribute{
-							Name:  constants.FIELD_ATTR_RELATION,
-							Value: relation,
-						})
-
-						f.AttributeDefinition.DataType = fk.ToTable
-						f.AttributeDefinition.Kind = constants.FIELD_KIND_OBJECT
-					}
-					break
-				}
-			}
+		relation := &relations.Relation{
+			From:       fk.FromColumns,
+			To:         fk.ToColumns,
+			ToClass:    fk.ToTable,
+			FromClass:  tableName,
+			OnDelete:   fk.OnDelete,
+			OnUpdate:   fk.OnUpdate,
+			Deferrable: fk.Deferrable,
 		}
-	}
-}
 
-func (p *SQLParser) applyIndexesToAST(ast *ast.SchemaAST, indexes map[string]*ParsedIndex) {
-	for _, index := range indexes {
-		for _, cls := range ast.Classes {
-			if cls.Name == index.Table {
-				directiveName := constants.CLASS_ATTR_INDEX
-				if index.IsText {
-					directiveName = constants.CLASS_ATTR_TEXT_INDEX
-				}
+		if err := relationValidator.ValidateRelation(relation); err != nil {
+			continue
+		}
 
-				if !p.isSystemGeneratedIndexForAST(index, cls) {
-					indexDirective := &directives.ClassDirective{
-						Name:  directiveName,
-						Value: index.Columns,
-					}
-					cls.Attributes.Directives = append(cls.Attributes.Directives, indexDirective)
-				}
+		isOptional := false
+		for _, f := range fields {
+			if f.GetName() == fk.FromColumns[0] {
+				isOptional = f.IsOptional()
 				break
 			}
 		}
+
+		attrDef := &fieldattributes.AttributeDefinition{
+			Name:       p.relationFieldName(tableName, fk),
+			DataType:   fk.ToTable,
+			Kind:       constants.FIELD_KIND_OBJECT,
+			IsOptional: isOptional,
+			Attributes: []*fieldattributes.Attribute{
+				{
+					Name:  constants.FIELD_ATTR_RELATION,
+					Value: relation,
+				},
+			},
+			Directives: []*fielddirectives.FieldDirective{},
+			Relation:   relation,
+		}
+
+		fields = append(fields, &field.Field{
+			AttributeDefinition: attrDef,
+			Position:            len(fields),
+		})
 	}
+
+	return fields
 }
 
-func (p *SQLParser) isSystemGeneratedIndexForAST(index *ParsedIndex, cls *class.Class) bool {
-	pkFields := cls.GetPrimaryKeyFields()
-	if len(index.Columns) == len(pkFields) && len(pkFields) > 0 {
-		pkSet := make(map[string]bool)
-		for _, pk := range pkFields {
-			pkSet[pk] = true
+func (p *SQLParser) relationFieldName(tableName string, fk ForeignKeyInfo) string {
+	prefix := fmt.Sprintf("fk_%s_", strings.ToLower(tableName))
+	if strings.HasPrefix(fk.Name, prefix) && len(fk.Name) > len(prefix) {
+		return strings.TrimPrefix(fk.Name, prefix)
+	}
+	return strings.ToLower(fk.ToTable[:1]) + fk.ToTable[1:]
+}
+
+func (p *SQLParser) isSystemGeneratedIndexForAST(indexName string, index *directives.Index, cls *class.Class) bool {
+	if pkFields := cls.GetPrimaryKeyFields(); len(pkFields) > 0 {
+		if isImplicitIndex(indexName, index, cls.Name+"_pkey", pkFields) {
+			return true
 		}
+	}
 
-		allPK := true
-		for _, col := range index.Columns {
-			if !pkSet[col] {
-				allPK = false
-				break
-			}
+	for _, f := range cls.Attributes.Fields {
+		if !f.HasRelation() {
+			continue
 		}
-		if allPK {
+		relation := f.AttributeDefinition.Relation
+		fkIndexName := fmt.Sprintf("idx_%s_%s", strings.ToLower(cls.Name), strings.ToLower(strings.Join(relation.From, "_")))
+		if isImplicitIndex(indexName, index, fkIndexName, relation.From) {
 			return true
 		}
 	}
@@ -490,9 +827,75 @@ func (p *SQLParser) isSystemGeneratedIndexForAST(index *ParsedIndex, cls *class.
 	return false
 }
 
+func isImplicitIndex(indexName string, index *directives.Index, implicitName string, columns []string) bool {
+	if indexName != implicitName || index.GetType() != constants.INDEX_TYPE_BTREE {
+		return false
+	}
+	if index.Where != "" || len(index.Include) > 0 || len(index.Columns) != len(columns) {
+		return false
+	}
+	for i, column := range index.Columns {
+		if column.Field != columns[i] || column.Expression != "" {
+			return false
+		}
+		if column.Sort != "" && !strings.EqualFold(column.Sort, "asc") {
+			return false
+		}
+	}
+	return true
+}
+
+func (p *SQLParser) applyCreatePartitionedTable(stmt string, matches []string, ast *ast.SchemaAST) error {
+	partitionBy, err := directives.ParsePartitionKeySQL(matches[1])
+	if err != nil {
+		return err
+	}
+
+	tableStmt := stmt[:len(stmt)-len(matches[0])] + ")"
+	tableMatches := p.createTableRegex.FindStringSubmatch(tableStmt)
+	if tableMatches == nil {
+		return fmt.Errorf("unrecognized partitioned table definition")
+	}
+
+	if err := p.applyCreateTable(tableMatches, ast); err != nil {
+		return err
+	}
+
+	if targetClass := ast.GetClassByName(tableMatches[1]); targetClass != nil && targetClass.Attributes.GetPartitionBy() == nil {
+		targetClass.Attributes.Directives = append(targetClass.Attributes.Directives, &directives.ClassDirective{
+			Name:  constants.CLASS_ATTR_PARTITION_BY,
+			Value: partitionBy,
+		})
+	}
+	return nil
+}
+
+func (p *SQLParser) applyCreatePartition(stmt string, ast *ast.SchemaAST) error {
+	partition, parentName, err := directives.ParsePartitionSQL(stmt)
+	if err != nil {
+		return err
+	}
+
+	parentClass := ast.GetClassByName(parentName)
+	if parentClass == nil {
+		return fmt.Errorf("table %s does not exist", parentName)
+	}
+
+	p.removePartitionDirective(parentClass, partition.Name)
+	parentClass.Attributes.Directives = append(parentClass.Attributes.Directives, &directives.ClassDirective{
+		Name:  constants.CLASS_ATTR_PARTITION,
+		Value: partition,
+	})
+	return nil
+}
+
 func (p *SQLParser) applyDropTable(matches []string, ast *ast.SchemaAST) error {
 	tableName := matches[1]
 
+	for _, cls := range ast.Classes {
+		p.removePartitionDirective(cls, tableName)
+	}
+
 	for i, cls := range ast.Classes {
 		if cls.Name == tableName {
 			ast.Classes = append(ast.Classes[:i], ast.Classes[i+1:]...)
@@ -523,6 +926,16 @@ func (p *SQLParser) applyAlterTable(matches []string, ast *ast.SchemaAST) error
 		return fmt.Errorf("table %s does not exist", tableName)
 	}
 
+	if rlsMatches := p.rowSecurityRegex.FindStringSubmatch(alterAction); rlsMatches != nil {
+		p.removeDirective(targetClass, constants.CLASS_ATTR_RLS)
+		if rlsMatches[1] == "ENABLE" {
+			targetClass.Attributes.Directives = append(targetClass.Attributes.Directives, &directives.ClassDirective{
+				Name: constants.CLASS_ATTR_RLS,
+			})
+		}
+		return nil
+	}
+
 	if addMatches := p.addColumnRegex.FindStringSubmatch(alterAction); addMatches != nil {
 		columnName := addMatches[1]
 
@@ -532,35 +945,119 @@ func (p *SQLParser) applyAlterTable(matches []string, ast *ast.SchemaAST) error
 			}
 		}
 
-		columnType := addMatches[2]
-		constraints := strings.TrimSpace(addMatches[3])
+		columnDef := fmt.Sprintf(`"%s" %s %s`, columnName, addMatches[2], addMatches[3])
+		newField, err := p.parseColumnForAST(columnDef, tableName, ast, len(targetClass.Attributes.Fields))
+		if err != nil {
+			return err
+		}
 
-		isArray := strings.HasSuffix(columnType, "[]")
-		if isArray {
+		targetClass.Attributes.Fields = append(targetClass.Attributes.Fields, newField)
+		return nil
+	}
+
+	if defaultMatches := p.setDefaultRegex.FindStringSubmatch(alterAction); defaultMatches != nil {
+		if targetField := targetClass.Attributes.GetFieldByName(defaultMatches[1]); targetField != nil {
+			p.setFieldDefault(targetField, p.mapDefaultValueToSchema(strings.TrimSpace(defaultMatches[2]), tableName, defaultMatches[1]), ast)
+		}
+		return nil
+	}
+
+	if defaultMatches := p.dropDefaultRegex.FindStringSubmatch(alterAction); defaultMatches != nil {
+		if targetField := targetClass.Attributes.GetFieldByName(defaultMatches[1]); targetField != nil {
+			p.setFieldDefault(targetField, "", ast)
+		}
+		return nil
+	}
+
+	if identityMatches := p.addIdentityRegex.FindStringSubmatch(alterAction); identityMatches != nil {
+		if targetField := targetClass.Attributes.GetFieldByName(identityMatches[1]); targetField != nil {
+			p.setFieldDefault(targetField, constants.DEFAULT_AUTOINCREMENT_CALLBACK, ast)
+		}
+		return nil
+	}
+
+	if identityMatches := p.dropIdentityRegex.FindStringSubmatch(alterAction); identityMatches != nil {
+		if targetField := targetClass.Attributes.GetFieldByName(identityMatches[1]); targetField != nil && targetField.HasDefault() &&
+			targetField.AttributeDefinition.DefaultValue.GetValue() == constants.DEFAULT_AUTOINCREMENT_CALLBACK {
+			p.setFieldDefault(targetField, "", ast)
+		}
+		return nil
+	}
+
+	if typeMatches := p.alterTypeRegex.FindStringSubmatch(alterAction); typeMatches != nil {
+		if targetField := targetClass.Attributes.GetFieldByName(typeMatches[1]); targetField != nil {
+			columnType := typeMatches[2]
+			targetField.AttributeDefinition.IsArray = strings.HasSuffix(columnType, "[]")
 			columnType = strings.TrimSuffix(columnType, "[]")
+			targetField.AttributeDefinition.DataType = p.mapSQLTypeToSchemaType(columnType)
+			targetField.AttributeDefinition.Kind = p.determineFieldKind(targetField.AttributeDefinition.DataType, ast)
+			p.setFieldNativeType(targetField, columnType)
 		}
+		return nil
+	}
 
-		schemaType := p.mapSQLTypeToSchemaType(columnType)
-		isOptional := !strings.Contains(strings.ToUpper(constraints), "NOT NULL")
+	if exprMatches := p.setExpressionRegex.FindStringSubmatch(alterAction); exprMatches != nil {
+		if targetField := targetClass.Attributes.GetFieldByName(exprMatches[1]); targetField != nil {
+			p.setComputedExpression(targetField, strings.TrimSpace(exprMatches[2]))
+		}
+		return nil
+	}
 
-		kind := p.determineFieldKind(schemaType, ast.Enums)
+	if exprMatches := p.dropExpressionRegex.FindStringSubmatch(alterAction); exprMatches != nil {
+		if targetField := targetClass.Attributes.GetFieldByName(exprMatches[1]); targetField != nil {
+			p.setComputedExpression(targetField, "")
+		}
+		return nil
+	}
 
-		attrDef := &fieldattributes.AttributeDefinition{
-			Name:       columnName,
-			DataType:   schemaType,
-			Kind:       kind,
-			IsArray:    isArray,
-			IsOptional: isOptional,
-			Attributes: []*fieldattributes.Attribute{},
-			Directives: []*fielddirectives.FieldDirective{},
+	if exclusion, ok := p.parseExclusionConstraint(strings.TrimSpace(alterAction[len("ADD"):])); ok && strings.HasPrefix(strings.ToUpper(alterAction), "ADD CONSTRAINT") {
+		p.removeExclusionDirective(targetClass, exclusion.GetName(tableName))
+		targetClass.Attributes.Directives = append(targetClass.Attributes.Directives, &directives.ClassDirective{
+			Name:  constants.CLASS_ATTR_EXCLUDE,
+			Value: exclusion,
+		})
+		return nil
+	}
+
+	if fkMatches := p.foreignKeyRegex.FindStringSubmatch(alterAction); fkMatches != nil && strings.HasPrefix(strings.ToUpper(alterAction), "ADD CONSTRAINT") {
+		targetClass.Attributes.Fields = p.applyForeignKeysToFields(tableName, targetClass.Attributes.Fields, []ForeignKeyInfo{p.buildForeignKeyInfo(fkMatches)})
+		return nil
+	}
+
+	if checkMatches := p.checkConstraintRegex.FindStringSubmatch(alterAction); checkMatches != nil && strings.HasPrefix(strings.ToUpper(alterAction), "ADD CONSTRAINT") {
+		p.removeCheckDirective(targetClass)
+		targetClass.Attributes.Directives = append(targetClass.Attributes.Directives, &directives.ClassDirective{
+			Name:  constants.CLASS_ATTR_CHECK,
+			Value: strings.TrimSpace(checkMatches[1]),
+		})
+		return nil
+	}
+
+	if dropMatches := p.dropConstraintRegex.FindStringSubmatch(alterAction); dropMatches != nil {
+		constraintName := dropMatches[1]
+
+		if constraintName == fmt.Sprintf("chk_%s", strings.ToLower(tableName)) {
+			p.removeCheckDirective(targetClass)
+			return nil
 		}
 
-		newField := &field.Field{
-			AttributeDefinition: attrDef,
-			Position:            len(targetClass.Attributes.Fields),
+		if p.removeExclusionDirective(targetClass, constraintName) {
+			return nil
 		}
 
-		targetClass.Attributes.Fields = append(targetClass.Attributes.Fields, newField)
+		for i, f := range targetClass.Attributes.Fields {
+			if f.HasRelation() && fmt.Sprintf("fk_%s_%s", strings.ToLower(tableName), strings.ToLower(f.GetName())) == constraintName {
+				targetClass.Attributes.Fields = append(
+					targetClass.Attributes.Fields[:i],
+					targetClass.Attributes.Fields[i+1:]...,
+				)
+
+				for j := i; j < len(targetClass.Attributes.Fields); j++ {
+					targetClass.Attributes.Fields[j].Position = j
+				}
+				break
+			}
+		}
 		return nil
 	}
 
@@ -586,53 +1083,462 @@ func (p *SQLParser) applyAlterTable(matches []string, ast *ast.SchemaAST) error
 	return nil
 }
 
-func (p *SQLParser) applyCreateIndex(matches []string, indexes map[string]*ParsedIndex) error {
+func (p *SQLParser) applyCreateView(matches []string, ast *ast.SchemaAST) error {
+	name := matches[2]
+	materialized := matches[1] != ""
+
+	if existing, exists := ast.Views[name]; exists && existing.Materialized == materialized {
+		existing.SQL = strings.TrimSpace(matches[3])
+		return nil
+	}
+
+	ast.Views[name] = &view.View{
+		Name:         name,
+		Materialized: materialized,
+		SQL:          strings.TrimSpace(matches[3]),
+		Position:     len(ast.Views),
+	}
+	return nil
+}
+
+func (p *SQLParser) applyCreatePolicy(stmt string, ast *ast.SchemaAST) error {
+	policy, tableName, err := directives.ParsePolicySQL(stmt)
+	if err != nil {
+		return err
+	}
+
+	targetClass := ast.GetClassByName(tableName)
+	if targetClass == nil {
+		return fmt.Errorf("table %s does not exist", tableName)
+	}
+
+	p.removePolicyDirective(targetClass, policy.Name)
+	targetClass.Attributes.Directives = append(targetClass.Attributes.Directives, &directives.ClassDirective{
+		Name:  constants.CLASS_ATTR_POLICY,
+		Value: policy,
+	})
+	return nil
+}
+
+func (p *SQLParser) applyDropPolicy(matches []string, ast *ast.SchemaAST) error {
+	if targetClass := ast.GetClassByName(matches[2]); targetClass != nil {
+		p.removePolicyDirective(targetClass, matches[1])
+	}
+	return nil
+}
+
+func (p *SQLParser) applyCreateIndex(stmt string, ast *ast.SchemaAST) error {
+	index, tableName, isText, err := directives.ParseIndexSQL(stmt)
+	if err != nil {
+		return err
+	}
+
+	if v := ast.GetViewByName(tableName); v != nil {
+		p.removeViewIndex(v, index.Name)
+		v.Indexes = append(v.Indexes, &view.ViewIndex{
+			Unique: p.uniqueIndexRegex.MatchString(stmt),
+			Index:  index,
+		})
+		return nil
+	}
+
+	for _, cls := range ast.Classes {
+		if cls.Name != tableName {
+			continue
+		}
+
+		if p.isSystemGeneratedIndexForAST(index.Name, index, cls) {
+			return nil
+		}
+
+		directiveName := constants.CLASS_ATTR_INDEX
+		if isText {
+			directiveName = constants.CLASS_ATTR_TEXT_INDEX
+		}
+
+		p.removeIndexDirective(cls, index.Name)
+		cls.Attributes.Directives = append(cls.Attributes.Directives, &directives.ClassDirective{
+			Name:  directiveName,
+			Value: index,
+		})
+		return nil
+	}
+
+	return fmt.Errorf("table %s does not exist", tableName)
+}
+
+func (p *SQLParser) applyDropIndex(matches []string, ast *ast.SchemaAST) error {
 	indexName := matches[1]
-	tableName := matches[2]
-	indexType := matches[3]
-	columnsStr := matches[4]
+	for _, cls := range ast.Classes {
+		p.removeIndexDirective(cls, indexName)
+	}
+	for _, v := range ast.Views {
+		p.removeViewIndex(v, indexName)
+	}
+	return nil
+}
 
-	isTextIndex := strings.ToUpper(indexType) == "GIN" ||
-		strings.Contains(strings.ToUpper(columnsStr), "GIN_TRGM_OPS") ||
-		strings.Contains(columnsStr, "||")
+func (p *SQLParser) extractGeneratedExpression(constraints string) (string, string) {
+	loc := p.generatedRegex.FindStringIndex(constraints)
+	if loc == nil {
+		return "", constraints
+	}
 
-	var columns []string
-	if isTextIndex && strings.Contains(columnsStr, "||") {
-		concatPattern := regexp.MustCompile(`"([^"]+)"`)
-		columnMatches := concatPattern.FindAllStringSubmatch(columnsStr, -1)
-		for _, match := range columnMatches {
-			columns = append(columns, match[1])
+	level := 1
+	var quote byte
+	end := -1
+	for i := loc[1]; i < len(constraints) && end == -1; i++ {
+		char := constraints[i]
+		switch {
+		case quote != 0:
+			if char == quote {
+				quote = 0
+			}
+		case char == '\'' || char == '"':
+			quote = char
+		case char == '(':
+			level++
+		case char == ')':
+			level--
+			if level == 0 {
+				end = i
+			}
 		}
-	} else {
-		columns = p.parseColumnList(columnsStr)
 	}
 
-	indexes[indexName] = &ParsedIndex{
-		Name:    indexName,
-		Table:   tableName,
-		Columns: columns,
-		Type:    indexType,
-		IsText:  isTextIndex,
+	if end == -1 {
+		return "", constraints
+	}
+
+	expression := strings.TrimSpace(constraints[loc[1]:end])
+	rest := strings.TrimSpace(constraints[end+1:])
+	rest = strings.TrimSpace(strings.TrimPrefix(strings.TrimPrefix(rest, "STORED"), "stored"))
+
+	return expression, strings.TrimSpace(constraints[:loc[0]] + " " + rest)
+}
+
+func (p *SQLParser) setComputedExpression(f *field.Field, expression string) {
+	var remaining []*fieldattributes.Attribute
+	for _, attr := range f.AttributeDefinition.Attributes {
+		if attr.Name != constants.FIELD_ATTR_COMPUTED {
+			remaining = append(remaining, attr)
+		}
 	}
 
+	if expression != "" {
+		remaining = append(remaining, &fieldattributes.Attribute{
+			Name:  constants.FIELD_ATTR_COMPUTED,
+			Value: strconv.Quote(expression),
+		})
+	}
+
+	f.AttributeDefinition.Attributes = remaining
+	f.AttributeDefinition.Computed = expression
+}
+
+func (p *SQLParser) extractDefaultExpression(constraints string) string {
+	loc := p.defaultKeywordRegex.FindStringIndex(constraints)
+	if loc == nil {
+		return ""
+	}
+
+	expression := strings.TrimSpace(constraints[loc[1]:])
+	if strings.HasSuffix(strings.ToUpper(expression), " UNIQUE") {
+		expression = strings.TrimSpace(expression[:len(expression)-len(" UNIQUE")])
+	}
+	return expression
+}
+
+func (p *SQLParser) setFieldDefault(f *field.Field, schemaDefault string, schema *ast.SchemaAST) {
+	var attrs []*fieldattributes.Attribute
+	for _, attr := range f.AttributeDefinition.Attributes {
+		if attr.Name != constants.FIELD_ATTR_DEFAULT {
+			attrs = append(attrs, attr)
+		}
+	}
+	f.AttributeDefinition.Attributes = attrs
+	f.AttributeDefinition.DefaultValue = nil
+
+	if schemaDefault == "" {
+		return
+	}
+
+	validator := defaults.NewDefaultValidator(schema.Enums)
+	for name := range schema.Composites {
+		validator.RegisterComposite(name)
+	}
+	for name, d := range schema.Domains {
+		validator.RegisterDomain(name, d.BaseType)
+	}
+	defaultValue, err := validator.ValidateDefault(schemaDefault, f.GetDataType(), f.IsArray())
+	if err != nil {
+		return
+	}
+
+	f.AttributeDefinition.DefaultValue = defaultValue
+	f.AttributeDefinition.Attributes = append(f.AttributeDefinition.Attributes, &fieldattributes.Attribute{
+		Name:  constants.FIELD_ATTR_DEFAULT,
+		Value: defaultValue.String(),
+	})
+}
+
+func (p *SQLParser) setFieldNativeType(f *field.Field, sqlType string) {
+	var attrs []*fieldattributes.Attribute
+	for _, attr := range f.AttributeDefinition.Attributes {
+		if !native.IsNativeAttribute(attr.Name) {
+			attrs = append(attrs, attr)
+		}
+	}
+	f.AttributeDefinition.Attributes = attrs
+	f.AttributeDefinition.NativeType = nil
+
+	nativeType, args := p.parseNativeType(sqlType)
+	if nativeType == nil {
+		return
+	}
+
+	f.AttributeDefinition.NativeType = nativeType
+	f.AttributeDefinition.Attributes = append(f.AttributeDefinition.Attributes, &fieldattributes.Attribute{
+		Name:  constants.FIELD_ATTR_NATIVE + nativeType.Name,
+		Value: args,
+	})
+}
+
+func (p *SQLParser) parseNativeType(sqlType string) (*native.NativeType, string) {
+	matches := p.nativeTypeRegex.FindStringSubmatch(strings.ToUpper(sqlType))
+	if matches == nil {
+		return nil, ""
+	}
+
+	var name string
+	switch matches[1] {
+	case "VARCHAR":
+		name = constants.NATIVE_TYPE_VARCHAR
+	case "CHAR":
+		if matches[2] == "1" {
+			return nil, ""
+		}
+		name = constants.NATIVE_TYPE_CHAR
+	case "NUMERIC":
+		name = constants.NATIVE_TYPE_NUMERIC
+	case "TIMESTAMP", "TIMESTAMPTZ", "TIME":
+		if matches[2] == "3" {
+			return nil, ""
+		}
+		name = temporalNativeTypes[matches[1]]
+	}
+
+	args := matches[2]
+	if matches[3] != "" {
+		args += "," + matches[3]
+	}
+
+	nativeType, err := native.ParseNativeType(constants.FIELD_ATTR_NATIVE+name, args)
+	if err != nil {
+		return nil, ""
+	}
+
+	return nativeType, args
+}
+
+func (p *SQLParser) applyCreateSequence(matches []string, ast *ast.SchemaAST) error {
+	name := matches[1]
+	if _, exists := ast.Sequences[name]; exists {
+		return nil
+	}
+
+	seq := sequence.NewSequence(name)
+	seq.Position = len(ast.Sequences)
+	p.applySequenceOptions(seq, matches[2])
+	ast.Sequences[name] = seq
 	return nil
 }
 
-func (p *SQLParser) applyDropIndex(matches []string, indexes map[string]*ParsedIndex) error {
-	indexName := matches[1]
-	delete(indexes, indexName)
+func (p *SQLParser) applyAlterSequence(matches []string, ast *ast.SchemaAST) error {
+	name := matches[1]
+
+	if p.ownedByRegex.MatchString(matches[2]) {
+		delete(ast.Sequences, name)
+		return nil
+	}
+
+	seq, exists := ast.Sequences[name]
+	if !exists {
+		return fmt.Errorf("sequence %s does not exist", name)
+	}
+	p.applySequenceOptions(seq, matches[2])
 	return nil
 }
 
-func (p *SQLParser) determineFieldKind(fieldType string, enums map[string]*enum.Enum) string {
+func (p *SQLParser) applySequenceOptions(seq *sequence.Sequence, options string) {
+	for _, option := range p.sequenceOptionRegex.FindAllStringSubmatch(options, -1) {
+		value, err := strconv.ParseInt(option[2], 10, 64)
+		if err != nil {
+			continue
+		}
+
+		switch strings.Fields(option[1])[0] {
+		case "START":
+			seq.Start = value
+		case "INCREMENT":
+			seq.Increment = value
+		case "CACHE":
+			seq.Cache = value
+		}
+	}
+}
+
+func (p *SQLParser) applyCreateTrigger(matches []string, ast *ast.SchemaAST) error {
+	tableName := matches[2]
+	columnName := matches[3]
+
+	cls := ast.GetClassByName(tableName)
+	if cls == nil {
+		return fmt.Errorf("table %s does not exist", tableName)
+	}
+
+	targetField := cls.Attributes.GetFieldByName(columnName)
+	if targetField == nil {
+		return fmt.Errorf("column %s does not exist on table %s", columnName, tableName)
+	}
+
+	if !targetField.IsUpdatedAt() {
+		targetField.AttributeDefinition.Directives = append(targetField.AttributeDefinition.Directives, &fielddirectives.FieldDirective{
+			Name: constants.FIELD_ATTR_UPDATED_AT,
+		})
+	}
+	return nil
+}
+
+func (p *SQLParser) applyDropTrigger(matches []string, ast *ast.SchemaAST) error {
+	triggerName := matches[1]
+	tableName := matches[2]
+
+	cls := ast.GetClassByName(tableName)
+	if cls == nil {
+		return nil
+	}
+
+	for _, f := range cls.Attributes.Fields {
+		if !f.IsUpdatedAt() || fmt.Sprintf(constants.UPDATED_AT_TRIGGER_NAME, strings.ToLower(tableName), strings.ToLower(f.GetName())) != triggerName {
+			continue
+		}
+
+		var remaining []*fielddirectives.FieldDirective
+		for _, directive := range f.AttributeDefinition.Directives {
+			if directive.Name != constants.FIELD_ATTR_UPDATED_AT {
+				remaining = append(remaining, directive)
+			}
+		}
+		f.AttributeDefinition.Directives = remaining
+	}
+	return nil
+}
+
+func (p *SQLParser) removeCheckDirective(cls *class.Class) {
+	p.removeDirective(cls, constants.CLASS_ATTR_CHECK)
+}
+
+func (p *SQLParser) removeDirective(cls *class.Class, name string) {
+	var remaining []*directives.ClassDirective
+	for _, directive := range cls.Attributes.Directives {
+		if directive.Name != name {
+			remaining = append(remaining, directive)
+		}
+	}
+	cls.Attributes.Directives = remaining
+}
+
+func (p *SQLParser) removePartitionDirective(cls *class.Class, partitionName string) {
+	var remaining []*directives.ClassDirective
+	for _, directive := range cls.Attributes.Directives {
+		if partition, err := directive.GetPartition(); err == nil && partition.Name == partitionName {
+			continue
+		}
+		remaining = append(remaining, directive)
+	}
+	cls.Attributes.Directives = remaining
+}
+
+func (p *SQLParser) removePolicyDirective(cls *class.Class, policyName string) {
+	var remaining []*directives.ClassDirective
+	for _, directive := range cls.Attributes.Directives {
+		if policy, err := directive.GetPolicy(); err == nil && policy.Name == policyName {
+			continue
+		}
+		remaining = append(remaining, directive)
+	}
+	cls.Attributes.Directives = remaining
+}
+
+func (p *SQLParser) removeExclusionDirective(cls *class.Class, constraintName string) bool {
+	removed := false
+	var remaining []*directives.ClassDirective
+	for _, directive := range cls.Attributes.Directives {
+		if exclusion, err := directive.GetExclusion(); err == nil && exclusion.GetName(cls.Name) == constraintName {
+			removed = true
+			continue
+		}
+		remaining = append(remaining, directive)
+	}
+	cls.Attributes.Directives = remaining
+	return removed
+}
+
+func (p *SQLParser) parseExclusionConstraint(part string) (*directives.Exclusion, bool) {
+	if !strings.Contains(strings.ToUpper(part), "EXCLUDE") {
+		return nil, false
+	}
+
+	exclusion, name, err := directives.ParseExclusionSQL(part)
+	if err != nil {
+		return nil, false
+	}
+	exclusion.Name = name
+
+	return exclusion, true
+}
+
+func (p *SQLParser) removeIndexDirective(cls *class.Class, indexName string) {
+	var remaining []*directives.ClassDirective
+	for _, directive := range cls.Attributes.Directives {
+		if index, err := directive.GetIndex(); err == nil {
+			if index.GetName(cls.Name, directive.Name == constants.CLASS_ATTR_TEXT_INDEX) == indexName {
+				continue
+			}
+		}
+		remaining = append(remaining, directive)
+	}
+	cls.Attributes.Directives = remaining
+}
+
+func (p *SQLParser) removeViewIndex(v *view.View, indexName string) {
+	var remaining []*view.ViewIndex
+	for _, viewIndex := range v.Indexes {
+		if v.IndexName(viewIndex) != indexName {
+			remaining = append(remaining, viewIndex)
+		}
+	}
+	v.Indexes = remaining
+}
+
+func (p *SQLParser) determineFieldKind(fieldType string, schema *ast.SchemaAST) string {
 	if constants.IsScalarType(fieldType) {
 		return constants.FIELD_KIND_SCALAR
 	}
 
-	if _, exists := enums[fieldType]; exists {
+	if _, exists := schema.Enums[fieldType]; exists {
 		return constants.FIELD_KIND_ENUM
 	}
 
+	if schema.GetCompositeByName(fieldType) != nil {
+		return constants.FIELD_KIND_COMPOSITE
+	}
+
+	if schema.GetDomainByName(fieldType) != nil {
+		return constants.FIELD_KIND_DOMAIN
+	}
+
 	return constants.FIELD_KIND_OBJECT
 }
 
@@ -653,10 +1559,31 @@ func (p *SQLParser) splitSQLStatements(sqlContent string) []string {
 	var current strings.Builder
 	inQuotes := false
 	quoteChar := byte(0)
+	dollarTag := ""
 
 	for i := 0; i < len(content); i++ {
 		char := content[i]
 
+		if dollarTag != "" {
+			if strings.HasPrefix(content[i:], dollarTag) {
+				current.WriteString(dollarTag)
+				i += len(dollarTag) - 1
+				dollarTag = ""
+				continue
+			}
+			current.WriteByte(char)
+			continue
+		}
+
+		if !inQuotes && char == '$' {
+			if end := strings.IndexByte(content[i+1:], '$'); end != -1 && p.isDollarTag(content[i+1:i+1+end]) {
+				dollarTag = content[i : i+end+2]
+				current.WriteString(dollarTag)
+				i += len(dollarTag) - 1
+				continue
+			}
+		}
+
 		if !inQuotes && (char == '\'' || char == '"') {
 			inQuotes = true
 			quoteChar = char
@@ -682,6 +1609,18 @@ func (p *SQLParser) splitSQLStatements(sqlContent string) []string {
 	return statements
 }
 
+func (p *SQLParser) isDollarTag(tag string) bool {
+	if tag != "" && tag[0] >= '0' && tag[0] <= '9' {
+		return false
+	}
+	for _, r := range tag {
+		if !(r == '_' || (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9')) {
+			return false
+		}
+	}
+	return true
+}
+
 func (p *SQLParser) splitTableParts(content string) []string {
 	var parts []string
 	var current strings.Builder
@@ -725,7 +1664,8 @@ func (p *SQLParser) splitTableParts(content string) []string {
 
 func (p *SQLParser) isConstraint(part string) bool {
 	upperPart := strings.ToUpper(part)
-	return strings.Contains(upperPart, "PRIMARY KEY") ||
+	return strings.HasPrefix(upperPart, "CONSTRAINT") ||
+		strings.Contains(upperPart, "PRIMARY KEY") ||
 		strings.Contains(upperPart, "UNIQUE") ||
 		strings.Contains(upperPart, "CHECK") ||
 		strings.Contains(upperPart, "FOREIGN KEY")
@@ -762,6 +1702,10 @@ func (p *SQLParser) mapSQLActionToConstant(action string) string {
 }
 
 func (p *SQLParser) mapSQLTypeToSchemaType(sqlType string) string {
+	if strings.HasPrefix(sqlType, `"`) {
+		return strings.Trim(sqlType, `"`)
+	}
+
 	sqlType = strings.ToUpper(sqlType)
 
 	switch {
@@ -774,42 +1718,65 @@ func (p *SQLParser) mapSQLTypeToSchemaType(sqlType string) string {
 	case strings.HasPrefix(sqlType, "DOUBLE PRECISION"):
 		return string(constants.FLOAT)
 	case strings.HasPrefix(sqlType, "NUMERIC"):
-		return string(constants.NUMERIC)
+		return string(constants.DECIMAL)
 	case strings.HasPrefix(sqlType, "TEXT"), strings.HasPrefix(sqlType, "VARCHAR"):
 		return string(constants.STRING)
 	case strings.HasPrefix(sqlType, "UUID"):
-		return string(constants.STRING)
+		return string(constants.UUID)
+	case strings.HasPrefix(sqlType, "INET"):
+		return string(constants.INET)
+	case strings.HasPrefix(sqlType, "CIDR"):
+		return string(constants.CIDR)
+	case strings.HasPrefix(sqlType, "INT4RANGE"):
+		return string(constants.INT4RANGE)
+	case strings.HasPrefix(sqlType, "TSTZRANGE"):
+		return string(constants.TSTZRANGE)
 	case strings.HasPrefix(sqlType, "BOOLEAN"):
 		return string(constants.BOOLEAN)
 	case strings.HasPrefix(sqlType, "DATE"):
 		return string(constants.DATE)
+	case strings.HasPrefix(sqlType, "TIMESTAMPTZ"):
+		return string(constants.TIMESTAMPTZ)
 	case strings.HasPrefix(sqlType, "TIMESTAMP"):
 		return string(constants.TIMESTAMP)
+	case strings.HasPrefix(sqlType, "TIME"):
+		return string(constants.TIME)
+	case strings.HasPrefix(sqlType, "INTERVAL"):
+		return string(constants.INTERVAL)
 	case strings.HasPrefix(sqlType, "JSONB"):
 		return string(constants.JSON)
 	case strings.HasPrefix(sqlType, "BYTEA"):
 		return string(constants.BYTES)
 	case strings.HasPrefix(sqlType, "CHAR(1)"):
 		return string(constants.CHAR)
+	case strings.HasPrefix(sqlType, "CHAR("):
+		return string(constants.STRING)
 	default:
 		return sqlType
 	}
 }
 
-func (p *SQLParser) mapDefaultValueToSchema(defaultVal string) string {
+func (p *SQLParser) mapDefaultValueToSchema(defaultVal string, tableName string, columnName string) string {
 	if defaultVal == "" {
 		return ""
 	}
 
 	defaultVal = strings.TrimSpace(defaultVal)
 
+	if matches := p.nextvalRegex.FindStringSubmatch(defaultVal); matches != nil {
+		if matches[1] == fmt.Sprintf(constants.OWNED_SEQUENCE_NAME, tableName, columnName) {
+			return constants.DEFAULT_AUTOINCREMENT_CALLBACK
+		}
+		return fmt.Sprintf("%s(%s)", constants.DEFAULT_NEXTVAL_FUNCTION, matches[1])
+	}
+
 	switch {
-	case defaultVal == "CURRENT_TIMESTAMP(3)":
+	case defaultVal == "CURRENT_TIMESTAMP(3)", defaultVal == "LOCALTIME(3)":
 		return constants.DEFAULT_NOW_CALLBACK
 	case defaultVal == "gen_random_uuid()":
 		return constants.DEFAULT_UUID_CALLBACK
-	case defaultVal == "autoincrement()":
-		return constants.DEFAULT_AUTOINCREMENT_CALLBACK
+	case defaultVal == "uuidv7()":
+		return constants.DEFAULT_UUIDV7_CALLBACK
 	case strings.HasPrefix(defaultVal, "'") && strings.HasSuffix(defaultVal, "'"):
 		return defaultVal
 	default:
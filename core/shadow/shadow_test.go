@@ -0,0 +1,488 @@
+package shadow
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/rit3sh-x/blaze/core/ast"
+	"github.com/rit3sh-x/blaze/core/migration"
+	"github.com/rit3sh-x/blaze/core/utils"
+)
+
+const cyclicSchema = `class Post {
+  id Int @primaryKey @default(autoincrement())
+  authorId Int
+  author User @relation([authorId], [id], onDelete: Cascade)
+}
+
+class User {
+  id Int @primaryKey @default(autoincrement())
+  teamId Int?
+  team Team? @relation([teamId], [id], onDelete: SetNull, deferrable: true)
+  posts Post[]
+}
+
+class Team {
+  id Int @primaryKey @default(autoincrement())
+  ownerId Int
+  owner User @relation([ownerId], [id], deferrable: true)
+  members User[]
+}`
+
+const itemSchema = `class Item {
+  tenant String
+  code String
+  label String?
+  @@primaryKey([tenant, code])
+  @@index(fields: [code(sort: Desc), tenant])
+}`
+
+const indexedItemSchema = `class Item {
+  tenant String
+  code String
+  label String?
+  @@primaryKey([tenant, code])
+  @@index(fields: [code(sort: Desc), tenant])
+  @@index(fields: [label], type: Hash, where: "label IS NOT NULL")
+}`
+
+const pkOrderIndexSchema = `class Item {
+  tenant String
+  code String
+  label String?
+  @@primaryKey([tenant, code])
+  @@index([code, tenant])
+}`
+
+const unlinkedPostSchema = `class User {
+  id Int @primaryKey
+}
+class Post {
+  id Int @primaryKey
+  authorId Int
+}`
+
+const linkedPostSchema = `class User {
+  id Int @primaryKey
+  posts Post[]
+}
+class Post {
+  id Int @primaryKey
+  authorId Int
+  author User @relation([authorId], [id])
+}`
+
+const plainEventSchema = `class Event {
+  id Int @primaryKey
+  name String
+}`
+
+const stampedEventSchema = `class Event {
+  id Int @primaryKey
+  name String
+  changedAt Timestamp @updatedAt
+  touchedAt TimestampTz @updatedAt
+}`
+
+const zonedEventSchema = `class Event {
+  id Int @primaryKey
+  name String
+  changedAt TimestampTz @updatedAt
+  touchedAt TimestampTz @updatedAt @db.Timestamptz(6)
+}`
+
+const lineSchema = `class Line {
+  id Int @primaryKey
+  qty Int
+  price Int
+  total Int @computed("qty * price")
+}`
+
+const doubledLineSchema = `class Line {
+  id Int @primaryKey
+  qty Int
+  price Int
+  total Int @computed("qty * price * 2")
+}`
+
+const orderSchema = `class Order {
+  id Int @primaryKey
+  number Int
+}`
+
+const sequencedOrderSchema = `sequence orderNumber {
+  start 1000
+  increment 5
+  cache 10
+}
+class Order {
+  id Int @primaryKey @default(autoincrement())
+  number Int @default(nextval(orderNumber))
+}`
+
+const productSchema = `class Product {
+  id Int @primaryKey
+  code String
+  sku String
+  price Numeric
+  seenAt Timestamp
+}`
+
+const nativeProductSchema = `class Product {
+  id Int @primaryKey
+  code String @db.VarChar(16)
+  sku String @db.Char(8)
+  price Numeric @db.Numeric(12,2)
+  seenAt Timestamp @db.Timestamp(6)
+}`
+
+const widenedProductSchema = `class Product {
+  id Int @primaryKey
+  code String @db.VarChar(32)
+  sku String @db.Char(8)
+  price Numeric @db.Numeric(14,4)
+  seenAt Timestamp @db.Timestamp(3)
+}`
+
+const shiftSchema = `class Shift {
+  id Int @primaryKey
+  startsAt Timestamp
+}`
+
+const temporalShiftSchema = `class Shift {
+  id Int @primaryKey
+  startsAt TimestampTz @default(now())
+  opensAt Time @default(now())
+  closesAt Time? @db.Time(0)
+  length Interval
+  breaks Interval[]
+}`
+
+const textRefAccountSchema = `class Account {
+  id Int @primaryKey
+  ref String
+}`
+
+const uuidRefAccountSchema = `class Account {
+  id Int @primaryKey
+  ref Uuid
+  ownerId Uuid? @default(uuidv7())
+}`
+
+const bookingSchema = `class Booking {
+  id Int @primaryKey
+}`
+
+const networkBookingSchema = `class Booking {
+  id Int @primaryKey
+  ip Inet
+  net Cidr?
+  amount Decimal @db.Numeric(12,2)
+  seats Int4Range
+  during TsTzRange?
+  peers Inet[]
+}`
+
+const customerSchema = `type Geo {
+  lat Float
+  lng Float
+}
+type Address {
+  street String
+  city String
+  zip String?
+  geo Geo?
+}
+domain Email = String @check("VALUE ~ '@'")
+domain Cents = Int @default(0) @check("VALUE >= 0")
+class Customer {
+  id Int @primaryKey
+  email Email
+  balance Cents
+  home Address
+  previous Address[]
+}`
+
+const movedCustomerSchema = `type Geo {
+  lat Float
+  lng Float
+}
+type Address {
+  street String
+  city String
+  country String
+  geo Geo?
+}
+domain Email = String @check("VALUE ~ '@.+'")
+domain Cents = Int @default(0) @check("VALUE >= 0")
+class Customer {
+  id Int @primaryKey
+  email Email
+  balance Cents
+  home Address
+  previous Address[]
+}`
+
+const viewSchema = `class User {
+  id Int @primaryKey
+  email String
+  active Boolean
+}
+class Post {
+  id Int @primaryKey
+  authorId Int
+  title String
+}
+view ActiveUser {
+  id Int
+  email String
+} @@sql("SELECT \"id\", \"email\" FROM \"User\" WHERE \"active\"")
+view RecentUser {
+  id Int
+  email String
+  @@sql("SELECT \"id\", \"email\" FROM \"ActiveUser\"
+         WHERE \"id\" > 100")
+}
+materializedView UserStats {
+  userId Int
+  posts BigInt
+  @@unique([userId])
+  @@index([posts])
+  @@sql("SELECT \"authorId\" AS \"userId\", count(*) AS \"posts\" FROM \"Post\" GROUP BY \"authorId\"")
+}`
+
+const changedViewSchema = `class User {
+  id Int @primaryKey
+  email String @db.VarChar(100)
+  active Boolean
+}
+class Post {
+  id Int @primaryKey
+  authorId Int
+  title String
+}
+view ActiveUser {
+  id Int
+  email String
+} @@sql("SELECT \"id\", \"email\" FROM \"User\" WHERE \"active\" AND \"id\" > 0")
+view RecentUser {
+  id Int
+  email String
+  @@sql("SELECT \"id\", \"email\" FROM \"ActiveUser\"
+         WHERE \"id\" > 100")
+}
+materializedView UserStats {
+  userId Int
+  posts BigInt
+  @@unique([userId])
+  @@index([posts])
+  @@sql("SELECT \"authorId\" AS \"userId\", count(*) AS \"posts\" FROM \"Post\" GROUP BY \"authorId\"")
+}`
+
+const plainDocSchema = `class Doc {
+  id Int @primaryKey
+  tenantId Int
+}`
+
+const securedDocSchema = `class Doc {
+  id Int @primaryKey
+  tenantId Int
+  @@rls
+  @@policy(tenant_isolation, using: "\"tenantId\" = current_setting('app.tenant_id')::int")
+  @@policy(tenant_insert, for: Insert, to: app_user, check: "\"tenantId\" = current_setting('app.tenant_id')::int")
+}`
+
+const narrowedDocSchema = `class Doc {
+  id Int @primaryKey
+  tenantId Int
+  @@rls
+  @@policy(tenant_isolation, for: Select, using: "\"tenantId\" = current_setting('app.tenant_id')::int")
+}`
+
+const partitionedSchema = `class Event {
+  id Int
+  kind String
+  createdAt Timestamp @default(now())
+
+  @@primaryKey([id, createdAt])
+  @@index([kind])
+  @@partitionBy(range: [createdAt], interval: monthly, start: "2026-08", premake: 2)
+  @@partition(Event_old, from: MINVALUE, to: "2026-08-01")
+}
+
+class Region {
+  id Int
+  code String
+
+  @@primaryKey([id, code])
+  @@partitionBy(list: [code])
+  @@partition(Region_eu, in: ["eu", "uk"])
+  @@partition(Region_other, default: true)
+}
+
+class Bucket {
+  id Int @primaryKey
+
+  @@partitionBy(hash: [id])
+  @@partition(Bucket_0, modulus: 2, remainder: 0)
+  @@partition(Bucket_1, modulus: 2, remainder: 1)
+}`
+
+const repartitionedSchema = `class Event {
+  id Int
+  kind String
+  createdAt Timestamp @default(now())
+
+  @@primaryKey([id, createdAt])
+  @@index([kind])
+  @@partitionBy(range: [createdAt], interval: monthly, start: "2026-08", premake: 3)
+  @@partition(Event_old, from: MINVALUE, to: "2026-08-01")
+}
+
+class Region {
+  id Int
+  code String
+
+  @@primaryKey([id, code])
+  @@partitionBy(list: [code])
+  @@partition(Region_other, default: true)
+}
+
+class Bucket {
+  id Int @primaryKey
+
+  @@partitionBy(hash: [id])
+  @@partition(Bucket_0, modulus: 2, remainder: 0)
+  @@partition(Bucket_1, modulus: 2, remainder: 1)
+}`
+
+const roomBookingSchema = `class Booking {
+  id Int @primaryKey
+  during TsTzRange
+  cancelled Boolean @default(false)
+}`
+
+const exclusiveBookingSchema = `class Booking {
+  id Int @primaryKey
+  during TsTzRange
+  cancelled Boolean @default(false)
+
+  @@exclude([during WITH &&], where: "NOT cancelled")
+}`
+
+const renamedExclusiveBookingSchema = `class Booking {
+  id Int @primaryKey
+  during TsTzRange
+  cancelled Boolean @default(false)
+
+  @@exclude(using: Gist, [(tstzrange(lower(during), upper(during))) WITH &&], name: "booking_no_overlap")
+}`
+
+func TestMigrationShadowRoundTrip(t *testing.T) {
+	tests := []struct {
+		name          string
+		from          string
+		to            string
+		concurrent    bool
+		autoincrement string
+	}{
+		{name: "create cyclic tables", to: cyclicSchema},
+		{name: "drop cyclic tables", from: cyclicSchema},
+		{name: "add index", from: itemSchema, to: indexedItemSchema},
+		{name: "add index concurrently", from: itemSchema, to: indexedItemSchema, concurrent: true},
+		{name: "drop index", from: indexedItemSchema, to: itemSchema},
+		{name: "drop index concurrently", from: indexedItemSchema, to: itemSchema, concurrent: true},
+		{name: "add foreign key concurrently", from: unlinkedPostSchema, to: linkedPostSchema, concurrent: true},
+		{name: "add updatedAt columns", from: plainEventSchema, to: stampedEventSchema},
+		{name: "change updatedAt clock", from: stampedEventSchema, to: zonedEventSchema},
+		{name: "drop updatedAt columns", from: stampedEventSchema, to: plainEventSchema},
+		{name: "index in primary key order", from: itemSchema, to: pkOrderIndexSchema},
+		{name: "add computed column", to: lineSchema},
+		{name: "change computed expression", from: lineSchema, to: doubledLineSchema},
+		{name: "add identity and sequence", from: orderSchema, to: sequencedOrderSchema},
+		{name: "add owned sequence", from: orderSchema, to: sequencedOrderSchema, autoincrement: "sequence"},
+		{name: "drop identity and sequence", from: sequencedOrderSchema, to: orderSchema},
+		{name: "drop owned sequence", from: sequencedOrderSchema, to: orderSchema, autoincrement: "sequence"},
+		{name: "add native type modifiers", from: productSchema, to: nativeProductSchema},
+		{name: "change native type modifiers", from: nativeProductSchema, to: widenedProductSchema},
+		{name: "drop native type modifiers", from: nativeProductSchema, to: productSchema},
+		{name: "add temporal columns", from: shiftSchema, to: temporalShiftSchema},
+		{name: "drop temporal columns", from: temporalShiftSchema, to: shiftSchema},
+		{name: "convert text to uuid", from: textRefAccountSchema, to: uuidRefAccountSchema},
+		{name: "add network and range columns", from: bookingSchema, to: networkBookingSchema},
+		{name: "drop network and range columns", from: networkBookingSchema, to: bookingSchema},
+		{name: "create composite types and domains", to: customerSchema},
+		{name: "alter composite types and domains", from: customerSchema, to: movedCustomerSchema},
+		{name: "drop composite types and domains", from: customerSchema},
+		{name: "create views", to: viewSchema},
+		{name: "recreate changed views", from: viewSchema, to: changedViewSchema},
+		{name: "drop views", from: viewSchema},
+		{name: "enable row level security", from: plainDocSchema, to: securedDocSchema},
+		{name: "change policies", from: securedDocSchema, to: narrowedDocSchema},
+		{name: "disable row level security", from: securedDocSchema, to: plainDocSchema},
+		{name: "create partitioned tables", to: partitionedSchema},
+		{name: "add and drop partitions", from: partitionedSchema, to: repartitionedSchema},
+		{name: "restore dropped partitions", from: repartitionedSchema, to: partitionedSchema},
+		{name: "drop partitioned tables", from: partitionedSchema},
+		{name: "add exclusion constraint", from: roomBookingSchema, to: exclusiveBookingSchema},
+		{name: "replace exclusion constraint", from: exclusiveBookingSchema, to: renamedExclusiveBookingSchema},
+		{name: "drop exclusion constraint", from: exclusiveBookingSchema, to: roomBookingSchema},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			empty := buildSchema(t, "")
+			from := buildSchema(t, tt.from)
+			to := buildSchema(t, tt.to)
+
+			options := migration.MigrationOptions{Autoincrement: tt.autoincrement}
+			setup := generateMigration(t, empty, from, options)
+			shadow, err := NewSQLParser().ApplyMigrationToAST(nil, setup)
+			if err != nil {
+				t.Fatalf("applying setup migration: %v", err)
+			}
+			if rediff := generateMigration(t, shadow, from, options); len(migration.SplitMigrationSteps(rediff)) > 0 {
+				t.Fatalf("setup migration does not round trip:\n%s", rediff)
+			}
+
+			change := generateMigration(t, shadow, to, migration.MigrationOptions{Concurrent: tt.concurrent, Autoincrement: tt.autoincrement})
+			if len(migration.SplitMigrationSteps(change)) == 0 {
+				t.Fatalf("expected a migration from %q to %q", tt.from, tt.to)
+			}
+			shadow, err = NewSQLParser().ApplyMigrationToAST(shadow, change)
+			if err != nil {
+				t.Fatalf("applying migration: %v\n%s", err, change)
+			}
+			if rediff := generateMigration(t, shadow, to, options); len(migration.SplitMigrationSteps(rediff)) > 0 {
+				t.Errorf("migration does not round trip:\n%s\nrediff:\n%s", change, rediff)
+			}
+		})
+	}
+}
+
+func buildSchema(t *testing.T, source string) *ast.SchemaAST {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "schema.blaze")
+	if err := os.WriteFile(path, []byte(source), 0644); err != nil {
+		t.Fatalf("writing schema: %v", err)
+	}
+	content, err := utils.ReadAndSeparateSchema(path)
+	if err != nil {
+		t.Fatalf("ReadAndSeparateSchema() error: %v", err)
+	}
+	schema, err := ast.BuildSchemaAST(content)
+	if err != nil {
+		t.Fatalf("BuildSchemaAST() error: %v", err)
+	}
+	return schema
+}
+
+func generateMigration(t *testing.T, from *ast.SchemaAST, to *ast.SchemaAST, options migration.MigrationOptions) string {
+	t.Helper()
+	sql, err := migration.NewMigrationEngineWithOptions(from, to, options).GenerateMigration()
+	if err != nil {
+		t.Fatalf("GenerateMigration() error: %v", err)
+	}
+	return sql
+}
@@ -2,9 +2,13 @@ package class
 
 import (
 	"fmt"
+	"regexp"
 	"sort"
+	"strconv"
 	"strings"
 
+	"github.com/rit3sh-x/blaze/core/ast/class/directives"
+	"github.com/rit3sh-x/blaze/core/ast/field/native"
 	"github.com/rit3sh-x/blaze/core/constants"
 )
 
@@ -15,6 +19,10 @@ type Column struct {
 	IsArray         bool
 	ColumnDefault   string
 	OrdinalPosition int8
+	Computed        string
+	IsIdentity      bool
+	OwnedSequence   string
+	NativeType      *native.NativeType
 }
 
 type Constraint struct {
@@ -24,10 +32,11 @@ type Constraint struct {
 }
 
 type Index struct {
-	Name      string
-	IsUnique  bool
-	IsPrimary bool
-	Fields    []string
+	Name       string
+	IsUnique   bool
+	IsPrimary  bool
+	Fields     []string
+	Definition string
 }
 
 type Relation struct {
@@ -40,14 +49,22 @@ type Relation struct {
 }
 
 type ClassData struct {
-	Name        string
-	Columns     []Column
-	Constraints []Constraint
-	Indexes     []Index
-	Relations   []Relation
+	Name             string
+	Columns          []Column
+	Constraints      []Constraint
+	Indexes          []Index
+	Relations        []Relation
+	UpdatedAtColumns []string
+	RowSecurity      bool
+	Policies         []*directives.Policy
+	Exclusions       []*directives.Exclusion
+	PartitionBy      *directives.PartitionBy
+	Partitions       []*directives.Partition
 }
 
-func mapPostgreSQLType(pgType string, enums []string) (string, error) {
+var nextvalRegex = regexp.MustCompile(`^nextval\('([^']+)'(?:::regclass)?\)$`)
+
+func MapPostgreSQLType(pgType string, enums []string) (string, error) {
 	if Contains(enums, pgType) {
 		return pgType, nil
 	}
@@ -74,20 +91,93 @@ func isDefaultCallback(defaultVal string) (string, bool) {
 
 	defaultVal = strings.TrimSpace(strings.ToLower(defaultVal))
 
+	if strings.Contains(defaultVal, "uuidv7") {
+		return constants.DEFAULT_UUIDV7_CALLBACK, true
+	}
 	if strings.Contains(defaultVal, "gen_random_uuid") {
 		return constants.DEFAULT_UUID_CALLBACK, true
 	}
-	if strings.Contains(defaultVal, "current_timestamp") {
+	if strings.Contains(defaultVal, "current_timestamp") || strings.Contains(defaultVal, "localtime") || strings.Contains(defaultVal, "now()") {
 		return constants.DEFAULT_NOW_CALLBACK, true
 	}
-	if strings.Contains(defaultVal, "nextval") {
+	return "", false
+}
+
+func FormatDefault(fieldType string, defaultValue string) string {
+	if callback, isCallback := isDefaultCallback(defaultValue); isCallback {
+		return callback
+	}
+
+	if scalarType := constants.ScalarType(strings.TrimRight(fieldType, "[]?")); constants.IsTemporalType(scalarType) || constants.IsNetworkType(scalarType) || constants.IsRangeType(scalarType) {
+		return fmt.Sprintf("\"%s\"", strings.ReplaceAll(defaultValue, "_", " "))
+	}
+
+	if strings.Contains(strings.ToLower(fieldType), "string") || strings.Contains(strings.ToLower(fieldType), "char") || strings.Contains(strings.ToLower(fieldType), "uuid") {
+		if strings.Contains(defaultValue, "(") {
+			return ""
+		}
+		return fmt.Sprintf("\"%s\"", defaultValue)
+	}
+
+	return defaultValue
+}
+
+func sequenceDefault(column Column) (string, bool) {
+	if column.IsIdentity {
 		return constants.DEFAULT_AUTOINCREMENT_CALLBACK, true
 	}
 
-	return "", false
+	matches := nextvalRegex.FindStringSubmatch(column.ColumnDefault)
+	if matches == nil {
+		return "", false
+	}
+
+	if column.OwnedSequence != "" {
+		return constants.DEFAULT_AUTOINCREMENT_CALLBACK, true
+	}
+
+	sequenceName := matches[1]
+	if idx := strings.LastIndex(sequenceName, "."); idx != -1 {
+		sequenceName = sequenceName[idx+1:]
+	}
+	return fmt.Sprintf("%s(%s)", constants.DEFAULT_NEXTVAL_FUNCTION, strings.Trim(sequenceName, `"`)), true
+}
+
+func ResolveNativeType(pgType string, characterLength, numericPrecision, numericScale, datetimePrecision *int32) *native.NativeType {
+	switch strings.ToLower(pgType) {
+	case "varchar":
+		if characterLength != nil {
+			return &native.NativeType{Name: constants.NATIVE_TYPE_VARCHAR, Args: []int{int(*characterLength)}}
+		}
+	case "bpchar":
+		if characterLength != nil && *characterLength > 1 {
+			return &native.NativeType{Name: constants.NATIVE_TYPE_CHAR, Args: []int{int(*characterLength)}}
+		}
+	case "numeric":
+		if numericPrecision != nil {
+			args := []int{int(*numericPrecision)}
+			if numericScale != nil && *numericScale != 0 {
+				args = append(args, int(*numericScale))
+			}
+			return &native.NativeType{Name: constants.NATIVE_TYPE_NUMERIC, Args: args}
+		}
+	case "timestamp":
+		if datetimePrecision != nil && *datetimePrecision != 3 {
+			return &native.NativeType{Name: constants.NATIVE_TYPE_TIMESTAMP, Args: []int{int(*datetimePrecision)}}
+		}
+	case "timestamptz":
+		if datetimePrecision != nil && *datetimePrecision != 3 {
+			return &native.NativeType{Name: constants.NATIVE_TYPE_TIMESTAMPTZ, Args: []int{int(*datetimePrecision)}}
+		}
+	case "time":
+		if datetimePrecision != nil && *datetimePrecision != 3 {
+			return &native.NativeType{Name: constants.NATIVE_TYPE_TIME, Args: []int{int(*datetimePrecision)}}
+		}
+	}
+	return nil
 }
 
-func GenerateClassSchema(classData []ClassData, enums []string) string {
+func GenerateClassSchema(classData []ClassData, enums []string, domains map[string]string) string {
 	if len(classData) == 0 {
 		return ""
 	}
@@ -106,11 +196,15 @@ func GenerateClassSchema(classData []ClassData, enums []string) string {
 		})
 
 		for _, column := range class.Columns {
-			fieldType, err := mapPostgreSQLType(column.DataType, enums)
+			fieldType, err := MapPostgreSQLType(column.DataType, enums)
 			if err != nil {
 				return err.Error()
 			}
 
+			if column.NativeType != nil && column.NativeType.Name == constants.NATIVE_TYPE_CHAR {
+				fieldType = constants.STRING.String()
+			}
+
 			if column.IsArray {
 				fieldType += "[]"
 			}
@@ -132,21 +226,27 @@ func GenerateClassSchema(classData []ClassData, enums []string) string {
 				attributes = append(attributes, "@unique")
 			}
 
+			if column.NativeType != nil {
+				attributes = append(attributes, column.NativeType.String())
+			}
+
+			if Contains(class.UpdatedAtColumns, column.Name) {
+				attributes = append(attributes, "@"+constants.FIELD_ATTR_UPDATED_AT)
+			}
+
 			defualtValue := column.ColumnDefault
 
-			if defualtValue != "null" && defualtValue != "" {
-				if callback, isCallback := isDefaultCallback(defualtValue); isCallback {
-					attributes = append(attributes, fmt.Sprintf("@default(%s)", callback))
-				} else {
-					if strings.Contains(strings.ToLower(fieldType), "string") || strings.Contains(strings.ToLower(fieldType), "char") {
-						if !strings.Contains(defualtValue, "(") {
-							attributes = append(attributes, fmt.Sprintf("@default(\"%s\")", defualtValue))
-						}
-					} else if strings.Contains(strings.ToLower(fieldType), "int") || strings.Contains(strings.ToLower(fieldType), "float") || strings.Contains(strings.ToLower(fieldType), "numeric") || strings.Contains(strings.ToLower(fieldType), "boolean") {
-						attributes = append(attributes, fmt.Sprintf("@default(%s)", defualtValue))
-					} else {
-						attributes = append(attributes, fmt.Sprintf("@default(%s)", defualtValue))
-					}
+			if column.Computed != "" {
+				attributes = append(attributes, fmt.Sprintf("@%s(%s)", constants.FIELD_ATTR_COMPUTED, strconv.Quote(column.Computed)))
+			} else if seqDefault, isSequence := sequenceDefault(column); isSequence {
+				attributes = append(attributes, fmt.Sprintf("@default(%s)", seqDefault))
+			} else if defualtValue != "null" && defualtValue != "" {
+				defaultType := fieldType
+				if baseType, isDomain := domains[column.DataType]; isDomain {
+					defaultType = baseType
+				}
+				if formatted := FormatDefault(defaultType, defualtValue); formatted != "" {
+					attributes = append(attributes, fmt.Sprintf("@default(%s)", formatted))
 				}
 			}
 
@@ -205,14 +305,57 @@ func GenerateClassSchema(classData []ClassData, enums []string) string {
 		}
 
 		for _, index := range class.Indexes {
-			if !index.IsPrimary && len(index.Fields) > 1 {
-				indexCols := fmt.Sprintf("[%s]", strings.Join(index.Fields, ", "))
-				if index.IsUnique {
+			if index.IsPrimary {
+				continue
+			}
+
+			if index.IsUnique {
+				if len(index.Fields) > 1 {
+					indexCols := fmt.Sprintf("[%s]", strings.Join(index.Fields, ", "))
 					classAttributes = append(classAttributes, fmt.Sprintf("@@unique(%s)", indexCols))
-				} else {
-					classAttributes = append(classAttributes, fmt.Sprintf("@@index(%s)", indexCols))
 				}
+				continue
+			}
+
+			if isForeignKeyIndex(class.Name, index.Name, class.Relations) {
+				continue
+			}
+
+			parsedIndex, _, isText, err := directives.ParseIndexSQL(index.Definition)
+			if err != nil {
+				continue
+			}
+
+			parsedIndex.Name = ""
+			if parsedIndex.GetName(class.Name, isText) != index.Name {
+				parsedIndex.Name = index.Name
+			}
+
+			directiveName := constants.CLASS_ATTR_INDEX
+			if isText {
+				directiveName = constants.CLASS_ATTR_TEXT_INDEX
 			}
+			classAttributes = append(classAttributes, fmt.Sprintf("@@%s(%s)", directiveName, parsedIndex.String()))
+		}
+
+		for _, exclusion := range class.Exclusions {
+			classAttributes = append(classAttributes, fmt.Sprintf("@@%s(%s)", constants.CLASS_ATTR_EXCLUDE, exclusion.String()))
+		}
+
+		if class.RowSecurity {
+			classAttributes = append(classAttributes, "@@"+constants.CLASS_ATTR_RLS)
+		}
+
+		for _, policy := range class.Policies {
+			classAttributes = append(classAttributes, fmt.Sprintf("@@%s(%s)", constants.CLASS_ATTR_POLICY, policy.String()))
+		}
+
+		if class.PartitionBy != nil {
+			classAttributes = append(classAttributes, fmt.Sprintf("@@%s(%s)", constants.CLASS_ATTR_PARTITION_BY, class.PartitionBy.String()))
+		}
+
+		for _, partition := range class.Partitions {
+			classAttributes = append(classAttributes, fmt.Sprintf("@@%s(%s)", constants.CLASS_ATTR_PARTITION, partition.String()))
 		}
 
 		if len(classAttributes) > 0 {
@@ -250,6 +393,15 @@ func isColumnUnique(columnName string, constraints []Constraint) bool {
 	return false
 }
 
+func isForeignKeyIndex(className string, indexName string, relations []Relation) bool {
+	for _, relation := range relations {
+		if indexName == fmt.Sprintf("idx_%s_%s", strings.ToLower(className), strings.ToLower(strings.Join(relation.FkColumns, "_"))) {
+			return true
+		}
+	}
+	return false
+}
+
 func isColumnNullable(columnName string, columns []Column) bool {
 	for _, column := range columns {
 		if column.Name == columnName {
@@ -0,0 +1,223 @@
+package class
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/rit3sh-x/blaze/core/ast/class/directives"
+)
+
+func TestGenerateClassSchema(t *testing.T) {
+	tests := []struct {
+		name    string
+		classes []ClassData
+		enums   []string
+		domains map[string]string
+		want    []string
+		absent  []string
+	}{
+		{
+			name: "identity and owned sequence columns become autoincrement",
+			classes: []ClassData{{
+				Name: "Order",
+				Columns: []Column{
+					{Name: "id", DataType: "int4", OrdinalPosition: 1, IsIdentity: true},
+					{Name: "legacyId", DataType: "int4", OrdinalPosition: 2, ColumnDefault: `nextval('"Order_legacyId_seq"'::regclass)`, OwnedSequence: `public."Order_legacyId_seq"`},
+					{Name: "number", DataType: "int4", OrdinalPosition: 3, ColumnDefault: `nextval('public."orderNumber"'::regclass)`},
+				},
+				Constraints: []Constraint{{Name: "Order_pkey", Type: "PRIMARY_KEY", Columns: []string{"id"}}},
+			}},
+			want: []string{
+				"id         Int @primaryKey @default(autoincrement())",
+				"legacyId   Int @default(autoincrement())",
+				"number     Int @default(nextval(orderNumber))",
+			},
+			absent: []string{"regclass", "Order_legacyId_seq"},
+		},
+		{
+			name: "native type modifiers are written back",
+			classes: []ClassData{{
+				Name: "Product",
+				Columns: []Column{
+					{Name: "code", DataType: "varchar", OrdinalPosition: 1, NativeType: ResolveNativeType("varchar", int32Ptr(16), nil, nil, nil)},
+					{Name: "sku", DataType: "bpchar", OrdinalPosition: 2, NativeType: ResolveNativeType("bpchar", int32Ptr(8), nil, nil, nil)},
+					{Name: "price", DataType: "numeric", OrdinalPosition: 3, NativeType: ResolveNativeType("numeric", nil, int32Ptr(12), int32Ptr(2), nil)},
+				},
+			}},
+			want: []string{
+				"code       String @db.VarChar(16)",
+				"sku        String @db.Char(8)",
+				"price      Numeric @db.Numeric(12, 2)",
+			},
+		},
+		{
+			name: "temporal columns are introspected",
+			classes: []ClassData{{
+				Name: "Shift",
+				Columns: []Column{
+					{Name: "startsAt", DataType: "timestamptz", OrdinalPosition: 1, ColumnDefault: "CURRENT_TIMESTAMP(3)"},
+					{Name: "opensAt", DataType: "time", OrdinalPosition: 2, IsNullable: true, ColumnDefault: "LOCALTIME(3)"},
+					{Name: "length", DataType: "interval", OrdinalPosition: 3, ColumnDefault: "'01:00:00'::interval"},
+				},
+			}},
+			want: []string{
+				"startsAt   TimestampTz @default(now())",
+				"opensAt    Time? @default(now())",
+				"length     Interval",
+			},
+			absent: []string{"unsupported PostgreSQL type"},
+		},
+		{
+			name: "uuid columns become the uuid scalar",
+			classes: []ClassData{{
+				Name: "Account",
+				Columns: []Column{
+					{Name: "id", DataType: "uuid", OrdinalPosition: 1, ColumnDefault: "uuidv7()"},
+					{Name: "token", DataType: "uuid", OrdinalPosition: 2, ColumnDefault: "gen_random_uuid()"},
+				},
+			}},
+			want: []string{
+				"id         Uuid @default(uuidv7())",
+				"token      Uuid @default(uuid())",
+			},
+		},
+		{
+			name: "network and range columns are introspected",
+			classes: []ClassData{{
+				Name: "Booking",
+				Columns: []Column{
+					{Name: "ip", DataType: "inet", OrdinalPosition: 1, ColumnDefault: "'127.0.0.1'::inet"},
+					{Name: "net", DataType: "cidr", OrdinalPosition: 2, IsNullable: true},
+					{Name: "seats", DataType: "int4range", OrdinalPosition: 3},
+					{Name: "during", DataType: "tstzrange", OrdinalPosition: 4},
+				},
+			}},
+			want: []string{
+				"ip         Inet",
+				"net        Cidr?",
+				"seats      Int4Range",
+				"during     TsTzRange",
+			},
+			absent: []string{"unsupported PostgreSQL type"},
+		},
+		{
+			name: "row level security and policies are written back",
+			classes: []ClassData{{
+				Name: "Doc",
+				Columns: []Column{
+					{Name: "id", DataType: "int4", OrdinalPosition: 1},
+					{Name: "tenantId", DataType: "int4", OrdinalPosition: 2},
+				},
+				Constraints: []Constraint{{Name: "Doc_pkey", Type: "PRIMARY_KEY", Columns: []string{"id"}}},
+				RowSecurity: true,
+				Policies: []*directives.Policy{
+					{Name: "tenant_isolation", Command: "All", Using: `("tenantId" = 1)`},
+					{Name: "tenant_insert", Command: "Insert", Roles: []string{"app_user"}, Check: "true"},
+				},
+			}},
+			want: []string{
+				"@@rls",
+				`@@policy(tenant_isolation, using: "(\"tenantId\" = 1)")`,
+				`@@policy(tenant_insert, for: Insert, to: app_user, check: "true")`,
+			},
+		},
+		{
+			name: "partition keys and explicit partitions are written back",
+			classes: []ClassData{{
+				Name: "Event",
+				Columns: []Column{
+					{Name: "id", DataType: "int4", OrdinalPosition: 1},
+					{Name: "createdAt", DataType: "timestamp", OrdinalPosition: 2},
+				},
+				Constraints: []Constraint{{Name: "Event_pkey", Type: "PRIMARY_KEY", Columns: []string{"id", "createdAt"}}},
+				PartitionBy: &directives.PartitionBy{Strategy: "range", Columns: []string{"createdAt"}},
+				Partitions: []*directives.Partition{
+					{Name: "Event_old", From: []string{"MINVALUE"}, To: []string{"2026-08-01"}},
+					{Name: "Event_rest", Default: true},
+				},
+			}},
+			want: []string{
+				"@@partitionBy(range: [createdAt])",
+				`@@partition(Event_old, from: MINVALUE, to: "2026-08-01")`,
+				"@@partition(Event_rest, default: true)",
+			},
+		},
+		{
+			name: "exclusion constraints are written back",
+			classes: []ClassData{{
+				Name: "Booking",
+				Columns: []Column{
+					{Name: "id", DataType: "int4", OrdinalPosition: 1},
+					{Name: "roomId", DataType: "int4", OrdinalPosition: 2},
+					{Name: "during", DataType: "tstzrange", OrdinalPosition: 3},
+				},
+				Constraints: []Constraint{{Name: "Booking_pkey", Type: "PRIMARY_KEY", Columns: []string{"id"}}},
+				Exclusions: []*directives.Exclusion{{
+					Elements: []directives.ExclusionElement{{Field: "roomId", Operator: "="}, {Field: "during", Operator: "&&"}},
+					Name:     "booking_no_overlap",
+				}},
+			}},
+			want: []string{
+				"during     TsTzRange",
+				`@@exclude(using: Gist, [roomId WITH =, during WITH &&], name: "booking_no_overlap")`,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			schema := GenerateClassSchema(tt.classes, tt.enums, tt.domains)
+			for _, fragment := range tt.want {
+				if !strings.Contains(schema, fragment) {
+					t.Errorf("missing %q in:\n%s", fragment, schema)
+				}
+			}
+			for _, fragment := range tt.absent {
+				if strings.Contains(schema, fragment) {
+					t.Errorf("unexpected %q in:\n%s", fragment, schema)
+				}
+			}
+		})
+	}
+}
+
+func TestResolveNativeType(t *testing.T) {
+	tests := []struct {
+		name              string
+		pgType            string
+		characterLength   *int32
+		numericPrecision  *int32
+		numericScale      *int32
+		datetimePrecision *int32
+		want              string
+	}{
+		{name: "varchar length", pgType: "varchar", characterLength: int32Ptr(16), want: "VARCHAR(16)"},
+		{name: "unbounded varchar", pgType: "varchar"},
+		{name: "char length", pgType: "bpchar", characterLength: int32Ptr(8), want: "CHAR(8)"},
+		{name: "single char", pgType: "bpchar", characterLength: int32Ptr(1)},
+		{name: "numeric precision and scale", pgType: "numeric", numericPrecision: int32Ptr(12), numericScale: int32Ptr(2), want: "NUMERIC(12,2)"},
+		{name: "numeric without scale", pgType: "numeric", numericPrecision: int32Ptr(12), numericScale: int32Ptr(0), want: "NUMERIC(12)"},
+		{name: "unbounded numeric", pgType: "numeric"},
+		{name: "timestamp precision", pgType: "timestamp", datetimePrecision: int32Ptr(6), want: "TIMESTAMP(6)"},
+		{name: "default timestamp precision", pgType: "timestamp", datetimePrecision: int32Ptr(3)},
+		{name: "timestamptz precision", pgType: "timestamptz", datetimePrecision: int32Ptr(0), want: "TIMESTAMPTZ(0)"},
+		{name: "time precision", pgType: "time", datetimePrecision: int32Ptr(6), want: "TIME(6)"},
+		{name: "type without modifiers", pgType: "int4", characterLength: int32Ptr(4)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ""
+			if nativeType := ResolveNativeType(tt.pgType, tt.characterLength, tt.numericPrecision, tt.numericScale, tt.datetimePrecision); nativeType != nil {
+				got = nativeType.SQL()
+			}
+			if got != tt.want {
+				t.Errorf("ResolveNativeType() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func int32Ptr(v int32) *int32 {
+	return &v
+}
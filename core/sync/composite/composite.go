@@ -0,0 +1,79 @@
+package composite
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/rit3sh-x/blaze/core/constants"
+)
+
+type CompositeData struct {
+	Name          string
+	AttributeName string
+	AttributeType string
+	IsArray       bool
+	Position      int32
+}
+
+func GenerateCompositeSchema(compositeData []CompositeData) string {
+	if len(compositeData) == 0 {
+		return ""
+	}
+
+	typeMap := make(map[string][]CompositeData)
+	var names []string
+	for _, data := range compositeData {
+		if _, exists := typeMap[data.Name]; !exists {
+			names = append(names, data.Name)
+		}
+		typeMap[data.Name] = append(typeMap[data.Name], data)
+	}
+	sort.Strings(names)
+
+	var schemaBuilder strings.Builder
+	for i, name := range names {
+		attributes := typeMap[name]
+		sort.Slice(attributes, func(i, j int) bool {
+			return attributes[i].Position < attributes[j].Position
+		})
+
+		if i > 0 {
+			schemaBuilder.WriteString("\n\n")
+		}
+		schemaBuilder.WriteString(formatComposite(name, attributes))
+	}
+
+	return schemaBuilder.String()
+}
+
+func formatComposite(name string, attributes []CompositeData) string {
+	var builder strings.Builder
+	builder.WriteString(fmt.Sprintf(constants.KEYWORD_TYPE+" %s {\n", name))
+
+	for _, attribute := range attributes {
+		attributeType := attribute.AttributeType
+		if attribute.IsArray {
+			attributeType += "[]"
+		}
+		builder.WriteString(fmt.Sprintf("  %s %s\n", attribute.AttributeName, attributeType))
+	}
+
+	builder.WriteString("}")
+	return builder.String()
+}
+
+func GetCompositeNames(compositeData []CompositeData) []string {
+	nameSet := make(map[string]bool)
+	var names []string
+
+	for _, data := range compositeData {
+		if !nameSet[data.Name] {
+			nameSet[data.Name] = true
+			names = append(names, data.Name)
+		}
+	}
+
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,26 @@
+package composite
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestGenerateCompositeSchema(t *testing.T) {
+	data := []CompositeData{
+		{Name: "Geo", AttributeName: "lng", AttributeType: "Float", Position: 2},
+		{Name: "Address", AttributeName: "city", AttributeType: "String", Position: 2},
+		{Name: "Geo", AttributeName: "lat", AttributeType: "Float", Position: 1},
+		{Name: "Address", AttributeName: "street", AttributeType: "String", Position: 1},
+		{Name: "Address", AttributeName: "lines", AttributeType: "String", IsArray: true, Position: 3},
+	}
+
+	want := "type Address {\n  street String\n  city String\n  lines String[]\n}\n\n" +
+		"type Geo {\n  lat Float\n  lng Float\n}"
+	if got := GenerateCompositeSchema(data); got != want {
+		t.Errorf("GenerateCompositeSchema() =\n%s\nwant\n%s", got, want)
+	}
+
+	if names := GetCompositeNames(data); !reflect.DeepEqual(names, []string{"Address", "Geo"}) {
+		t.Errorf("GetCompositeNames() = %v", names)
+	}
+}
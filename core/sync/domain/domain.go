@@ -0,0 +1,94 @@
+package domain
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/rit3sh-x/blaze/core/ast/field/native"
+	"github.com/rit3sh-x/blaze/core/constants"
+)
+
+type DomainData struct {
+	Name       string
+	BaseType   string
+	NativeType *native.NativeType
+	Default    string
+	Check      string
+}
+
+func GenerateDomainSchema(domainData []DomainData) string {
+	if len(domainData) == 0 {
+		return ""
+	}
+
+	sort.Slice(domainData, func(i, j int) bool {
+		return domainData[i].Name < domainData[j].Name
+	})
+
+	var lines []string
+	for _, d := range domainData {
+		lines = append(lines, formatDomain(d))
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+func formatDomain(d DomainData) string {
+	var builder strings.Builder
+	builder.WriteString(fmt.Sprintf(constants.KEYWORD_DOMAIN+" %s = %s", d.Name, d.BaseType))
+
+	if d.NativeType != nil {
+		builder.WriteString(" " + d.NativeType.String())
+	}
+
+	if d.Default != "" {
+		builder.WriteString(fmt.Sprintf(" @%s(%s)", constants.DOMAIN_ATTR_DEFAULT, d.Default))
+	}
+
+	if d.Check != "" {
+		builder.WriteString(fmt.Sprintf(" @%s(\"%s\")", constants.DOMAIN_ATTR_CHECK, strings.ReplaceAll(d.Check, `"`, `\"`)))
+	}
+
+	return builder.String()
+}
+
+func CheckExpression(constraintDefinition string) string {
+	expression := strings.TrimSpace(constraintDefinition)
+	if !strings.HasPrefix(strings.ToUpper(expression), "CHECK") {
+		return expression
+	}
+
+	expression = strings.TrimSpace(expression[len("CHECK"):])
+	for strings.HasPrefix(expression, "(") && strings.HasSuffix(expression, ")") && wrapsWhole(expression) {
+		expression = strings.TrimSpace(expression[1 : len(expression)-1])
+	}
+	return expression
+}
+
+func wrapsWhole(expression string) bool {
+	depth := 0
+	inString := false
+
+	for i, char := range expression {
+		if char == '\'' {
+			inString = !inString
+			continue
+		}
+		if inString {
+			continue
+		}
+
+		switch char {
+		case '(':
+			depth++
+		case ')':
+			depth--
+			if depth == 0 && i != len(expression)-1 {
+				return false
+			}
+		}
+	}
+
+	return depth == 0
+}
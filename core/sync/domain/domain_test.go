@@ -0,0 +1,41 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/rit3sh-x/blaze/core/ast/field/native"
+)
+
+func TestCheckExpression(t *testing.T) {
+	tests := []struct {
+		definition string
+		want       string
+	}{
+		{definition: "CHECK ((VALUE ~ '@'::text))", want: "VALUE ~ '@'::text"},
+		{definition: "CHECK (VALUE >= 0)", want: "VALUE >= 0"},
+		{definition: "CHECK ((VALUE > 0) AND (VALUE < 10))", want: "(VALUE > 0) AND (VALUE < 10)"},
+		{definition: "CHECK ((VALUE <> ')('::text))", want: "VALUE <> ')('::text"},
+		{definition: "VALUE IS NOT NULL", want: "VALUE IS NOT NULL"},
+	}
+
+	for _, tt := range tests {
+		if got := CheckExpression(tt.definition); got != tt.want {
+			t.Errorf("CheckExpression(%q) = %q, want %q", tt.definition, got, tt.want)
+		}
+	}
+}
+
+func TestGenerateDomainSchema(t *testing.T) {
+	schema := GenerateDomainSchema([]DomainData{
+		{Name: "Email", BaseType: "String", Check: `VALUE ~ '"@'`},
+		{Name: "Cents", BaseType: "Int", Default: "0", Check: "VALUE >= 0"},
+		{Name: "Code", BaseType: "String", NativeType: &native.NativeType{Name: "VarChar", Args: []int{8}}},
+	})
+
+	want := "domain Cents = Int @default(0) @check(\"VALUE >= 0\")\n" +
+		"domain Code = String @db.VarChar(8)\n" +
+		"domain Email = String @check(\"VALUE ~ '\\\"@'\")"
+	if schema != want {
+		t.Errorf("GenerateDomainSchema() =\n%s\nwant\n%s", schema, want)
+	}
+}
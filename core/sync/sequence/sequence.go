@@ -0,0 +1,46 @@
+package sequence
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/rit3sh-x/blaze/core/constants"
+)
+
+type SequenceData struct {
+	Name      string
+	Start     int64
+	Increment int64
+	Cache     int64
+}
+
+func GenerateSequenceSchema(sequenceData []SequenceData) string {
+	if len(sequenceData) == 0 {
+		return ""
+	}
+
+	sort.Slice(sequenceData, func(i, j int) bool {
+		return sequenceData[i].Name < sequenceData[j].Name
+	})
+
+	var schemaBuilder strings.Builder
+	for i, seq := range sequenceData {
+		if i > 0 {
+			schemaBuilder.WriteString("\n\n")
+		}
+		schemaBuilder.WriteString(formatSequence(seq))
+	}
+
+	return schemaBuilder.String()
+}
+
+func formatSequence(seq SequenceData) string {
+	var builder strings.Builder
+	builder.WriteString(fmt.Sprintf(constants.KEYWORD_SEQUENCE+" %s {\n", seq.Name))
+	builder.WriteString(fmt.Sprintf("  start %d\n", seq.Start))
+	builder.WriteString(fmt.Sprintf("  increment %d\n", seq.Increment))
+	builder.WriteString(fmt.Sprintf("  cache %d\n", seq.Cache))
+	builder.WriteString("}")
+	return builder.String()
+}
@@ -7,9 +7,14 @@ import (
 	"strings"
 
 	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/rit3sh-x/blaze/core/ast/class/directives"
 	"github.com/rit3sh-x/blaze/core/constants"
 	"github.com/rit3sh-x/blaze/core/sync/class"
+	"github.com/rit3sh-x/blaze/core/sync/composite"
+	"github.com/rit3sh-x/blaze/core/sync/domain"
 	"github.com/rit3sh-x/blaze/core/sync/enum"
+	"github.com/rit3sh-x/blaze/core/sync/sequence"
+	"github.com/rit3sh-x/blaze/core/sync/view"
 )
 
 func GetEnums(client *pgxpool.Pool, ctx context.Context) (string, []string, error) {
@@ -53,7 +58,138 @@ func GetEnums(client *pgxpool.Pool, ctx context.Context) (string, []string, erro
 	return enumSchema, enumNames, nil
 }
 
-func GetClasses(client *pgxpool.Pool, ctx context.Context, enums []string) (string, error) {
+func GetSequences(client *pgxpool.Pool, ctx context.Context) (string, error) {
+	var sequenceData []sequence.SequenceData
+
+	rows, err := client.Query(ctx, constants.ALL_SEQUENCES_QUERY)
+	if err != nil {
+		return "", fmt.Errorf("failed to fetch available sequences: %v", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var data sequence.SequenceData
+		if err := rows.Scan(&data.Name, &data.Start, &data.Increment, &data.Cache); err != nil {
+			return "", fmt.Errorf("scan sequence row error: %v", err)
+		}
+		sequenceData = append(sequenceData, data)
+	}
+
+	if rows.Err() != nil {
+		return "", fmt.Errorf("row iteration error: %v", rows.Err())
+	}
+
+	return sequence.GenerateSequenceSchema(sequenceData), nil
+}
+
+func GetCompositeTypes(client *pgxpool.Pool, ctx context.Context, userTypes []string) (string, []string, error) {
+	var compositeData []composite.CompositeData
+
+	rows, err := client.Query(ctx, constants.ALL_COMPOSITE_TYPES_QUERY)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to fetch available composite types: %v", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var data composite.CompositeData
+		if err := rows.Scan(&data.Name, &data.AttributeName, &data.AttributeType, &data.Position); err != nil {
+			return "", nil, fmt.Errorf("scan composite type row error: %v", err)
+		}
+		compositeData = append(compositeData, data)
+	}
+
+	if rows.Err() != nil {
+		return "", nil, fmt.Errorf("row iteration error: %v", rows.Err())
+	}
+
+	compositeNames := composite.GetCompositeNames(compositeData)
+	knownTypes := append(append([]string{}, userTypes...), compositeNames...)
+
+	for i := range compositeData {
+		attributeType := compositeData[i].AttributeType
+		if attributeType != "" && attributeType[0] == '_' {
+			attributeType = attributeType[1:]
+			compositeData[i].IsArray = true
+		}
+
+		mappedType, err := class.MapPostgreSQLType(attributeType, knownTypes)
+		if err != nil {
+			return "", nil, fmt.Errorf("composite type %s: %v", compositeData[i].Name, err)
+		}
+		compositeData[i].AttributeType = mappedType
+	}
+
+	return composite.GenerateCompositeSchema(compositeData), compositeNames, nil
+}
+
+func GetDomains(client *pgxpool.Pool, ctx context.Context) (string, map[string]string, error) {
+	var domainData []domain.DomainData
+	domainBases := make(map[string]string)
+
+	rows, err := client.Query(ctx, constants.ALL_DOMAINS_QUERY)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to fetch available domains: %v", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var domainName, baseType string
+		var domainDefault, checkDefinition *string
+		var characterLength, numericPrecision, numericScale, datetimePrecision *int32
+		if err := rows.Scan(&domainName, &baseType, &domainDefault, &characterLength, &numericPrecision, &numericScale, &datetimePrecision, &checkDefinition); err != nil {
+			return "", nil, fmt.Errorf("scan domain row error: %v", err)
+		}
+
+		mappedType, err := class.MapPostgreSQLType(baseType, nil)
+		if err != nil {
+			return "", nil, fmt.Errorf("domain %s: %v", domainName, err)
+		}
+
+		data := domain.DomainData{
+			Name:       domainName,
+			BaseType:   mappedType,
+			NativeType: class.ResolveNativeType(baseType, characterLength, numericPrecision, numericScale, datetimePrecision),
+		}
+
+		if data.NativeType != nil && data.NativeType.Name == constants.NATIVE_TYPE_CHAR {
+			data.BaseType = constants.STRING.String()
+		}
+
+		if defaultVal := cleanColumnDefault(domainDefault); defaultVal != "null" {
+			data.Default = class.FormatDefault(data.BaseType, defaultVal)
+		}
+
+		if checkDefinition != nil {
+			data.Check = domain.CheckExpression(*checkDefinition)
+		}
+
+		domainData = append(domainData, data)
+		domainBases[domainName] = data.BaseType
+	}
+
+	if rows.Err() != nil {
+		return "", nil, fmt.Errorf("row iteration error: %v", rows.Err())
+	}
+
+	return domain.GenerateDomainSchema(domainData), domainBases, nil
+}
+
+func cleanColumnDefault(columnDefault *string) string {
+	defaultVal := "null"
+	if columnDefault != nil {
+		defaultVal = *columnDefault
+	}
+	defaultVal = strings.TrimSpace(strings.ReplaceAll(defaultVal, " ", "_"))
+
+	enumCastRegex := regexp.MustCompile(`^'([^']*)'::.*`)
+	if matches := enumCastRegex.FindStringSubmatch(defaultVal); len(matches) > 1 {
+		defaultVal = matches[1]
+	}
+	return defaultVal
+}
+
+func GetClasses(client *pgxpool.Pool, ctx context.Context, enums []string, domains map[string]string) (string, error) {
 	var classData []class.ClassData
 	rows, err := client.Query(ctx, constants.FETCH_AVAILABLE_TABLES)
 	if err != nil {
@@ -81,15 +217,17 @@ func GetClasses(client *pgxpool.Pool, ctx context.Context, enums []string) (stri
 		var tableData class.ClassData
 		tableData.Name = tableName
 
-		columnRows, err := client.Query(ctx, constants.TableTypes(tableName))
+		columnRows, err := client.Query(ctx, constants.TABLE_TYPES_QUERY, tableName)
 		if err != nil {
 			return "", fmt.Errorf("failed to fetch columns for table %s: %v", tableName, err)
 		}
 		for columnRows.Next() {
 			var columnName, baseType, isNullable string
-			var columnDefault *string
+			var columnDefault, generationExpression, ownedSequence, domainName *string
 			var ordinalPosition int8
-			if err := columnRows.Scan(&columnName, &baseType, &isNullable, &columnDefault, &ordinalPosition); err != nil {
+			var isIdentity bool
+			var characterLength, numericPrecision, numericScale, datetimePrecision *int32
+			if err := columnRows.Scan(&columnName, &baseType, &isNullable, &columnDefault, &ordinalPosition, &generationExpression, &isIdentity, &ownedSequence, &characterLength, &numericPrecision, &numericScale, &datetimePrecision, &domainName); err != nil {
 				columnRows.Close()
 				return "", fmt.Errorf("scan column row error for table %s: %v", tableName, err)
 			}
@@ -102,16 +240,23 @@ func GetClasses(client *pgxpool.Pool, ctx context.Context, enums []string) (stri
 			}
 
 			nullable := isNullable == "YES"
-			defaultVal := "null"
-			if columnDefault != nil {
-				defaultVal = *columnDefault
-			}
-			defaultVal = strings.TrimSpace(strings.ReplaceAll(defaultVal, " ", "_"))
+			defaultVal := cleanColumnDefault(columnDefault)
 			cleanDataType := strings.ReplaceAll(baseType, " ", "_")
 
-			enumCastRegex := regexp.MustCompile(`^'([^']*)'::.*`)
-			if matches := enumCastRegex.FindStringSubmatch(defaultVal); len(matches) > 1 {
-				defaultVal = matches[1]
+			nativeType := class.ResolveNativeType(cleanDataType, characterLength, numericPrecision, numericScale, datetimePrecision)
+			if domainName != nil {
+				cleanDataType = *domainName
+				nativeType = nil
+			}
+
+			computed := ""
+			if generationExpression != nil {
+				computed = strings.TrimSpace(*generationExpression)
+			}
+
+			owned := ""
+			if ownedSequence != nil {
+				owned = *ownedSequence
 			}
 
 			column := class.Column{
@@ -121,6 +266,10 @@ func GetClasses(client *pgxpool.Pool, ctx context.Context, enums []string) (stri
 				ColumnDefault:   defaultVal,
 				OrdinalPosition: ordinalPosition,
 				IsArray:         isArray,
+				Computed:        computed,
+				IsIdentity:      isIdentity,
+				OwnedSequence:   owned,
+				NativeType:      nativeType,
 			}
 			tableData.Columns = append(tableData.Columns, column)
 		}
@@ -130,7 +279,7 @@ func GetClasses(client *pgxpool.Pool, ctx context.Context, enums []string) (stri
 		}
 		columnRows.Close()
 
-		constraintRows, err := client.Query(ctx, constants.TableConstraints(tableName))
+		constraintRows, err := client.Query(ctx, constants.TABLE_CONSTRAINTS_QUERY, tableName)
 		if err != nil {
 			return "", fmt.Errorf("failed to fetch constraints for table %s: %v", tableName, err)
 		}
@@ -163,14 +312,14 @@ func GetClasses(client *pgxpool.Pool, ctx context.Context, enums []string) (stri
 			tableData.Constraints = append(tableData.Constraints, *constraint)
 		}
 
-		indexRows, err := client.Query(ctx, constants.TableIndexes(tableName))
+		indexRows, err := client.Query(ctx, constants.TABLE_INDEXES_QUERY, tableName)
 		if err != nil {
 			return "", fmt.Errorf("failed to fetch indexes for table %s: %v", tableName, err)
 		}
 		for indexRows.Next() {
-			var indexName, columns string
+			var indexName, columns, definition string
 			var isUnique, isPrimary bool
-			if err := indexRows.Scan(&indexName, &isUnique, &isPrimary, &columns); err != nil {
+			if err := indexRows.Scan(&indexName, &isUnique, &isPrimary, &columns, &definition); err != nil {
 				indexRows.Close()
 				return "", fmt.Errorf("scan index row error for table %s: %v", tableName, err)
 			}
@@ -185,10 +334,11 @@ func GetClasses(client *pgxpool.Pool, ctx context.Context, enums []string) (stri
 			}
 
 			index := class.Index{
-				Name:      indexName,
-				IsUnique:  isUnique,
-				IsPrimary: isPrimary,
-				Fields:    cleanFields,
+				Name:       indexName,
+				IsUnique:   isUnique,
+				IsPrimary:  isPrimary,
+				Fields:     cleanFields,
+				Definition: definition,
 			}
 			tableData.Indexes = append(tableData.Indexes, index)
 		}
@@ -198,7 +348,7 @@ func GetClasses(client *pgxpool.Pool, ctx context.Context, enums []string) (stri
 		}
 		indexRows.Close()
 
-		relationRows, err := client.Query(ctx, constants.TableRelations(tableName))
+		relationRows, err := client.Query(ctx, constants.TABLE_RELATIONS_QUERY, tableName)
 		if err != nil {
 			return "", fmt.Errorf("failed to fetch relations for table %s: %v", tableName, err)
 		}
@@ -233,9 +383,215 @@ func GetClasses(client *pgxpool.Pool, ctx context.Context, enums []string) (stri
 			tableData.Relations = append(tableData.Relations, *relation)
 		}
 
+		triggerRows, err := client.Query(ctx, constants.TABLE_UPDATED_AT_TRIGGERS_QUERY, tableName)
+		if err != nil {
+			return "", fmt.Errorf("failed to fetch triggers for table %s: %v", tableName, err)
+		}
+		triggerArgRegex := regexp.MustCompile(constants.UPDATED_AT_FUNCTION_NAME + `\('([^']+)'`)
+		for triggerRows.Next() {
+			var triggerName, definition string
+			if err := triggerRows.Scan(&triggerName, &definition); err != nil {
+				triggerRows.Close()
+				return "", fmt.Errorf("scan trigger row error for table %s: %v", tableName, err)
+			}
+
+			if matches := triggerArgRegex.FindStringSubmatch(definition); matches != nil {
+				tableData.UpdatedAtColumns = append(tableData.UpdatedAtColumns, matches[1])
+			}
+		}
+		if err := triggerRows.Err(); err != nil {
+			triggerRows.Close()
+			return "", fmt.Errorf("row iteration error for table %s: %v", tableName, err)
+		}
+		triggerRows.Close()
+
+		exclusionRows, err := client.Query(ctx, constants.TABLE_EXCLUSIONS_QUERY, tableName)
+		if err != nil {
+			return "", fmt.Errorf("failed to fetch exclusion constraints for table %s: %v", tableName, err)
+		}
+		for exclusionRows.Next() {
+			var constraintName, definition string
+			if err := exclusionRows.Scan(&constraintName, &definition); err != nil {
+				exclusionRows.Close()
+				return "", fmt.Errorf("scan exclusion row error for table %s: %v", tableName, err)
+			}
+
+			exclusion, _, err := directives.ParseExclusionSQL(definition)
+			if err != nil {
+				continue
+			}
+			if exclusion.GetName(tableName) != constraintName {
+				exclusion.Name = constraintName
+			}
+			tableData.Exclusions = append(tableData.Exclusions, exclusion)
+		}
+		if err := exclusionRows.Err(); err != nil {
+			exclusionRows.Close()
+			return "", fmt.Errorf("row iteration error for table %s: %v", tableName, err)
+		}
+		exclusionRows.Close()
+
+		if err := client.QueryRow(ctx, constants.TABLE_ROW_SECURITY_QUERY, tableName).Scan(&tableData.RowSecurity); err != nil {
+			return "", fmt.Errorf("failed to fetch row security for table %s: %v", tableName, err)
+		}
+
+		policyRows, err := client.Query(ctx, constants.TABLE_POLICIES_QUERY, tableName)
+		if err != nil {
+			return "", fmt.Errorf("failed to fetch policies for table %s: %v", tableName, err)
+		}
+		for policyRows.Next() {
+			var policyName, command string
+			var roles []string
+			var usingExpression, checkExpression *string
+			if err := policyRows.Scan(&policyName, &command, &roles, &usingExpression, &checkExpression); err != nil {
+				policyRows.Close()
+				return "", fmt.Errorf("scan policy row error for table %s: %v", tableName, err)
+			}
+
+			policy := &directives.Policy{
+				Name:    policyName,
+				Command: directives.PolicyCommandFromSQL(command),
+				Roles:   directives.NormalizePolicyRoles(roles),
+			}
+			if usingExpression != nil {
+				policy.Using = *usingExpression
+			}
+			if checkExpression != nil {
+				policy.Check = *checkExpression
+			}
+			tableData.Policies = append(tableData.Policies, policy)
+		}
+		if err := policyRows.Err(); err != nil {
+			policyRows.Close()
+			return "", fmt.Errorf("row iteration error for table %s: %v", tableName, err)
+		}
+		policyRows.Close()
+
+		var partitionKey *string
+		if err := client.QueryRow(ctx, constants.TABLE_PARTITION_KEY_QUERY, tableName).Scan(&partitionKey); err != nil {
+			return "", fmt.Errorf("failed to fetch partition key for table %s: %v", tableName, err)
+		}
+		if partitionKey != nil {
+			partitionBy, err := directives.ParsePartitionKeySQL(*partitionKey)
+			if err != nil {
+				return "", fmt.Errorf("failed to parse partition key for table %s: %v", tableName, err)
+			}
+			tableData.PartitionBy = partitionBy
+
+			partitionRows, err := client.Query(ctx, constants.TABLE_PARTITIONS_QUERY, tableName)
+			if err != nil {
+				return "", fmt.Errorf("failed to fetch partitions for table %s: %v", tableName, err)
+			}
+			for partitionRows.Next() {
+				var partitionName, bound string
+				if err := partitionRows.Scan(&partitionName, &bound); err != nil {
+					partitionRows.Close()
+					return "", fmt.Errorf("scan partition row error for table %s: %v", tableName, err)
+				}
+
+				partition, err := directives.ParsePartitionBoundSQL(partitionName, bound)
+				if err != nil {
+					partitionRows.Close()
+					return "", fmt.Errorf("failed to parse partition %s of table %s: %v", partitionName, tableName, err)
+				}
+				tableData.Partitions = append(tableData.Partitions, partition)
+			}
+			if err := partitionRows.Err(); err != nil {
+				partitionRows.Close()
+				return "", fmt.Errorf("row iteration error for table %s: %v", tableName, err)
+			}
+			partitionRows.Close()
+		}
+
 		classData = append(classData, tableData)
 	}
 
-	classSchema := class.GenerateClassSchema(classData, enums)
+	classSchema := class.GenerateClassSchema(classData, enums, domains)
 	return classSchema, nil
-}
\ No newline at end of file
+}
+
+func GetViews(client *pgxpool.Pool, ctx context.Context, userTypes []string) (string, error) {
+	var viewData []view.ViewData
+	viewIndex := make(map[string]int)
+
+	rows, err := client.Query(ctx, constants.ALL_VIEWS_QUERY)
+	if err != nil {
+		return "", fmt.Errorf("failed to fetch available views: %v", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var data view.ViewData
+		if err := rows.Scan(&data.Name, &data.Materialized, &data.Definition); err != nil {
+			return "", fmt.Errorf("scan view row error: %v", err)
+		}
+		viewIndex[data.Name] = len(viewData)
+		viewData = append(viewData, data)
+	}
+
+	if rows.Err() != nil {
+		return "", fmt.Errorf("row iteration error: %v", rows.Err())
+	}
+
+	if len(viewData) == 0 {
+		return "", nil
+	}
+
+	columnRows, err := client.Query(ctx, constants.ALL_VIEW_COLUMNS_QUERY)
+	if err != nil {
+		return "", fmt.Errorf("failed to fetch view columns: %v", err)
+	}
+	defer columnRows.Close()
+
+	for columnRows.Next() {
+		var viewName string
+		var column view.ViewColumn
+		if err := columnRows.Scan(&viewName, &column.Name, &column.DataType, &column.Position); err != nil {
+			return "", fmt.Errorf("scan view column row error: %v", err)
+		}
+
+		i, exists := viewIndex[viewName]
+		if !exists {
+			continue
+		}
+
+		if column.DataType != "" && column.DataType[0] == '_' {
+			column.DataType = column.DataType[1:]
+			column.IsArray = true
+		}
+
+		mappedType, err := class.MapPostgreSQLType(column.DataType, userTypes)
+		if err != nil {
+			return "", fmt.Errorf("view %s: %v", viewName, err)
+		}
+		column.DataType = mappedType
+
+		viewData[i].Columns = append(viewData[i].Columns, column)
+	}
+
+	if columnRows.Err() != nil {
+		return "", fmt.Errorf("row iteration error: %v", columnRows.Err())
+	}
+
+	indexRows, err := client.Query(ctx, constants.ALL_MATERIALIZED_VIEW_INDEXES_QUERY)
+	if err != nil {
+		return "", fmt.Errorf("failed to fetch materialized view indexes: %v", err)
+	}
+	defer indexRows.Close()
+
+	for indexRows.Next() {
+		var viewName, indexName, definition string
+		if err := indexRows.Scan(&viewName, &indexName, &definition); err != nil {
+			return "", fmt.Errorf("scan materialized view index row error: %v", err)
+		}
+		if i, exists := viewIndex[viewName]; exists {
+			viewData[i].Indexes = append(viewData[i].Indexes, definition)
+		}
+	}
+
+	if indexRows.Err() != nil {
+		return "", fmt.Errorf("row iteration error: %v", indexRows.Err())
+	}
+
+	return view.GenerateViewSchema(viewData), nil
+}
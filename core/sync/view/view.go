@@ -0,0 +1,106 @@
+package view
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/rit3sh-x/blaze/core/ast/class/directives"
+	astview "github.com/rit3sh-x/blaze/core/ast/view"
+	"github.com/rit3sh-x/blaze/core/constants"
+)
+
+type ViewColumn struct {
+	Name     string
+	DataType string
+	IsArray  bool
+	Position int32
+}
+
+type ViewData struct {
+	Name         string
+	Materialized bool
+	Definition   string
+	Columns      []ViewColumn
+	Indexes      []string
+}
+
+var uniqueIndexRegex = regexp.MustCompile(`(?i)^CREATE\s+UNIQUE\s`)
+
+func GenerateViewSchema(viewData []ViewData) string {
+	if len(viewData) == 0 {
+		return ""
+	}
+
+	sort.Slice(viewData, func(i, j int) bool {
+		return viewData[i].Name < viewData[j].Name
+	})
+
+	var blocks []string
+	for _, v := range viewData {
+		blocks = append(blocks, formatView(v))
+	}
+
+	return strings.Join(blocks, "\n\n")
+}
+
+func formatView(v ViewData) string {
+	keyword := constants.KEYWORD_VIEW
+	if v.Materialized {
+		keyword = constants.KEYWORD_MATERIALIZED_VIEW
+	}
+
+	var builder strings.Builder
+	builder.WriteString(fmt.Sprintf("%s %s {\n", keyword, v.Name))
+
+	columns := append([]ViewColumn{}, v.Columns...)
+	sort.Slice(columns, func(i, j int) bool {
+		return columns[i].Position < columns[j].Position
+	})
+	for _, column := range columns {
+		columnType := column.DataType
+		if column.IsArray {
+			columnType += "[]"
+		}
+		builder.WriteString(fmt.Sprintf("  %s %s?\n", column.Name, columnType))
+	}
+
+	for _, definition := range v.Indexes {
+		if directive := formatIndex(v.Name, definition); directive != "" {
+			builder.WriteString(fmt.Sprintf("  %s\n", directive))
+		}
+	}
+
+	builder.WriteString(fmt.Sprintf("  @@%s(\"%s\")\n", constants.VIEW_ATTR_SQL, strings.ReplaceAll(CleanDefinition(v.Definition), `"`, `\"`)))
+	builder.WriteString("}")
+	return builder.String()
+}
+
+func formatIndex(viewName string, definition string) string {
+	index, _, isText, err := directives.ParseIndexSQL(definition)
+	if err != nil || isText {
+		return ""
+	}
+
+	viewIndex := &astview.ViewIndex{
+		Unique: uniqueIndexRegex.MatchString(definition),
+		Index:  index,
+	}
+
+	name := index.Name
+	index.Name = ""
+	if (&astview.View{Name: viewName}).IndexName(viewIndex) != name {
+		index.Name = name
+	}
+
+	directive := constants.VIEW_ATTR_INDEX
+	if viewIndex.Unique {
+		directive = constants.VIEW_ATTR_UNIQUE
+	}
+	return fmt.Sprintf("@@%s(%s)", directive, index.String())
+}
+
+func CleanDefinition(definition string) string {
+	return strings.TrimSuffix(strings.Join(strings.Fields(definition), " "), ";")
+}
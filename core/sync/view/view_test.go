@@ -0,0 +1,43 @@
+package view
+
+import "testing"
+
+func TestGenerateViewSchema(t *testing.T) {
+	schema := GenerateViewSchema([]ViewData{
+		{
+			Name:         "UserStats",
+			Materialized: true,
+			Definition:   " SELECT \"Post\".\"authorId\" AS \"userId\",\n    count(*) AS posts\n   FROM \"Post\"\n  GROUP BY \"Post\".\"authorId\";",
+			Columns: []ViewColumn{
+				{Name: "posts", DataType: "BigInt", Position: 2},
+				{Name: "userId", DataType: "Int", Position: 1},
+			},
+			Indexes: []string{
+				`CREATE UNIQUE INDEX idx_userstats_userid_unique ON public."UserStats" USING btree ("userId")`,
+				`CREATE INDEX userstats_by_posts ON public."UserStats" USING btree (posts)`,
+			},
+		},
+		{
+			Name:       "ActiveUser",
+			Definition: ` SELECT id, email FROM "User" WHERE active;`,
+			Columns:    []ViewColumn{{Name: "email", DataType: "String", Position: 2}, {Name: "id", DataType: "Int", Position: 1}, {Name: "tags", DataType: "String", IsArray: true, Position: 3}},
+		},
+	})
+
+	want := "view ActiveUser {\n" +
+		"  id Int?\n" +
+		"  email String?\n" +
+		"  tags String[]?\n" +
+		"  @@sql(\"SELECT id, email FROM \\\"User\\\" WHERE active\")\n" +
+		"}\n\n" +
+		"materializedView UserStats {\n" +
+		"  userId Int?\n" +
+		"  posts BigInt?\n" +
+		"  @@unique([userId])\n" +
+		"  @@index(fields: [posts], name: \"userstats_by_posts\")\n" +
+		"  @@sql(\"SELECT \\\"Post\\\".\\\"authorId\\\" AS \\\"userId\\\", count(*) AS posts FROM \\\"Post\\\" GROUP BY \\\"Post\\\".\\\"authorId\\\"\")\n" +
+		"}"
+	if schema != want {
+		t.Errorf("GenerateViewSchema() =\n%s\nwant\n%s", schema, want)
+	}
+}
@@ -1,9 +1,15 @@
 package utils
 
 import (
+	"sort"
 	"strings"
 
+	"github.com/rit3sh-x/blaze/core/ast/class"
+	classattributes "github.com/rit3sh-x/blaze/core/ast/class/attributes"
+	"github.com/rit3sh-x/blaze/core/ast/composite"
 	"github.com/rit3sh-x/blaze/core/ast/field"
+	fieldattributes "github.com/rit3sh-x/blaze/core/ast/field/attributes"
+	"github.com/rit3sh-x/blaze/core/ast/view"
 	"github.com/rit3sh-x/blaze/core/ast"
 	"github.com/rit3sh-x/blaze/core/constants"
 )
@@ -12,14 +18,10 @@ func GetGoType(field *field.Field, ast *ast.SchemaAST) string {
 	baseType := field.GetBaseType()
 	goType := ""
 
-	if scalarType := GetScalarType(baseType); scalarType != "" {
-		goType = ScalarToGoType(scalarType)
-	} else if ast.GetEnumByName(baseType) != nil {
-		goType = baseType
-	} else if ast.GetClassByName(baseType) != nil {
+	if ast.GetClassByName(baseType) != nil {
 		goType = "*" + baseType
 	} else {
-		goType = "interface{}"
+		goType = TypeNameToGoType(baseType, ast)
 	}
 
 	if field.IsArray() {
@@ -33,6 +35,72 @@ func GetGoType(field *field.Field, ast *ast.SchemaAST) string {
 	return goType
 }
 
+func GetCompositeFieldGoType(fld *composite.CompositeField, ast *ast.SchemaAST) string {
+	goType := TypeNameToGoType(fld.Type, ast)
+
+	if fld.IsArray {
+		goType = "[]" + goType
+	}
+
+	if fld.IsOptional && !strings.HasPrefix(goType, "*") {
+		goType = "*" + goType
+	}
+
+	return goType
+}
+
+func TypeNameToGoType(typeName string, ast *ast.SchemaAST) string {
+	if scalarType := GetScalarType(typeName); scalarType != "" {
+		return ScalarToGoType(scalarType)
+	}
+	if d := ast.GetDomainByName(typeName); d != nil {
+		return ScalarToGoType(d.BaseType)
+	}
+	if ast.GetEnumByName(typeName) != nil || ast.GetCompositeByName(typeName) != nil {
+		return typeName
+	}
+	return "interface{}"
+}
+
+func ViewAsClass(v *view.View, schema *ast.SchemaAST) *class.Class {
+	fields := make([]*field.Field, len(v.Fields))
+	for i, viewField := range v.Fields {
+		kind := constants.FIELD_KIND_SCALAR
+		switch {
+		case schema.GetEnumByName(viewField.Type) != nil:
+			kind = constants.FIELD_KIND_ENUM
+		case schema.GetCompositeByName(viewField.Type) != nil:
+			kind = constants.FIELD_KIND_COMPOSITE
+		case schema.GetDomainByName(viewField.Type) != nil:
+			kind = constants.FIELD_KIND_DOMAIN
+		}
+
+		fields[i] = &field.Field{
+			AttributeDefinition: &fieldattributes.AttributeDefinition{
+				Name:       viewField.Name,
+				DataType:   viewField.Type,
+				Kind:       kind,
+				IsArray:    viewField.IsArray,
+				IsOptional: viewField.IsOptional,
+			},
+			Position: viewField.Position,
+		}
+	}
+
+	return &class.Class{
+		Name:       v.Name,
+		Attributes: &classattributes.ClassAttributes{Fields: fields},
+		Position:   v.Position,
+	}
+}
+
+func ResolveScalarType(typeName string, ast *ast.SchemaAST) string {
+	if d := ast.GetDomainByName(typeName); d != nil {
+		return d.BaseType
+	}
+	return GetScalarType(typeName)
+}
+
 func GetScalarType(typeName string) string {
 	for _, scalarType := range constants.ScalarTypes {
 		if string(scalarType) == typeName {
@@ -62,17 +130,103 @@ func ScalarToGoType(scalarType string) string {
 		return "time.Time"
 	case constants.TIMESTAMP:
 		return "time.Time"
+	case constants.TIMESTAMPTZ, constants.TIME:
+		return "time.Time"
+	case constants.INTERVAL:
+		return "time.Duration"
 	case constants.JSON:
 		return "interface{}"
 	case constants.BYTES:
 		return "[]byte"
 	case constants.CHAR:
 		return "string"
+	case constants.UUID:
+		return "[16]byte"
+	case constants.DECIMAL:
+		return "pgtype.Numeric"
+	case constants.INET, constants.CIDR:
+		return "netip.Prefix"
+	case constants.INT4RANGE:
+		return "pgtype.Range[pgtype.Int4]"
+	case constants.TSTZRANGE:
+		return "pgtype.Range[pgtype.Timestamptz]"
+	default:
+		return "interface{}"
+	}
+}
+
+func RangeElementGoType(scalarType string) string {
+	switch constants.ScalarType(scalarType) {
+	case constants.INT4RANGE:
+		return "int32"
+	case constants.TSTZRANGE:
+		return "time.Time"
 	default:
 		return "interface{}"
 	}
 }
 
+func ScalarGoImport(scalarType string) string {
+	switch constants.ScalarType(scalarType) {
+	case constants.DATE, constants.TIMESTAMP, constants.TIMESTAMPTZ, constants.TIME, constants.INTERVAL:
+		return "time"
+	case constants.DECIMAL, constants.INT4RANGE, constants.TSTZRANGE:
+		return "github.com/jackc/pgx/v5/pgtype"
+	case constants.INET, constants.CIDR:
+		return "net/netip"
+	default:
+		return ""
+	}
+}
+
+func SchemaGoImports(schemaAST *ast.SchemaAST) []string {
+	seen := make(map[string]bool)
+	var imports []string
+	var typeNames []string
+	for _, cls := range schemaAST.Classes {
+		for _, field := range cls.Attributes.Fields {
+			typeNames = append(typeNames, field.GetBaseType())
+		}
+	}
+	for _, v := range schemaAST.Views {
+		for _, fld := range v.Fields {
+			typeNames = append(typeNames, fld.Type)
+		}
+	}
+	for _, compositeType := range schemaAST.Composites {
+		for _, fld := range compositeType.Fields {
+			typeNames = append(typeNames, fld.Type)
+		}
+	}
+	for _, typeName := range typeNames {
+		if imp := ScalarGoImport(ResolveScalarType(typeName, schemaAST)); imp != "" && !seen[imp] {
+			seen[imp] = true
+			imports = append(imports, imp)
+		}
+	}
+	sort.Strings(imports)
+	return imports
+}
+
+func HookGoImports(schemaAST *ast.SchemaAST) []string {
+	imports := SchemaGoImports(schemaAST)
+	for _, imp := range imports {
+		if imp == "time" {
+			return imports
+		}
+	}
+	for _, cls := range schemaAST.Classes {
+		for _, field := range cls.Attributes.Fields {
+			if !field.IsArray() && ResolveScalarType(field.GetBaseType(), schemaAST) == constants.TSTZRANGE.String() {
+				imports = append(imports, "time")
+				sort.Strings(imports)
+				return imports
+			}
+		}
+	}
+	return imports
+}
+
 func ToExportedName(name string) string {
 	if len(name) == 0 {
 		return name
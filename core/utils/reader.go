@@ -6,6 +6,7 @@ import (
 	"regexp"
 	"strings"
 
+	"github.com/rit3sh-x/blaze/core/ast"
 	"github.com/rit3sh-x/blaze/core/constants"
 )
 
@@ -43,31 +44,35 @@ func ReadFileClean(filePath string) (string, error) {
 	return strings.Join(lines, "\n"), nil
 }
 
-func ReadAndSeparateSchema(filePath string) (string, string, error) {
+func ReadAndSeparateSchema(filePath string) (*ast.SchemaContent, error) {
 	content, err := ReadFileClean(filePath)
 	if err != nil {
-		return "", "", err
+		return nil, err
 	}
 
 	if strings.TrimSpace(content) == "" {
-		return "", "", nil
+		return &ast.SchemaContent{}, nil
 	}
 
-	enumContent, classContent := separateEnumsAndClassesWithRegex(content)
-	return enumContent, classContent, nil
+	return SeparateSchemaBlocks(content), nil
 }
 
-func separateEnumsAndClassesWithRegex(content string) (string, string) {
+func SeparateSchemaBlocks(content string) *ast.SchemaContent {
     enumPattern := regexp.MustCompile(`(?s)` + constants.KEYWORD_ENUM + `\s+[A-Z][a-zA-Z0-9_]{0,63}\s*\{[^{}]*(?:\{[^{}]*\}[^{}]*)*\}`)
+    sequencePattern := regexp.MustCompile(`(?m)^` + constants.KEYWORD_SEQUENCE + `\s+[a-zA-Z][a-zA-Z0-9_]{0,62}\s*\{[^{}]*\}`)
+    typePattern := regexp.MustCompile(`(?m)^` + constants.KEYWORD_TYPE + `\s+[A-Za-z_][A-Za-z0-9_]*\s*\{[^{}]*\}`)
+    domainPattern := regexp.MustCompile(`(?m)^` + constants.KEYWORD_DOMAIN + `\s+[A-Za-z_][A-Za-z0-9_]*\s*=.*$`)
+    viewPattern := regexp.MustCompile(`(?m)^(?:` + constants.KEYWORD_VIEW + `|` + constants.KEYWORD_MATERIALIZED_VIEW + `)\s+[A-Za-z_][A-Za-z0-9_]*\s*\{(?:[^{}"]|"(?:[^"\\]|\\.)*")*\}(?:\s*@@` + constants.VIEW_ATTR_SQL + `\("(?:[^"\\]|\\.)*"\))?`)
     classPattern := regexp.MustCompile(`(?s)` + constants.KEYWORD_CLASS + `\s+[A-Z][a-zA-Z0-9_]{0,63}\s*\{[^{}]*(?:\{[^{}]*\}[^{}]*)*\}`)
 
-    enumMatches := enumPattern.FindAllString(content, -1)
-    classMatches := classPattern.FindAllString(content, -1)
-
-    enumContent := strings.Join(enumMatches, "\n\n")
-    classContent := strings.Join(classMatches, "\n\n")
-
-    return enumContent, classContent
+    return &ast.SchemaContent{
+        Enums:     strings.Join(enumPattern.FindAllString(content, -1), "\n\n"),
+        Sequences: strings.Join(sequencePattern.FindAllString(content, -1), "\n\n"),
+        Types:     strings.Join(typePattern.FindAllString(content, -1), "\n\n"),
+        Domains:   strings.Join(domainPattern.FindAllString(content, -1), "\n"),
+        Views:     strings.Join(viewPattern.FindAllString(content, -1), "\n\n"),
+        Classes:   strings.Join(classPattern.FindAllString(content, -1), "\n\n"),
+    }
 }
 
 func collapseSpaces(s string) string {
@@ -45,8 +45,12 @@ func (sv *SchemaValidator) ValidateSchema() error {
 
 	sv.validateUniqueNames()
 	sv.validateClassEnumNameConflicts()
+	sv.validateSequences()
+	sv.validateUserTypes()
+	sv.validateViews()
 	sv.validateFieldTypes()
 	sv.validatePrimaryKeys()
+	sv.validatePartitions()
 	sv.validateAndNameRelationsAndIndexes()
 	sv.validateForeignKeyUniqueness()
 	sv.validateRelationConsistency()
@@ -108,6 +112,151 @@ func (sv *SchemaValidator) validateClassEnumNameConflicts() {
 	}
 }
 
+func (sv *SchemaValidator) validateSequences() {
+	for name := range sv.ast.Sequences {
+		if sv.ast.GetClassByName(name) != nil {
+			sv.addError("NAME_CONFLICT",
+				fmt.Sprintf("Sequence name '%s' conflicts with class name", name),
+				fmt.Sprintf("sequence '%s'", name))
+		}
+	}
+
+	for _, cls := range sv.ast.Classes {
+		for _, fld := range cls.Attributes.Fields {
+			if !fld.HasDefault() {
+				continue
+			}
+			defaultValue := fld.AttributeDefinition.DefaultValue
+
+			if defaultValue.IsSequence() {
+				sequenceName := fmt.Sprintf("%v", defaultValue.Value)
+				if sv.ast.GetSequenceByName(sequenceName) == nil {
+					sv.addError("UNKNOWN_SEQUENCE",
+						fmt.Sprintf("Field '%s' uses undefined sequence '%s'", fld.GetName(), sequenceName),
+						fmt.Sprintf("class '%s', field '%s'", cls.Name, fld.GetName()))
+				}
+			}
+
+			if defaultValue.IsCallback() && defaultValue.Value == constants.DEFAULT_AUTOINCREMENT_CALLBACK {
+				ownedName := fmt.Sprintf(constants.OWNED_SEQUENCE_NAME, cls.Name, fld.GetName())
+				if sv.ast.GetSequenceByName(ownedName) != nil {
+					sv.addError("NAME_CONFLICT",
+						fmt.Sprintf("Sequence name '%s' is reserved for autoincrement on field '%s'", ownedName, fld.GetName()),
+						fmt.Sprintf("sequence '%s'", ownedName))
+				}
+			}
+		}
+	}
+}
+
+func (sv *SchemaValidator) validateUserTypes() {
+	for name := range sv.ast.Composites {
+		if sv.ast.GetClassByName(name) != nil || sv.ast.GetEnumByName(name) != nil || sv.ast.GetDomainByName(name) != nil {
+			sv.addError("NAME_CONFLICT",
+				fmt.Sprintf("Type name '%s' conflicts with another class, enum or domain", name),
+				fmt.Sprintf("type '%s'", name))
+		}
+	}
+
+	for name := range sv.ast.Domains {
+		if sv.ast.GetClassByName(name) != nil || sv.ast.GetEnumByName(name) != nil {
+			sv.addError("NAME_CONFLICT",
+				fmt.Sprintf("Domain name '%s' conflicts with another class or enum", name),
+				fmt.Sprintf("domain '%s'", name))
+		}
+	}
+
+	for name, compositeType := range sv.ast.Composites {
+		for _, fld := range compositeType.Fields {
+			if constants.IsScalarType(fld.Type) || sv.ast.GetEnumByName(fld.Type) != nil || sv.ast.GetDomainByName(fld.Type) != nil {
+				continue
+			}
+
+			if sv.ast.GetCompositeByName(fld.Type) != nil {
+				if sv.compositeReaches(fld.Type, name, make(map[string]bool)) {
+					sv.addError("CIRCULAR_TYPE",
+						fmt.Sprintf("Type '%s' contains itself through field '%s'", name, fld.Name),
+						fmt.Sprintf("type '%s', field '%s'", name, fld.Name))
+				}
+				continue
+			}
+
+			sv.addError("INVALID_TYPE",
+				fmt.Sprintf("Unknown type '%s' for field '%s'", fld.Type, fld.Name),
+				fmt.Sprintf("type '%s', field '%s'", name, fld.Name))
+		}
+	}
+}
+
+func (sv *SchemaValidator) compositeReaches(from string, target string, visited map[string]bool) bool {
+	if from == target {
+		return true
+	}
+	if visited[from] {
+		return false
+	}
+	visited[from] = true
+
+	compositeType := sv.ast.GetCompositeByName(from)
+	if compositeType == nil {
+		return false
+	}
+	for _, fld := range compositeType.Fields {
+		if sv.compositeReaches(fld.Type, target, visited) {
+			return true
+		}
+	}
+	return false
+}
+
+func (sv *SchemaValidator) validateViews() {
+	for _, v := range sv.ast.GetViewOrder() {
+		if sv.ast.GetClassByName(v.Name) != nil || sv.ast.GetEnumByName(v.Name) != nil || sv.ast.GetCompositeByName(v.Name) != nil || sv.ast.GetDomainByName(v.Name) != nil {
+			sv.addError("NAME_CONFLICT",
+				fmt.Sprintf("View name '%s' conflicts with another class, enum, type or domain", v.Name),
+				fmt.Sprintf("%s '%s'", v.Keyword(), v.Name))
+		}
+
+		for _, fld := range v.Fields {
+			if constants.IsScalarType(fld.Type) || sv.ast.GetEnumByName(fld.Type) != nil || sv.ast.GetCompositeByName(fld.Type) != nil || sv.ast.GetDomainByName(fld.Type) != nil {
+				continue
+			}
+			sv.addError("INVALID_TYPE",
+				fmt.Sprintf("Unknown type '%s' for field '%s'", fld.Type, fld.Name),
+				fmt.Sprintf("%s '%s', field '%s'", v.Keyword(), v.Name, fld.Name))
+		}
+
+		for _, dependency := range sv.ast.GetViewDependencies(v) {
+			if dependency != v.Name && sv.ast.GetViewByName(dependency) != nil && sv.viewReaches(dependency, v.Name, make(map[string]bool)) {
+				sv.addError("CIRCULAR_VIEW",
+					fmt.Sprintf("View '%s' depends on itself through view '%s'", v.Name, dependency),
+					fmt.Sprintf("%s '%s'", v.Keyword(), v.Name))
+			}
+		}
+	}
+}
+
+func (sv *SchemaValidator) viewReaches(from string, target string, visited map[string]bool) bool {
+	if from == target {
+		return true
+	}
+	if visited[from] {
+		return false
+	}
+	visited[from] = true
+
+	v := sv.ast.GetViewByName(from)
+	if v == nil {
+		return false
+	}
+	for _, dependency := range sv.ast.GetViewDependencies(v) {
+		if sv.viewReaches(dependency, target, visited) {
+			return true
+		}
+	}
+	return false
+}
+
 func (sv *SchemaValidator) validateFieldTypes() {
 	for _, cls := range sv.ast.Classes {
 		for _, fld := range cls.Attributes.Fields {
@@ -125,6 +274,10 @@ func (sv *SchemaValidator) validateFieldTypes() {
 				continue
 			}
 
+			if sv.ast.GetCompositeByName(baseType) != nil || sv.ast.GetDomainByName(baseType) != nil {
+				continue
+			}
+
 			sv.addError("INVALID_TYPE",
 				fmt.Sprintf("Unknown type '%s' for field '%s'", baseType, fld.GetName()),
 				fmt.Sprintf("class '%s', field '%s'", cls.Name, fld.GetName()))
@@ -180,6 +333,113 @@ func (sv *SchemaValidator) validatePrimaryKeys() {
 	}
 }
 
+func (sv *SchemaValidator) validatePartitions() {
+	for _, cls := range sv.ast.Classes {
+		for _, partition := range cls.Attributes.GetPartitions() {
+			if sv.ast.GetClassByName(partition.Name) != nil || sv.ast.GetViewByName(partition.Name) != nil {
+				sv.addError("NAME_CONFLICT",
+					fmt.Sprintf("Partition name '%s' conflicts with another class or view", partition.Name),
+					fmt.Sprintf("class '%s', partition '%s'", cls.Name, partition.Name))
+			}
+		}
+
+		partitionBy := cls.Attributes.GetPartitionBy()
+		if partitionBy == nil {
+			continue
+		}
+
+		keyColumns := make(map[string]bool)
+		for _, column := range partitionBy.Columns {
+			keyColumns[column] = true
+
+			fld := cls.Attributes.GetFieldByName(column)
+			if fld == nil {
+				continue
+			}
+
+			if fld.HasRelation() || fld.IsArray() {
+				sv.addError("INVALID_PARTITION_KEY",
+					fmt.Sprintf("Partition key field '%s' must be a scalar column", column),
+					fmt.Sprintf("class '%s', field '%s'", cls.Name, column))
+			}
+
+			if cls.HasPrimaryKey() && fld.IsOptional() {
+				sv.addError("OPTIONAL_PARTITION_KEY",
+					fmt.Sprintf("Partition key field '%s' cannot be optional because it is part of the primary key", column),
+					fmt.Sprintf("class '%s', field '%s'", cls.Name, column))
+			}
+
+			if partitionBy.Interval != "" {
+				baseType := fld.GetBaseType()
+				if d := sv.ast.GetDomainByName(baseType); d != nil {
+					baseType = d.BaseType
+				}
+				if baseType != constants.DATE.String() && baseType != constants.TIMESTAMP.String() && baseType != constants.TIMESTAMPTZ.String() {
+					sv.addError("INVALID_PARTITION_KEY",
+						fmt.Sprintf("Interval partitioning requires a Date, Timestamp or TimestampTz column, got '%s'", fld.GetBaseType()),
+						fmt.Sprintf("class '%s', field '%s'", cls.Name, column))
+				}
+			}
+		}
+
+		for _, fld := range cls.Attributes.Fields {
+			if fld.IsUnique() && !fld.IsPrimaryKey() && (len(keyColumns) != 1 || !keyColumns[fld.GetName()]) {
+				sv.addError("UNIQUE_WITHOUT_PARTITION_KEY",
+					fmt.Sprintf("Unique field '%s' must include the partition key [%s]; use @@unique with the partition columns instead", fld.GetName(), strings.Join(partitionBy.Columns, ", ")),
+					fmt.Sprintf("class '%s', field '%s'", cls.Name, fld.GetName()))
+			}
+		}
+
+		if uniqueDirective := cls.Attributes.GetUniqueDirective(); uniqueDirective != nil {
+			if fields, err := uniqueDirective.GetFields(); err == nil && !sv.coversColumns(fields, partitionBy.Columns) {
+				sv.addError("UNIQUE_WITHOUT_PARTITION_KEY",
+					fmt.Sprintf("@@unique([%s]) must include the partition key [%s]", strings.Join(fields, ", "), strings.Join(partitionBy.Columns, ", ")),
+					fmt.Sprintf("class '%s'", cls.Name))
+			}
+		}
+
+		for _, exclusion := range cls.Attributes.GetExclusions() {
+			var equalityFields []string
+			for _, element := range exclusion.Elements {
+				if element.Field != "" && element.Operator == "=" {
+					equalityFields = append(equalityFields, element.Field)
+				}
+			}
+			if !sv.coversColumns(equalityFields, partitionBy.Columns) {
+				sv.addError("EXCLUSION_WITHOUT_PARTITION_KEY",
+					fmt.Sprintf("@@exclude on a partitioned class must compare the partition key [%s] with =", strings.Join(partitionBy.Columns, ", ")),
+					fmt.Sprintf("class '%s', exclusion '%s'", cls.Name, exclusion.GetName(cls.Name)))
+			}
+		}
+
+		for _, other := range sv.ast.Classes {
+			for _, fld := range other.Attributes.Fields {
+				if !fld.HasRelation() || fld.AttributeDefinition.Relation.ToClass != cls.Name {
+					continue
+				}
+				if !sv.coversColumns(fld.AttributeDefinition.Relation.To, partitionBy.Columns) {
+					sv.addError("RELATION_WITHOUT_PARTITION_KEY",
+						fmt.Sprintf("Relation to partitioned class '%s' must reference the partition key [%s]", cls.Name, strings.Join(partitionBy.Columns, ", ")),
+						fmt.Sprintf("class '%s', field '%s'", other.Name, fld.GetName()))
+				}
+			}
+		}
+	}
+}
+
+func (sv *SchemaValidator) coversColumns(fields []string, columns []string) bool {
+	present := make(map[string]bool)
+	for _, fieldName := range fields {
+		present[fieldName] = true
+	}
+	for _, column := range columns {
+		if !present[column] {
+			return false
+		}
+	}
+	return true
+}
+
 func (sv *SchemaValidator) validateAndNameRelationsAndIndexes() {
 	sv.nameRelations()
 	sv.nameIndexes()
@@ -211,7 +471,10 @@ func (sv *SchemaValidator) nameIndexes() {
 			switch directive.Name {
 			case constants.CLASS_ATTR_INDEX, constants.CLASS_ATTR_TEXT_INDEX:
 				if directive.PseudoName == "" {
-					values := directive.Value.([]string)
+					values, err := directive.GetFields()
+					if err != nil {
+						continue
+					}
 					indexName := sv.generateIndexName(cls.Name, values)
 					directive.PseudoName = indexName
 				}
@@ -324,7 +587,7 @@ func (sv *SchemaValidator) validateForeignKeyUniqueness() {
 
 			for _, directive := range targetClass.Attributes.Directives {
 				if directive.Name == constants.CLASS_ATTR_INDEX {
-					if indexFields, ok := directive.Value.([]string); ok {
+					if indexFields, err := directive.GetFields(); err == nil {
 						if sv.sliceEqual(referencedFields, indexFields) {
 							hasCorrespondingUnique := false
 
@@ -371,7 +634,7 @@ func (sv *SchemaValidator) validateForeignKeyUniqueness() {
 						hasIndex := false
 						for _, directive := range targetClass.Attributes.Directives {
 							if directive.Name == constants.CLASS_ATTR_INDEX {
-								if indexFields, ok := directive.Value.([]string); ok {
+								if indexFields, err := directive.GetFields(); err == nil {
 									if len(indexFields) == 1 && indexFields[0] == fieldName {
 										hasIndex = true
 										break
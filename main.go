@@ -7,7 +7,7 @@ import (
 )
 
 func main() {
-	tree, err := ast.BuildSchemaAST("", "")
+	tree, err := ast.BuildSchemaAST(nil)
 	if err != nil {
 		fmt.Println("error")
 	}